@@ -7,13 +7,16 @@ package reflect_test
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"flag"
 	"fmt"
+	"hash/maphash"
 	"io"
 	"math"
 	"math/rand"
 	"os"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -207,7 +210,7 @@ var valueTests = []pair{
 	{i: new(**int8), s: "**int8(0)"},
 	{i: new([5]int32), s: "[5]int32{0, 0, 0, 0, 0}"},
 	{i: new(**integer), s: "**reflect_test.integer(0)"},
-	{i: new(map[string]int32), s: "map[string]int32{<can't iterate on maps>}"},
+	{i: new(map[string]int32), s: "map[string]int32{}"},
 	{i: new(chan<- string), s: "chan<- string"},
 	{i: new(func(a int8, b int32)), s: "func(int8, int32)(0)"},
 	{i: new(struct {
@@ -336,6 +339,89 @@ func TestSetValue(t *testing.T) {
 	}
 }
 
+func TestWriteTracer(t *testing.T) {
+	type event struct {
+		op  WriteOp
+		typ Type
+	}
+	var got []event
+	SetWriteTracer(func(target Value, ev WriteEvent) {
+		if target.Type() != ev.Type {
+			t.Errorf("%v: target of type %v, event of type %v", ev.Op, target.Type(), ev.Type)
+		}
+		got = append(got, event{ev.Op, ev.Type})
+	})
+	defer SetWriteTracer(nil)
+
+	var want []event
+	for _, tt := range valueTests {
+		v := ValueOf(tt.i).Elem()
+		op := WriteOp(0)
+		switch v.Kind() {
+		case Int, Int8, Int16, Int32, Int64:
+			v.SetInt(8)
+			op = WriteSetInt
+		case Uint, Uint8, Uint16, Uint32, Uint64:
+			v.SetUint(8)
+			op = WriteSetUint
+		case Float32, Float64:
+			v.SetFloat(256.25)
+			op = WriteSetFloat
+		case Complex64, Complex128:
+			v.SetComplex(532.125 + 10i)
+			op = WriteSetComplex
+		case String:
+			v.SetString("stringy cheese")
+			op = WriteSetString
+		case Bool:
+			v.SetBool(true)
+			op = WriteSetBool
+		default:
+			v.SetZero()
+			op = WriteSetZero
+		}
+		want = append(want, event{op, v.Type()})
+	}
+
+	type S struct {
+		N int
+		M map[string]int
+		C chan int
+	}
+	s := &S{M: map[string]int{}, C: make(chan int, 1)}
+	sv := ValueOf(s).Elem()
+	sv.Field(0).Set(ValueOf(1))
+	sv.FieldByName("M").SetMapIndex(ValueOf("k"), ValueOf(2))
+	c := sv.Field(2)
+	c.Send(ValueOf(3))
+	if c.TrySend(ValueOf(4)) {
+		t.Fatal("TrySend on full channel succeeded")
+	}
+	c.Close()
+	want = append(want,
+		event{WriteSet, TypeOf(0)},
+		event{WriteSetMapIndex, TypeOf(s.M)},
+		event{WriteSend, TypeOf(s.C)},
+		event{WriteClose, TypeOf(s.C)},
+	)
+	if s.N != 1 || s.M["k"] != 2 || <-s.C != 3 {
+		t.Fatalf("mutations not applied: %+v", s)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("traced events:\ngot  %v\nwant %v", got, want)
+	}
+
+	SetWriteTracer(nil)
+	got = nil
+	sv.Field(0).SetInt(5)
+	if got != nil {
+		t.Fatalf("traced %v after disabling", got)
+	}
+	if n := testing.AllocsPerRun(100, func() { sv.Field(0).SetInt(6) }); n != 0 && !CrossCheckEnabled() {
+		t.Errorf("SetInt without tracer: %v allocs, want 0", n)
+	}
+}
+
 func TestCanSetField(t *testing.T) {
 	type embed struct{ x, X int }
 	type Embed struct{ x, X int }
@@ -359,6 +445,7 @@ func TestCanSetField(t *testing.T) {
 	type testCase struct {
 		index  []int
 		canSet bool
+		reason string // expected reason from CanSetPath
 	}
 	tests := []struct {
 		val   Value
@@ -366,37 +453,37 @@ func TestCanSetField(t *testing.T) {
 	}{{
 		val: ValueOf(&S1{}),
 		cases: []testCase{
-			{index: []int{0}, canSet: false},
-			{index: []int{0, 0}, canSet: false},
+			{index: []int{0}, canSet: false, reason: "embedded field reflect_test.S1.embed is unexported"},
+			{index: []int{0, 0}, canSet: false, reason: "field reflect_test.embed.x is unexported"},
 			{index: []int{0, 1}, canSet: true},
-			{index: []int{1}, canSet: false},
+			{index: []int{1}, canSet: false, reason: "field reflect_test.S1.x is unexported"},
 			{index: []int{2}, canSet: true},
 		},
 	}, {
 		val: ValueOf(&S2{embed: &embed{}}),
 		cases: []testCase{
-			{index: []int{0}, canSet: false},
-			{index: []int{0, 0}, canSet: false},
+			{index: []int{0}, canSet: false, reason: "embedded field reflect_test.S2.embed is unexported"},
+			{index: []int{0, 0}, canSet: false, reason: "field reflect_test.embed.x is unexported"},
 			{index: []int{0, 1}, canSet: true},
-			{index: []int{1}, canSet: false},
+			{index: []int{1}, canSet: false, reason: "field reflect_test.S2.x is unexported"},
 			{index: []int{2}, canSet: true},
 		},
 	}, {
 		val: ValueOf(&S3{}),
 		cases: []testCase{
 			{index: []int{0}, canSet: true},
-			{index: []int{0, 0}, canSet: false},
+			{index: []int{0, 0}, canSet: false, reason: "field reflect_test.Embed.x is unexported"},
 			{index: []int{0, 1}, canSet: true},
-			{index: []int{1}, canSet: false},
+			{index: []int{1}, canSet: false, reason: "field reflect_test.S3.x is unexported"},
 			{index: []int{2}, canSet: true},
 		},
 	}, {
 		val: ValueOf(&S4{Embed: &Embed{}}),
 		cases: []testCase{
 			{index: []int{0}, canSet: true},
-			{index: []int{0, 0}, canSet: false},
+			{index: []int{0, 0}, canSet: false, reason: "field reflect_test.Embed.x is unexported"},
 			{index: []int{0, 1}, canSet: true},
-			{index: []int{1}, canSet: false},
+			{index: []int{1}, canSet: false, reason: "field reflect_test.S4.x is unexported"},
 			{index: []int{2}, canSet: true},
 		},
 	}}
@@ -414,9 +501,29 @@ func TestCanSetField(t *testing.T) {
 				if got := f.CanSet(); got != tc.canSet {
 					t.Errorf("CanSet() = %v, want %v", got, tc.canSet)
 				}
+				if got, reason := CanSetPath(tt.val, tc.index); got != tc.canSet || reason != tc.reason {
+					t.Errorf("CanSetPath(%v) = %v, %q, want %v, %q", tc.index, got, reason, tc.canSet, tc.reason)
+				}
 			}
 		})
 	}
+
+	// Paths that cannot be walked, or whose root is not addressable.
+	for _, tc := range []struct {
+		root   Value
+		index  []int
+		reason string
+	}{
+		{ValueOf(&S4{}), []int{0, 1}, "embedded field reflect_test.S4.Embed is a nil pointer"},
+		{ValueOf((*S3)(nil)), []int{2}, "root value is a nil *reflect_test.S3"},
+		{ValueOf(S3{}), []int{2}, "root value of type reflect_test.S3 is not addressable"},
+		{ValueOf(S3{}), []int{0, 1}, "root value of type reflect_test.S3 is not addressable"},
+		{ValueOf(&struct{ s S3 }{}).Elem().Field(0), []int{2}, "root value was obtained using an unexported field"},
+	} {
+		if got, reason := CanSetPath(tc.root, tc.index); got || reason != tc.reason {
+			t.Errorf("CanSetPath(%v, %v) = %v, %q, want false, %q", tc.root.Type(), tc.index, got, reason, tc.reason)
+		}
+	}
 }
 
 var _i = 7
@@ -432,6 +539,9 @@ var valueToStringTests = []pair{
 	{i: &[10]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, s: "*[10]int(&[10]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})"},
 	{i: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, s: "[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}"},
 	{i: &[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, s: "*[]int(&[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})"},
+	{i: (*int)(nil), s: "*int(0)"},
+	{i: map[string]map[int]bool{"b": {2: true, 1: false}, "a": nil}, s: "map[string]map[int]bool{a: map[int]bool{}, b: map[int]bool{1: false, 2: true}}"},
+	{i: []any{nil, 1}, s: "[]interface {}{interface {}(<zero Value>), interface {}(1)}"},
 }
 
 func TestValueToString(t *testing.T) {
@@ -443,6 +553,27 @@ func TestValueToString(t *testing.T) {
 	}
 }
 
+func TestStringifyCycle(t *testing.T) {
+	r := &Recursive{x: 1, r: &Recursive{x: 2}}
+	r.r.r = r
+	const want = "*reflect_test.Recursive(&reflect_test.Recursive{1, *reflect_test.Recursive(&reflect_test.Recursive{2, *reflect_test.Recursive(<cycle>)})})"
+	if s := Stringify(ValueOf(r)); s != want {
+		t.Errorf("Stringify(cycle) = %#q, want %#q", s, want)
+	}
+
+	// A value referred to twice without a cycle prints in full.
+	p := &_i
+	if s, want := Stringify(ValueOf([2]*int{p, p})), "[2]*int{*int(&7), *int(&7)}"; s != want {
+		t.Errorf("Stringify(shared) = %#q, want %#q", s, want)
+	}
+
+	m := map[string]any{}
+	m["m"] = m
+	if s, want := Stringify(ValueOf(m)), "map[string]interface {}{m: interface {}(map[string]interface {}(<cycle>))}"; s != want {
+		t.Errorf("Stringify(map cycle) = %#q, want %#q", s, want)
+	}
+}
+
 func TestArrayElemSet(t *testing.T) {
 	v := ValueOf(&[10]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}).Elem()
 	v.Index(4).SetInt(123)
@@ -744,6 +875,50 @@ func TestCopyArray(t *testing.T) {
 	}
 }
 
+func TestCopyArrayPointer(t *testing.T) {
+	var buf [8]byte
+	src := []byte("0123456789")
+	if n := Copy(ValueOf(&buf), ValueOf(src)); n != 8 || string(buf[:]) != "01234567" {
+		t.Errorf("Copy into *[8]byte: n=%d, buf=%q", n, buf[:])
+	}
+	dst := make([]byte, 4)
+	if n := Copy(ValueOf(dst), ValueOf(&buf)); n != 4 || string(dst) != "0123" {
+		t.Errorf("Copy from *[8]byte: n=%d, dst=%q", n, dst)
+	}
+	if n := Copy(ValueOf(&buf), ValueOf("ab")); n != 2 || string(buf[:3]) != "ab2" {
+		t.Errorf("Copy string into *[8]byte: n=%d, buf=%q", n, buf[:])
+	}
+
+	// Same lengths as TestCopyArray, with pointers on both sides.
+	a := [8]int{1, 2, 3, 4, 10, 9, 8, 7}
+	b := [11]int{11, 22, 33, 44, 1010, 99, 88, 77, 66, 55, 44}
+	c := b
+	if n := Copy(ValueOf(&b), ValueOf(&a)); n != len(a) {
+		t.Errorf("Copy(*[11]int, *[8]int) = %d, want %d", n, len(a))
+	}
+	if [8]int(b[:8]) != a || [3]int(b[8:]) != [3]int(c[8:]) {
+		t.Errorf("Copy(*[11]int, *[8]int) gave %v", b)
+	}
+	if n := Copy(ValueOf(&a), ValueOf(&c)); n != len(a) || a != [8]int(c[:8]) {
+		t.Errorf("Copy(*[8]int, *[11]int) = %d, gave %v", n, a)
+	}
+
+	var nilArray *[8]byte
+	shouldPanic(func() { Copy(ValueOf(nilArray), ValueOf(src)) })
+	shouldPanic(func() { Copy(ValueOf(dst), ValueOf(nilArray)) })
+	shouldPanic(func() { Copy(ValueOf(&buf), ValueOf([]int{1})) })
+
+	// As with the builtin copy, a nil pointer to a zero-length array is empty.
+	var nilEmpty *[0]byte
+	if n := Copy(ValueOf(nilEmpty), ValueOf(src)); n != 0 {
+		t.Errorf("Copy into nil *[0]byte = %d, want 0", n)
+	}
+	if n := Copy(ValueOf(dst), ValueOf(nilEmpty)); n != 0 || string(dst) != "0123" {
+		t.Errorf("Copy from nil *[0]byte: n=%d, dst=%q", n, dst)
+	}
+	shouldPanic(func() { Copy(ValueOf(nilEmpty), ValueOf([]int{1})) })
+}
+
 func TestBigUnnamedStruct(t *testing.T) {
 	b := struct{ a, b, c, d int64 }{a: 1, b: 2, c: 3, d: 4}
 	v := ValueOf(b)
@@ -881,6 +1056,171 @@ func TestDeepEqual(t *testing.T) {
 	}
 }
 
+func TestDeepEqualWithOptions(t *testing.T) {
+	// The zero options reproduce DeepEqual.
+	for _, test := range deepEqualTests {
+		if test.b == (self{}) {
+			test.b = test.a
+		}
+		if r := DeepEqualWithOptions(test.a, test.b, DeepEqualOptions{}); r != test.eq {
+			t.Errorf("DeepEqualWithOptions(%v, %v, {}) = %v, want %v", test.a, test.b, r, test.eq)
+		}
+	}
+
+	type reading struct {
+		Name   string
+		Values []float64
+		Z      complex128
+	}
+	type series struct {
+		Readings []reading
+		ByName   map[string]*reading
+	}
+	nan := math.NaN()
+	mk := func(x float64) series {
+		r := reading{Name: "a", Values: []float64{1, x, nan}, Z: complex(x, nan)}
+		return series{Readings: []reading{r}, ByName: map[string]*reading{"a": &r}}
+	}
+	x, y := 0.1, 0.2
+	sum := x + y // 0.30000000000000004
+	nans := DeepEqualOptions{TreatNaNsEqual: true}
+	close := DeepEqualOptions{TreatNaNsEqual: true, FloatTolerance: 1e-9}
+	for _, tc := range []struct {
+		a, b any
+		opts DeepEqualOptions
+		eq   bool
+	}{
+		{[]float64{nan}, []float64{nan}, nans, true},
+		{[]float64{nan}, []float64{1}, nans, false},
+		{[]float64{nan}, []float64{nan}, DeepEqualOptions{FloatTolerance: 1}, false},
+		{[]float32{1}, []float32{1.5}, DeepEqualOptions{FloatTolerance: 0.5}, true},
+		{[]float32{1}, []float32{1.6}, DeepEqualOptions{FloatTolerance: 0.5}, false},
+		{[]any{math.Inf(1)}, []any{math.Inf(1)}, close, true},
+		{[]any{math.Inf(1)}, []any{math.Inf(-1)}, close, false},
+		{mk(sum), mk(0.3), close, true},
+		{mk(sum), mk(0.3), nans, false},
+		{mk(0.3), mk(0.31), close, false},
+		{map[float64]string{nan: "x", 1: "y"}, map[float64]string{nan: "x", 1: "y"}, nans, true},
+		{map[float64]string{nan: "x", nan: "y"}, map[float64]string{nan: "y", nan: "x"}, nans, true},
+		{map[float64]string{nan: "x", nan: "y"}, map[float64]string{nan: "x", nan: "x"}, nans, false},
+		{map[float64]int{sum: 1}, map[float64]int{0.3: 1}, close, true},
+		{map[float64]int{sum: 1}, map[float64]int{0.3: 2}, close, false},
+		{map[float64]int{1: 1, 2: 2}, map[float64]int{1: 1, 3: 2}, close, false},
+		{&loop1, &loop2, close, true},
+	} {
+		if r := DeepEqualWithOptions(tc.a, tc.b, tc.opts); r != tc.eq {
+			t.Errorf("DeepEqualWithOptions(%v, %v, %+v) = %v, want %v", tc.a, tc.b, tc.opts, r, tc.eq)
+		}
+	}
+}
+
+// caseless compares equal to another caseless ignoring case.
+type caseless struct{ s string }
+
+func (c *caseless) Equal(o caseless) bool { return strings.EqualFold(c.s, o.s) }
+
+func TestDeepEqualUseEqualMethod(t *testing.T) {
+	opts := DeepEqualOptions{UseEqualMethod: true}
+	for _, test := range deepEqualTests {
+		if test.b == (self{}) {
+			test.b = test.a
+		}
+		if r := DeepEqualWithOptions(test.a, test.b, opts); r != test.eq {
+			t.Errorf("DeepEqualWithOptions(%v, %v, %+v) = %v, want %v", test.a, test.b, opts, r, test.eq)
+		}
+	}
+
+	utc := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	local := utc.In(time.FixedZone("X", 3600))
+	type event struct {
+		At   time.Time
+		Name caseless
+		Next *caseless
+	}
+	a := event{At: utc, Name: caseless{"Go"}, Next: &caseless{"a"}}
+	b := event{At: local, Name: caseless{"GO"}, Next: &caseless{"A"}}
+	for _, tc := range []struct {
+		a, b any
+		eq   bool
+	}{
+		{utc, local, true},
+		{utc, local.Add(1), false},
+		{[]time.Time{utc}, []time.Time{local}, true},
+		{map[string]any{"t": utc}, map[string]any{"t": local}, true},
+		{a, b, true},
+		{&a, &b, true},
+		{caseless{"x"}, caseless{"y"}, false},
+		{event{}, event{Next: &caseless{}}, false},
+		{utc, struct{ time.Time }{utc}, false},
+	} {
+		if r := DeepEqualWithOptions(tc.a, tc.b, opts); r != tc.eq {
+			t.Errorf("DeepEqualWithOptions(%v, %v, %+v) = %v, want %v", tc.a, tc.b, opts, r, tc.eq)
+		}
+	}
+	if DeepEqualWithOptions(utc, local, DeepEqualOptions{}) {
+		t.Errorf("DeepEqualWithOptions without UseEqualMethod uses Equal")
+	}
+}
+
+func TestDeepDiff(t *testing.T) {
+	for _, test := range deepEqualTests {
+		if test.b == (self{}) {
+			test.b = test.a
+		}
+		if _, ok := DeepDiff(test.a, test.b); ok == test.eq {
+			t.Errorf("DeepDiff(%v, %v) found difference %v, want %v", test.a, test.b, ok, !test.eq)
+		}
+	}
+
+	type inner struct {
+		X int
+		m map[string]*inner
+	}
+	type outer struct {
+		Field []inner
+		P     *int
+	}
+	one := 1
+	mk := func() outer {
+		return outer{
+			Field: []inner{{}, {}, {}, {X: 1, m: map[string]*inner{"key": {X: 2}}}},
+			P:     &one,
+		}
+	}
+	a, b := mk(), mk()
+	b.Field[3].m["key"].X = 3
+	extra := mk()
+	extra.Field = append(extra.Field, inner{})
+	missing := mk()
+	delete(missing.Field[3].m, "key")
+	missing.Field[3].m["other"] = nil
+	nilPtr := mk()
+	nilPtr.P = nil
+	for _, tc := range []struct {
+		a, b any
+		want Difference
+	}{
+		{a, b, Difference{`.Field[3].m["key"].X`, "2", "3"}},
+		{a, extra, Difference{".Field", fmt.Sprintf("%#v", a.Field), fmt.Sprintf("%#v", extra.Field)}},
+		{a, missing, Difference{`.Field[3].m["key"]`, fmt.Sprintf("%#v", a.Field[3].m["key"]), "<missing>"}},
+		{missing, a, Difference{`.Field[3].m["other"]`, "(*reflect_test.inner)(nil)", "<missing>"}},
+		{a, nilPtr, Difference{".P", fmt.Sprintf("(*int)(%p)", &one), "(*int)(nil)"}},
+		{1, "1", Difference{"", "1", `"1"`}},
+		{nil, 1, Difference{"", "nil", "1"}},
+	} {
+		diff, ok := DeepDifference(tc.a, tc.b)
+		if !ok || diff != tc.want {
+			t.Errorf("DeepDifference(%v, %v) = %q, %v, want %q", tc.a, tc.b, diff, ok, tc.want)
+		}
+		if path, ok := DeepDiff(tc.a, tc.b); !ok || path != tc.want.Path {
+			t.Errorf("DeepDiff(%v, %v) = %q, %v, want %q", tc.a, tc.b, path, ok, tc.want.Path)
+		}
+	}
+	if diff, ok := DeepDifference(&loop1, &loop2); ok {
+		t.Errorf("DeepDifference(&loop1, &loop2) = %v", diff)
+	}
+}
+
 func TestTypeOf(t *testing.T) {
 	// Special case for nil
 	if typ := TypeOf(nil); typ != nil {
@@ -903,6 +1243,30 @@ type Recursive struct {
 	r *Recursive
 }
 
+func TestSelfReferentialTypeString(t *testing.T) {
+	tests := []struct {
+		typ  Type
+		want string
+	}{
+		{TypeOf(Loop(nil)), "reflect_test.Loop"},
+		{TypeOf(Loop(nil)).Elem(), "reflect_test.Loop"},
+		{TypeOf(Recursive{}), "reflect_test.Recursive"},
+		{TypeOf(Recursive{}).Field(1).Type, "*reflect_test.Recursive"},
+		{StructOf([]StructField{
+			{Name: "Self", Type: PtrTo(TypeOf(Recursive{}))},
+			{Name: "Loop", Type: TypeOf(Loop(nil))},
+		}), "struct { Self *reflect_test.Recursive; Loop reflect_test.Loop }"},
+	}
+	for _, test := range tests {
+		if got := test.typ.String(); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+		if got := PtrTo(test.typ).String(); got != "*"+test.want {
+			t.Errorf("got %q, want %q", got, "*"+test.want)
+		}
+	}
+}
+
 func TestDeepEqualRecursiveStruct(t *testing.T) {
 	a, b := new(Recursive), new(Recursive)
 	*a = Recursive{x: 12, r: a}
@@ -941,6 +1305,133 @@ func TestDeepEqualComplexStructInequality(t *testing.T) {
 	}
 }
 
+func TestDeepHash(t *testing.T) {
+	seed := maphash.MakeSeed()
+	for _, test := range deepEqualTests {
+		if !test.eq {
+			continue
+		}
+		if test.b == (self{}) {
+			test.b = test.a
+		}
+		if h1, h2 := DeepHash(test.a, seed), DeepHash(test.b, seed); h1 != h2 {
+			t.Errorf("DeepHash(%#v) = %#x, DeepHash(%#v) = %#x, want equal hashes", test.a, h1, test.b, h2)
+		}
+	}
+
+	// Cycles of different lengths with the same unrolling.
+	r1 := &Recursive{x: 1}
+	r1.r = r1
+	r2 := &Recursive{x: 1, r: &Recursive{x: 1}}
+	r2.r.r = r2
+	if !DeepEqual(r1, r2) {
+		t.Fatal("DeepEqual(r1, r2) = false")
+	}
+	if h1, h2 := DeepHash(r1, seed), DeepHash(r2, seed); h1 != h2 {
+		t.Errorf("DeepHash of equal cyclic values = %#x and %#x", h1, h2)
+	}
+
+	for _, test := range []struct{ a, b any }{
+		{1, 2},
+		{"ab", "ba"},
+		{[]int{1, 2}, []int{2, 1}},
+		{map[int]int{1: 2}, map[int]int{2: 1}},
+		{&Basic{1, 0.5}, &Basic{1, 0.25}},
+	} {
+		if DeepHash(test.a, seed) == DeepHash(test.b, seed) {
+			t.Errorf("DeepHash(%#v) == DeepHash(%#v)", test.a, test.b)
+		}
+	}
+	if DeepHash(1, seed) == DeepHash(1, maphash.MakeSeed()) {
+		t.Errorf("DeepHash ignores the seed")
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	for _, test := range deepEqualTests {
+		if !test.eq || test.a == nil || !DeepEqual(test.a, test.a) {
+			continue
+		}
+		if c := DeepCopy(test.a); !DeepEqualWithOptions(c, test.a, DeepEqualOptions{TreatNaNsEqual: true}) {
+			t.Errorf("DeepCopy(%#v) = %#v", test.a, c)
+		}
+	}
+	if c := DeepCopy(nil); c != nil {
+		t.Errorf("DeepCopy(nil) = %v, want nil", c)
+	}
+	if v := DeepCopyValue(Value{}); v.IsValid() {
+		t.Errorf("DeepCopyValue(Value{}) = %v, want the zero Value", v)
+	}
+
+	r := &Recursive{x: 1}
+	r.r = &Recursive{x: 2, r: r}
+	rc := DeepCopy(r).(*Recursive)
+	if !DeepEqual(rc, r) {
+		t.Errorf("DeepCopy(recursive) = %+v, want %+v", rc, r)
+	}
+	if rc == r || rc.r == r.r || rc.r.r != rc {
+		t.Errorf("DeepCopy(recursive) does not preserve the cycle within the copy")
+	}
+
+	str := "hello"
+	a := new(_Complex)
+	*a = _Complex{a: 5, b: [3]*_Complex{a, nil, a}, c: &str, d: map[float64]float64{1: 2}}
+	ac := DeepCopy(a).(*_Complex)
+	if !DeepEqual(ac, a) {
+		t.Errorf("DeepCopy(complex) = %+v, want %+v", ac, a)
+	}
+	if ac.b[0] != ac || ac.b[2] != ac || ac.b[1] != nil {
+		t.Errorf("DeepCopy(complex).b = %v, want pointers to the copy", ac.b)
+	}
+	*ac.c = "world"
+	ac.d[1] = 3
+	ac.a = 6
+	if str != "hello" || a.d[1] != 2 || a.a != 5 {
+		t.Errorf("mutating the copy changed the original: %+v", a)
+	}
+
+	inner := []int{2, 3}
+	s := [][]int{inner, inner}
+	sc := DeepCopy(s).([][]int)
+	sc[0][0] = 4
+	if inner[0] != 2 {
+		t.Errorf("mutating a copied slice changed the original")
+	}
+	if sc[1][0] != 4 {
+		t.Errorf("DeepCopy does not preserve the shared slice within the copy")
+	}
+
+	// Elements past the length are copied too.
+	backing := []*int{new(int), new(int), new(int)}
+	*backing[2] = 7
+	short := DeepCopy(backing[:1]).([]*int)
+	if len(short) != 1 || cap(short) != 3 {
+		t.Fatalf("DeepCopy(backing[:1]) has len %d, cap %d, want 1, 3", len(short), cap(short))
+	}
+	if full := short[:3]; full[2] == nil || *full[2] != 7 || full[2] == backing[2] {
+		t.Errorf("DeepCopy(backing[:1])[:3][2] = %v, want a copy of 7", full[2])
+	}
+
+	ch, fn := make(chan int), func() {}
+	type refs struct {
+		ch chan int
+		fn func()
+	}
+	rs := DeepCopy(refs{ch, fn}).(refs)
+	if rs.ch != ch || ValueOf(rs.fn).Pointer() != ValueOf(fn).Pointer() {
+		t.Errorf("DeepCopy does not copy channels and funcs by reference")
+	}
+
+	v := ValueOf(struct{ u *int }{new(int)}).Field(0)
+	vc := DeepCopyValue(v)
+	if !vc.CanSet() || !vc.CanInterface() {
+		t.Errorf("DeepCopyValue of an unexported field is not settable")
+	}
+	if vc.Pointer() == v.Pointer() {
+		t.Errorf("DeepCopyValue of an unexported pointer field shares the pointee")
+	}
+}
+
 type UnexpT struct {
 	m map[int]int
 }
@@ -1068,6 +1559,43 @@ func TestIsNil(t *testing.T) {
 	NotNil(fi, t)
 }
 
+func TestDynKind(t *testing.T) {
+	var s struct {
+		Err   error
+		Any   any
+		Ptr   *int
+		Inner fmt.Stringer
+	}
+	s.Any = (*int)(nil)
+	s.Inner = &strings.Builder{}
+	v := ValueOf(&s).Elem()
+	for _, tc := range []struct {
+		v    Value
+		kind Kind
+		typ  Type
+		ok   bool
+	}{
+		{Value{}, Invalid, nil, false},
+		{v.Field(0), Invalid, nil, false},
+		{v.Field(1), Ptr, TypeOf((*int)(nil)), true},
+		{v.Field(2), Ptr, TypeOf((*int)(nil)), true},
+		{v.Field(3), Ptr, TypeOf(&strings.Builder{}), true},
+		{ValueOf(1.5), Float64, TypeOf(1.5), true},
+		{v, Struct, v.Type(), true},
+		{ValueOf([]any{nil}).Index(0), Invalid, nil, false},
+		{ValueOf([]any{"x"}).Index(0), String, TypeOf(""), true},
+	} {
+		kind, ok := tc.v.DynKind()
+		if kind != tc.kind || ok != tc.ok {
+			t.Errorf("%v.DynKind() = %v, %v, want %v, %v", tc.v, kind, ok, tc.kind, tc.ok)
+		}
+		typ, ok := tc.v.DynType()
+		if typ != tc.typ || ok != tc.ok {
+			t.Errorf("%v.DynType() = %v, %v, want %v, %v", tc.v, typ, ok, tc.typ, tc.ok)
+		}
+	}
+}
+
 func TestInterfaceExtraction(t *testing.T) {
 	var s struct {
 		W io.Writer
@@ -1173,6 +1701,103 @@ func TestNilMap(t *testing.T) {
 	mv.SetMapIndex(ValueOf("hi"), Value{})
 }
 
+func TestMapHasKey(t *testing.T) {
+	m := map[string][4096]byte{"a": {1}, "b": {}}
+	mv := ValueOf(m)
+	for _, tc := range []struct {
+		key  string
+		want bool
+	}{{"a", true}, {"b", true}, {"c", false}, {"", false}} {
+		if got := mv.MapHasKey(ValueOf(tc.key)); got != tc.want {
+			t.Errorf("MapHasKey(%q) = %v, want %v", tc.key, got, tc.want)
+		}
+	}
+	key := ValueOf("a")
+	if n := testing.AllocsPerRun(100, func() { mv.MapHasKey(key) }); n != 0 && !CrossCheckEnabled() {
+		t.Errorf("MapHasKey allocates %v times, want 0", n)
+	}
+
+	// Implicit key conversion, as for MapIndex.
+	iv := ValueOf(map[any]int{1: 1, "x": 2, nil: 3})
+	if !iv.MapHasKey(ValueOf(1)) || !iv.MapHasKey(ValueOf("x")) || iv.MapHasKey(ValueOf(int8(1))) {
+		t.Errorf("MapHasKey on map[any]int gave wrong results")
+	}
+	if !iv.MapHasKey(Zero(TypeOf((*any)(nil)).Elem())) {
+		t.Errorf("MapHasKey(nil) = false on map with a nil key")
+	}
+	type MyString string
+	if !ValueOf(map[MyString]bool{"a": true}).MapHasKey(ValueOf(MyString("a"))) {
+		t.Errorf("MapHasKey(MyString) = false")
+	}
+
+	var nilMap map[string]int
+	if ValueOf(nilMap).MapHasKey(ValueOf("a")) {
+		t.Errorf("MapHasKey on nil map = true")
+	}
+	shouldPanic(func() { mv.MapHasKey(ValueOf(1)) })
+	shouldPanic(func() { ValueOf(1).MapHasKey(ValueOf(1)) })
+	shouldPanic(func() { ValueOf(map[any]int{}).MapHasKey(ValueOf([]int{})) })
+}
+
+func BenchmarkMapHasKey(b *testing.B) {
+	m := map[string][4096]byte{"a": {1}}
+	mv, key := ValueOf(m), ValueOf("a")
+	b.Run("MapHasKey", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if !mv.MapHasKey(key) {
+				b.Fatal("key not found")
+			}
+		}
+	})
+	b.Run("MapIndex", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if !mv.MapIndex(key).IsValid() {
+				b.Fatal("key not found")
+			}
+		}
+	})
+}
+
+func TestMapClone(t *testing.T) {
+	type entry struct {
+		N    int
+		Tags []string
+	}
+	m := map[string]entry{"a": {1, []string{"x"}}, "b": {2, nil}}
+	cv := MapClone(ValueOf(m))
+	if cv.Type() != TypeOf(m) {
+		t.Fatalf("MapClone type = %v, want %v", cv.Type(), TypeOf(m))
+	}
+	c := cv.Interface().(map[string]entry)
+	if !DeepEqual(c, m) {
+		t.Fatalf("MapClone = %v, want %v", c, m)
+	}
+	c["a"] = entry{N: 10}
+	delete(c, "b")
+	c["c"] = entry{N: 3}
+	if len(m) != 2 || m["a"].N != 1 || m["b"].N != 2 {
+		t.Errorf("mutating the clone changed the original: %v", m)
+	}
+	// Elements are copied shallowly.
+	m2 := map[int]entry{1: {1, []string{"x"}}}
+	MapClone(ValueOf(m2)).Interface().(map[int]entry)[1].Tags[0] = "y"
+	if m2[1].Tags[0] != "y" {
+		t.Errorf("MapClone copied slice contents")
+	}
+
+	var nilMap map[string]int
+	nv := MapClone(ValueOf(nilMap))
+	if nv.Type() != TypeOf(nilMap) || !nv.IsNil() {
+		t.Errorf("MapClone of nil map = %v (nil %v)", nv.Type(), nv.IsNil())
+	}
+	if c := MapClone(ValueOf(map[float64]int{})); c.IsNil() || c.Len() != 0 {
+		t.Errorf("MapClone of empty map is nil or not empty")
+	}
+	shouldPanic(func() { MapClone(ValueOf([]int{})) })
+}
+
 func TestChan(t *testing.T) {
 	for loop := 0; loop < 2; loop++ {
 		var c chan int
@@ -1271,6 +1896,92 @@ func TestChan(t *testing.T) {
 	}
 }
 
+func TestChanTimeout(t *testing.T) {
+	const short = 10 * time.Millisecond
+	c := make(chan int, 1)
+	cv := ValueOf(c)
+
+	// Ready channels.
+	if !cv.SendTimeout(ValueOf(1), short) {
+		t.Fatal("SendTimeout on buffered channel timed out")
+	}
+	if x, ok, timedOut := cv.RecvTimeout(short); timedOut || !ok || x.Int() != 1 {
+		t.Errorf("RecvTimeout = %v, %v, %v, want 1, true, false", x, ok, timedOut)
+	}
+
+	// Timeouts.
+	if x, ok, timedOut := cv.RecvTimeout(short); !timedOut || ok || x.IsValid() {
+		t.Errorf("RecvTimeout on empty channel = %v, %v, %v, want zero Value, false, true", x, ok, timedOut)
+	}
+	if _, _, timedOut := cv.RecvTimeout(0); !timedOut {
+		t.Errorf("RecvTimeout(0) on empty channel did not time out")
+	}
+	c <- 2
+	start := time.Now()
+	if cv.SendTimeout(ValueOf(3), short) {
+		t.Errorf("SendTimeout on full channel succeeded")
+	}
+	if d := time.Since(start); d < short {
+		t.Errorf("SendTimeout returned after %v, want at least %v", d, short)
+	}
+
+	// A value arriving before the timeout.
+	<-c
+	go func() {
+		time.Sleep(short)
+		c <- 4
+	}()
+	if x, ok, timedOut := cv.RecvTimeout(time.Minute); timedOut || !ok || x.Int() != 4 {
+		t.Errorf("RecvTimeout of late value = %v, %v, %v, want 4, true, false", x, ok, timedOut)
+	}
+	unbuffered := make(chan int)
+	go func() {
+		time.Sleep(short)
+		<-unbuffered
+	}()
+	if !ValueOf(unbuffered).SendTimeout(ValueOf(5), time.Minute) {
+		t.Errorf("SendTimeout to late receiver timed out")
+	}
+
+	// Closed channels.
+	close(c)
+	if x, ok, timedOut := cv.RecvTimeout(short); timedOut || ok || x.Int() != 0 {
+		t.Errorf("RecvTimeout on closed channel = %v, %v, %v, want 0, false, false", x, ok, timedOut)
+	}
+	shouldPanic(func() { cv.SendTimeout(ValueOf(1), short) })
+
+	// Method values are sent as their func type, as by Send.
+	fc := make(chan func() string, 1)
+	if !ValueOf(fc).SendTimeout(ValueOf(time.March).MethodByName("String"), short) {
+		t.Fatal("SendTimeout of a method value timed out")
+	}
+	if got := (<-fc)(); got != "March" {
+		t.Errorf("received method value returned %q, want March", got)
+	}
+
+	// Panics as for Recv and Send.
+	var sendOnly chan<- int = make(chan int)
+	var recvOnly <-chan int = make(chan int)
+	for _, tc := range []struct {
+		f    func()
+		want string
+	}{
+		{func() { ValueOf(sendOnly).RecvTimeout(short) }, "reflect: recv on send-only channel"},
+		{func() { ValueOf(recvOnly).SendTimeout(ValueOf(1), short) }, "reflect: send on recv-only channel"},
+		{func() { ValueOf(make(chan int)).SendTimeout(ValueOf("x"), short) }, "value of type string is not assignable to type int"},
+		{func() { ValueOf(1).RecvTimeout(short) }, "reflect: call of reflect.Value.RecvTimeout on int Value"},
+	} {
+		func() {
+			defer func() {
+				if msg := fmt.Sprint(recover()); !strings.Contains(msg, tc.want) {
+					t.Errorf("panic %q, want %q", msg, tc.want)
+				}
+			}()
+			tc.f()
+		}()
+	}
+}
+
 // caseInfo describes a single case in a select test.
 type caseInfo struct {
 	desc      string
@@ -1284,6 +1995,26 @@ type caseInfo struct {
 var allselect = flag.Bool("allselect", false, "exhaustive select test")
 
 func TestSelect(t *testing.T) {
+	testSelect(t, Select)
+}
+
+// TestSelectSet runs the TestSelect cases through a SelectSet, adding
+// and removing a spare case first so that Run sees a removed slot.
+func TestSelectSet(t *testing.T) {
+	testSelect(t, func(cases []SelectCase) (int, Value, bool) {
+		var s SelectSet
+		spare := s.Add(SelectCase{Dir: SelectRecv, Chan: ValueOf(make(chan int))})
+		s.Remove(spare)
+		for i, c := range cases {
+			if j := s.Add(c); j != i {
+				t.Fatalf("SelectSet.Add returned index %d, want %d", j, i)
+			}
+		}
+		return s.Run()
+	})
+}
+
+func testSelect(t *testing.T, selectFunc func([]SelectCase) (int, Value, bool)) {
 	selectWatch.once.Do(func() { go selectWatcher() })
 
 	var x exhaustive
@@ -1496,7 +2227,7 @@ func TestSelect(t *testing.T) {
 		}
 
 		// Run select.
-		i, recv, recvOK, panicErr := runSelect(cases, info)
+		i, recv, recvOK, panicErr := runSelect(selectFunc, cases, info)
 		if panicErr != nil && !canPanic {
 			t.Fatalf("%s\npanicked unexpectedly: %v", fmtSelect(info), panicErr)
 		}
@@ -1567,7 +2298,7 @@ func selectWatcher() {
 // runSelect runs a single select test.
 // It returns the values returned by Select but also returns
 // a panic value if the Select panics.
-func runSelect(cases []SelectCase, info []caseInfo) (chosen int, recv Value, recvOK bool, panicErr any) {
+func runSelect(selectFunc func([]SelectCase) (int, Value, bool), cases []SelectCase, info []caseInfo) (chosen int, recv Value, recvOK bool, panicErr any) {
 	defer func() {
 		panicErr = recover()
 
@@ -1581,7 +2312,7 @@ func runSelect(cases []SelectCase, info []caseInfo) (chosen int, recv Value, rec
 	selectWatch.info = info
 	selectWatch.Unlock()
 
-	chosen, recv, recvOK = Select(cases)
+	chosen, recv, recvOK = selectFunc(cases)
 	return
 }
 
@@ -1730,6 +2461,27 @@ func BenchmarkCall(b *testing.B) {
 	})
 }
 
+func BenchmarkCall8Args(b *testing.B) {
+	fv := ValueOf(func(a, b, c, d, e, f, g, h int) {})
+	args := make([]Value, 8)
+	for i := range args {
+		args[i] = ValueOf(i)
+	}
+	b.Run("Call", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fv.Call(args)
+		}
+	})
+	b.Run("ReflectCall", func(b *testing.B) {
+		rfv := ToReflectValue(fv)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			rfv.Call(ToReflectValues(args))
+		}
+	})
+}
+
 func BenchmarkCallArgCopy(b *testing.B) {
 	byteArray := func(n int) Value {
 		return Zero(ArrayOf(n, TypeOf(byte(0))))
@@ -1796,6 +2548,68 @@ func TestMakeFuncInterface(t *testing.T) {
 	}
 }
 
+func TestFuncID(t *testing.T) {
+	id := func(in []Value) []Value { return in }
+	typ := TypeOf(func(int) int { return 0 })
+	f1, f2 := MakeFunc(typ, id), MakeFunc(typ, id)
+	if f1.Pointer() != f2.Pointer() {
+		t.Fatalf("MakeFunc results have different code pointers")
+	}
+	if f1.FuncID() == f2.FuncID() {
+		t.Errorf("MakeFunc results have the same FuncID %#x", f1.FuncID())
+	}
+	if got, want := ValueOf(f1.Interface()).FuncID(), f1.FuncID(); got != want {
+		t.Errorf("FuncID after Interface round trip = %#x, want %#x", got, want)
+	}
+	fn := f1.Interface().(func(int) int)
+	if got, want := ValueOf(fn).FuncID(), ValueOf(fn).FuncID(); got != want {
+		t.Errorf("FuncID of the same func = %#x and %#x", got, want)
+	}
+
+	// IDs of collected functions are not handed out again, even if a new
+	// function reuses the memory of an old one.
+	seen := map[uint64]bool{f1.FuncID(): true, f2.FuncID(): true}
+	for i := 0; i < 100; i++ {
+		fid := MakeFunc(typ, id).FuncID()
+		if seen[fid] {
+			t.Fatalf("FuncID %#x reused", fid)
+		}
+		seen[fid] = true
+		if i%10 == 0 {
+			runtime.GC()
+		}
+	}
+
+	if got, want := ValueOf(dummy).FuncID(), ValueOf(dummy).FuncID(); got != want {
+		t.Errorf("FuncID of dummy = %#x and %#x", got, want)
+	}
+	if ValueOf(dummy).FuncID() == ValueOf(TestFuncID).FuncID() {
+		t.Errorf("dummy and TestFuncID have the same FuncID")
+	}
+	var nilFunc func()
+	if id := ValueOf(nilFunc).FuncID(); id != 0 {
+		t.Errorf("FuncID of nil func = %#x, want 0", id)
+	}
+
+	p := Point{3, 4}
+	m1, m2 := ValueOf(p).MethodByName("Dist"), ValueOf(Point{1, 2}).MethodByName("Dist")
+	if m1.FuncID() != m2.FuncID() {
+		t.Errorf("method values of the same method have different FuncIDs")
+	}
+	if got, want := m1.FuncID(), ValueOf(Point.Dist).FuncID(); got != want {
+		t.Errorf("FuncID of method value = %#x, want %#x", got, want)
+	}
+	if m1.FuncID() == ValueOf(p).MethodByName("TotalDist").FuncID() {
+		t.Errorf("Dist and TotalDist have the same FuncID")
+	}
+	var i interface{ Dist(int) int } = p
+	if got, want := ValueOf(&i).Elem().MethodByName("Dist").FuncID(), m1.FuncID(); got != want {
+		t.Errorf("FuncID of interface method value = %#x, want %#x", got, want)
+	}
+
+	shouldPanic(func() { ValueOf(1).FuncID() })
+}
+
 func TestMakeFuncVariadic(t *testing.T) {
 	// Test that variadic arguments are packed into a slice and passed as last arg
 	fn := func(_ int, is ...int) []int { return nil }
@@ -1834,6 +2648,37 @@ func TestMakeFuncVariadic(t *testing.T) {
 	}
 }
 
+func TestMakeTypedFunc(t *testing.T) {
+	sum := MakeTypedFunc[func(string, ...int) (string, int)](func(in []Value) []Value {
+		n := 0
+		for i := 0; i < in[1].Len(); i++ {
+			n += int(in[1].Index(i).Int())
+		}
+		return []Value{ValueOf(in[0].String() + "!"), ValueOf(n)}
+	})
+	if s, n := sum("sum", 1, 2, 3); s != "sum!" || n != 6 {
+		t.Errorf("sum(\"sum\", 1, 2, 3) = %q, %d; want \"sum!\", 6", s, n)
+	}
+	if s, n := sum("none"); s != "none!" || n != 0 {
+		t.Errorf("sum(\"none\") = %q, %d; want \"none!\", 0", s, n)
+	}
+
+	type divFunc func(a, b int) (int, int, error)
+	div := MakeTypedFunc[divFunc](func(in []Value) []Value {
+		a, b := int(in[0].Int()), int(in[1].Int())
+		return []Value{ValueOf(a / b), ValueOf(a % b), Zero(TypeFor[error]())}
+	})
+	if q, r, err := div(7, 2); q != 3 || r != 1 || err != nil {
+		t.Errorf("div(7, 2) = %d, %d, %v; want 3, 1, nil", q, r, err)
+	}
+
+	if TypeFor[divFunc]() != TypeOf(div) {
+		t.Errorf("TypeFor[divFunc]() = %v", TypeFor[divFunc]())
+	}
+	shouldPanic(func() { MakeTypedFunc[int](nil) })
+	shouldPanic(func() { MakeTypedFunc[any](nil) })
+}
+
 type Point struct {
 	x, y int
 }
@@ -2075,12 +2920,72 @@ func TestMethodValue(t *testing.T) {
 	if tt := v.Type(); tt != tfunc {
 		t.Errorf("Interface MethodByName Type is %s; want %s", tt, tfunc)
 	}
-	i = ValueOf(v.Interface()).Call([]Value{ValueOf(17)})[0].Int()
-	if i != 425 {
-		t.Errorf("Interface MethodByName returned %d; want 425", i)
+	i = ValueOf(v.Interface()).Call([]Value{ValueOf(17)})[0].Int()
+	if i != 425 {
+		t.Errorf("Interface MethodByName returned %d; want 425", i)
+	}
+}
+
+func TestCallMethodByName(t *testing.T) {
+	p := Point{x: 3, y: 4}
+	if i := ValueOf(p).CallMethodByName("Dist", []Value{ValueOf(10)})[0].Int(); i != 250 {
+		t.Errorf("Value CallMethodByName returned %d; want 250", i)
+	}
+	if i := ValueOf(&p).CallMethodByName("Dist", []Value{ValueOf(11)})[0].Int(); i != 275 {
+		t.Errorf("Pointer Value CallMethodByName returned %d; want 275", i)
+	}
+	if out := ValueOf(p).CallMethodByName("NoArgs", nil); len(out) != 0 {
+		t.Errorf("NoArgs returned %v", out)
+	}
+	type distancer interface {
+		Dist(int) int
+	}
+	var s distancer = p
+	if i := ValueOf(&s).Elem().CallMethodByName("Dist", []Value{ValueOf(12)})[0].Int(); i != 300 {
+		t.Errorf("Interface CallMethodByName returned %d; want 300", i)
+	}
+
+	var nilIface distancer
+	for _, tc := range []struct {
+		v    Value
+		name string
+		in   []Value
+	}{
+		{ValueOf(p), "Missing", nil},
+		{ValueOf(p), "Dist", nil},
+		{ValueOf(p), "Dist", []Value{ValueOf("x")}},
+		{ValueOf(p).Method(0), "Dist", []Value{ValueOf(1)}},
+		{ValueOf(&nilIface).Elem(), "Dist", []Value{ValueOf(1)}},
+	} {
+		want := panicOf(func() { tc.v.MethodByName(tc.name).Call(tc.in) })
+		got := panicOf(func() { tc.v.CallMethodByName(tc.name, tc.in) })
+		if want == nil || fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("CallMethodByName(%q) panicked with %v; want %v", tc.name, got, want)
+		}
+	}
+	got := panicOf(func() { Value{}.CallMethodByName("Dist", nil) })
+	if e, ok := got.(*ValueError); !ok || e.Method != "reflect.Value.CallMethodByName" || e.Kind != Invalid {
+		t.Errorf("CallMethodByName on zero Value panicked with %v", got)
 	}
 }
 
+func BenchmarkCallMethodByName(b *testing.B) {
+	v := ValueOf(Point{x: 3, y: 4})
+	in := []Value{ValueOf(10)}
+	b.Run("MethodByName+Call", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			v.MethodByName("Dist").Call(in)
+		}
+	})
+	b.Run("CallMethodByName", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			v.CallMethodByName("Dist", in)
+		}
+	})
+}
+
 func TestVariadicMethodValue(t *testing.T) {
 	p := Point{x: 3, y: 4}
 	points := []Point{{x: 20, y: 21}, {x: 22, y: 23}, {x: 24, y: 25}}
@@ -2235,6 +3140,66 @@ func TestMethod5(t *testing.T) {
 	shouldPanic(func() { vnil.Method(0) })
 }
 
+func TestBoundMethods(t *testing.T) {
+	p := &Point{x: 3, y: 4}
+	handlers := map[string]BoundMethod{}
+	var names []string
+	for _, m := range BoundMethods(ValueOf(p)) {
+		handlers[m.Name] = m
+		names = append(names, m.Name)
+		if m.Type != m.Func.Type() {
+			t.Errorf("%s: Type = %v, want %v", m.Name, m.Type, m.Func.Type())
+		}
+		if want := TypeOf(p).Method(m.Index).Name; want != m.Name {
+			t.Errorf("%s: Index %d names method %s", m.Name, m.Index, want)
+		}
+	}
+	if got, want := strings.Join(names, ","), "AnotherMethod,Dist,GCMethod,NoArgs,TotalDist"; got != want {
+		t.Fatalf("BoundMethods(&Point{}) = %s, want %s", got, want)
+	}
+	if n := handlers["Dist"].Func.Call([]Value{ValueOf(10)})[0].Int(); n != 250 {
+		t.Errorf("Dist(10) = %d, want 250", n)
+	}
+	if n := handlers["GCMethod"].Func.Call([]Value{ValueOf(5)})[0].Int(); n != 8 {
+		t.Errorf("GCMethod(5) = %d, want 8", n)
+	}
+	if n := len(handlers["NoArgs"].Func.Call(nil)); n != 0 {
+		t.Errorf("NoArgs returned %d results", n)
+	}
+	if n := handlers["TotalDist"].Func.Call([]Value{ValueOf(Point{x: 4, y: 4}), ValueOf(Point{x: 3, y: 6})})[0].Int(); n != 5 {
+		t.Errorf("TotalDist = %d, want 5", n)
+	}
+	if n := handlers["AnotherMethod"].Func.Interface().(func(int) int)(1); n != -1 {
+		t.Errorf("AnotherMethod(1) = %d, want -1", n)
+	}
+
+	// Value receivers, pointer receivers and interfaces, as in TestMethod5.
+	TinterType := TypeOf(new(Tinter)).Elem()
+	check := func(name string, v Value, inc int) {
+		ms := BoundMethods(v)
+		if len(ms) != 1 || ms[0].Name != "M" {
+			t.Errorf("BoundMethods(%s) = %v", name, ms)
+			return
+		}
+		f := ms[0].Func.Interface().(func(int, byte) (byte, int))
+		if b, x := f(1000, 99); b != 99 || x != 1000+inc {
+			t.Errorf("%s.M(1000, 99) = %v, %v, want 99, %v", name, b, x, 1000+inc)
+		}
+	}
+	sv := Tsmallv(1)
+	check("sv", ValueOf(sv), 1)
+	check("(i=sv)", ValueOf(sv).Convert(TinterType), 1)
+	sp := Tsmallp(2)
+	check("&sp", ValueOf(&sp), 2)
+	check("(i=&sp)", ValueOf(&sp).Convert(TinterType), 2)
+	if ms := BoundMethods(ValueOf(sp)); len(ms) != 0 {
+		t.Errorf("BoundMethods(sp) = %v, want none", ms)
+	}
+
+	shouldPanic(func() { BoundMethods(Value{}) })
+	shouldPanic(func() { BoundMethods(Zero(TinterType)) })
+}
+
 func TestInterfaceSet(t *testing.T) {
 	p := &Point{x: 3, y: 4}
 
@@ -2414,6 +3379,133 @@ var fieldTests = []FTest{
 	{s: S14{}, name: "X", index: nil, value: 0},
 }
 
+func TestFieldIndexByName(t *testing.T) {
+	for _, test := range fieldTests {
+		s := TypeOf(test.s)
+		index, ok := s.FieldIndexByName(test.name)
+		if ok != (test.index != nil) || !slices.Equal(index, test.index) {
+			t.Errorf("%s.FieldIndexByName(%q) = %v, %v; want %v", s, test.name, index, ok, test.index)
+		}
+	}
+
+	// X is ambiguous at every depth of R0.
+	if index, ok := TypeOf(R0{}).FieldIndexByName("X"); ok {
+		t.Fatalf("R0.FieldIndexByName(X) = %v, want not found", index)
+	}
+
+	typ := TypeOf(S2{})
+	f, _ := typ.FieldByName("C")
+	f.Index[0] = -1
+	if again, _ := typ.FieldIndexByName("C"); again[0] == -1 {
+		t.Fatal("FieldByName returned the cached index sequence")
+	}
+	if n := testing.AllocsPerRun(100, func() { typ.FieldIndexByName("C") }); n != 0 && !CrossCheckEnabled() {
+		t.Fatalf("FieldIndexByName allocated %v times", n)
+	}
+	shouldPanic(func() { TypeOf(0).FieldIndexByName("X") })
+}
+
+type FoldInner struct {
+	URL   string
+	Token string
+}
+
+type foldOuter struct {
+	Id int
+	ID int
+	FoldInner
+	Name string
+	NAME string
+}
+
+func TestFieldByNameFold(t *testing.T) {
+	typ := TypeOf(foldOuter{})
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"ID", "ID", true}, // exact match preferred
+		{"Id", "Id", true}, // exact match preferred
+		{"id", "Id", true}, // first folded match in field order
+		{"iD", "Id", true},
+		{"NAME", "NAME", true}, // exact match after a folded one
+		{"name", "Name", true},
+		{"url", "URL", true}, // promoted field
+		{"token", "Token", true},
+		{"missing", "", false},
+	}
+	for _, test := range tests {
+		f, ok := typ.FieldByNameFold(test.name)
+		if ok != test.ok || f.Name != test.want {
+			t.Errorf("FieldByNameFold(%q) = %q, %v; want %q, %v", test.name, f.Name, ok, test.want, test.ok)
+		}
+	}
+	f, _ := typ.FieldByNameFold("url")
+	if len(f.Index) != 2 || f.Index[0] != 2 || f.Index[1] != 0 {
+		t.Fatalf("unexpected index %v for promoted field", f.Index)
+	}
+	shouldPanic(func() { TypeOf(0).FieldByNameFold("x") })
+}
+
+func TestWalkFields(t *testing.T) {
+	type visit struct {
+		path string
+		name string
+	}
+	walk := func(typ Type) []visit {
+		var visits []visit
+		WalkFields(typ, func(path []int, f StructField) bool {
+			if !slices.Equal(path, f.Index) || typ.FieldByIndex(path).Name != f.Name {
+				t.Fatalf("%s: path %v does not lead to %s", typ, path, f.Name)
+			}
+			visits = append(visits, visit{fmt.Sprint(path), f.Name})
+			return true
+		})
+		return visits
+	}
+
+	// S4 embeds *S4: the cycle is reported once and not followed.
+	if got, want := walk(TypeOf(S4{})), []visit{{"[0]", "S4"}, {"[1]", "A"}}; !slices.Equal(got, want) {
+		t.Fatalf("S4: got %v, want %v", got, want)
+	}
+
+	got := walk(TypeOf(S5{}))
+	want := []visit{
+		{"[0]", "S6"}, {"[1]", "S7"}, {"[2]", "S8"},
+		{"[0 0]", "X"}, {"[1 0]", "X"}, {"[2 0]", "S9"},
+		{"[2 0 0]", "X"}, {"[2 0 1]", "Y"},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("S5: got %v, want %v", got, want)
+	}
+
+	// The first visit of a name is the field FieldByName finds.
+	for _, typ := range []Type{TypeOf(S3{}), TypeOf(S5{}), TypeOf(S4{})} {
+		first := map[string]string{}
+		for _, v := range walk(typ) {
+			if _, ok := first[v.name]; !ok {
+				first[v.name] = v.path
+			}
+		}
+		for name, path := range first {
+			if f, ok := typ.FieldByName(name); ok && fmt.Sprint(f.Index) != path {
+				t.Errorf("%s: first visit of %s at %s, FieldByName finds %v", typ, name, path, f.Index)
+			}
+		}
+	}
+
+	n := 0
+	WalkFields(TypeOf(S3{}), func([]int, StructField) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Fatalf("walk did not stop when fn returned false, visited %d fields", n)
+	}
+	shouldPanic(func() { WalkFields(TypeOf(0), func([]int, StructField) bool { return true }) })
+}
+
 func TestFieldByIndex(t *testing.T) {
 	for _, test := range fieldTests {
 		s := TypeOf(test.s)
@@ -2783,6 +3875,9 @@ func noAlloc(t *testing.T, n int, f func(int)) {
 	if runtime.GOMAXPROCS(0) > 1 {
 		t.Skip("skipping; GOMAXPROCS>1")
 	}
+	if CrossCheckEnabled() {
+		t.Skip("skipping malloc count in cross-check mode")
+	}
 	i := -1
 	allocs := testing.AllocsPerRun(n, func() {
 		f(i)
@@ -3203,6 +4298,143 @@ func TestSetPanic(t *testing.T) {
 	bad(func() { clear(v.Field(6).Field(1).Field(0)) }) // .namedT2.namedT0.W
 }
 
+func TestTrySetErrors(t *testing.T) {
+	type t0 struct {
+		W int
+	}
+
+	type t1 struct {
+		Y int
+		t0
+	}
+
+	type T2 struct {
+		Z       int
+		namedT0 t0
+	}
+
+	type T struct {
+		X int
+		t1
+		T2
+		NamedT1 t1
+		NamedT2 T2
+		namedT1 t1
+		namedT2 T2
+	}
+
+	// The cases of TestSetPanic: the field at index path and whether it
+	// can be set if its struct is addressable.
+	cases := []struct {
+		path []int
+		ok   bool
+	}{
+		{[]int{0}, true},        // .X
+		{[]int{1}, false},       // .t1
+		{[]int{1, 0}, true},     // .t1.Y
+		{[]int{1, 1}, false},    // .t1.t0
+		{[]int{1, 1, 0}, true},  // .t1.t0.W
+		{[]int{2}, true},        // .T2
+		{[]int{2, 0}, true},     // .T2.Z
+		{[]int{2, 1}, false},    // .T2.namedT0
+		{[]int{2, 1, 0}, false}, // .T2.namedT0.W
+		{[]int{3}, true},        // .NamedT1
+		{[]int{3, 0}, true},     // .NamedT1.Y
+		{[]int{3, 1}, false},    // .NamedT1.t0
+		{[]int{3, 1, 0}, true},  // .NamedT1.t0.W
+		{[]int{4}, true},        // .NamedT2
+		{[]int{4, 0}, true},     // .NamedT2.Z
+		{[]int{4, 1}, false},    // .NamedT2.namedT0
+		{[]int{4, 1, 0}, false}, // .NamedT2.namedT0.W
+		{[]int{5}, false},       // .namedT1
+		{[]int{5, 0}, false},    // .namedT1.Y
+		{[]int{5, 1}, false},    // .namedT1.t0
+		{[]int{5, 1, 0}, false}, // .namedT1.t0.W
+		{[]int{6}, false},       // .namedT2
+		{[]int{6, 0}, false},    // .namedT2.Z
+		{[]int{6, 1}, false},    // .namedT2.namedT0
+		{[]int{6, 1, 0}, false}, // .namedT2.namedT0.W
+	}
+	for _, addressable := range []bool{false, true} {
+		root := ValueOf(T{})
+		if addressable {
+			root = ValueOf(&T{}).Elem()
+		}
+		for _, c := range cases {
+			v := root.FieldByIndex(c.path)
+			err := v.TrySet(Zero(v.Type()))
+			if ok := addressable && c.ok; (err == nil) != ok {
+				t.Errorf("addressable=%v: TrySet of field %v: got error %v, want ok=%v", addressable, c.path, err, ok)
+			}
+			if err == nil {
+				continue
+			}
+			var opErr *ValueOpError
+			if !errors.As(err, &opErr) || opErr.To != v.Type() || opErr.From != v.Type() {
+				t.Errorf("TrySet of field %v: error %#v is not a *ValueOpError with both types", c.path, err)
+			}
+			if addressable && !strings.Contains(err.Error(), "unexported field") {
+				t.Errorf("TrySet of field %v: error %q does not mention the unexported field", c.path, err)
+			}
+			shouldPanic(func() { v.Set(Zero(v.Type())) })
+		}
+	}
+
+	v := ValueOf(new(int)).Elem()
+	for _, tc := range []struct {
+		x    Value
+		want string
+	}{
+		{ValueOf("s"), "reflect: reflect.Value.TrySet: value of type string is not assignable to type int"},
+		{Value{}, "reflect: reflect.Value.TrySet: cannot set value of type int to the zero Value"},
+		{ValueOf(struct{ n int }{1}).Field(0), "reflect: reflect.Value.TrySet: cannot use value of type int obtained using unexported field"},
+	} {
+		if err := v.TrySet(tc.x); err == nil || err.Error() != tc.want {
+			t.Errorf("TrySet error = %v, want %q", err, tc.want)
+		}
+	}
+	if err := ValueOf(1).TrySet(ValueOf(2)); err == nil || err.Error() != "reflect: reflect.Value.TrySet: cannot set unaddressable value of type int" {
+		t.Errorf("TrySet of unaddressable value: error = %v", err)
+	}
+	var verr *ValueError
+	if err := (Value{}).TrySet(ValueOf(1)); !errors.As(err, &verr) || verr.Kind != Invalid {
+		t.Errorf("TrySet of zero Value: error = %#v, want *ValueError", err)
+	}
+	if err := v.TrySet(ValueOf(7)); err != nil || v.Int() != 7 {
+		t.Errorf("TrySet(7) = %v, value %d", err, v.Int())
+	}
+}
+
+func TestTryConvert(t *testing.T) {
+	type myInt int
+	if v, err := ValueOf(3).TryConvert(TypeOf(myInt(0))); err != nil || v.Interface() != myInt(3) {
+		t.Errorf("TryConvert to myInt = %v, %v", v, err)
+	}
+	for _, tc := range []struct {
+		v    Value
+		t    Type
+		want string
+	}{
+		{ValueOf("s"), TypeOf(0), "reflect: reflect.Value.TryConvert: value of type string cannot be converted to type int"},
+		{ValueOf([]int{1}), TypeOf([2]int{}), "reflect: reflect.Value.TryConvert: slice of length 1 is too short for type [2]int of length 2"},
+		{ValueOf([]int{1}), TypeOf(&[2]int{}), "reflect: reflect.Value.TryConvert: slice of length 1 is too short for type *[2]int of length 2"},
+		{ValueOf(1), nil, "reflect: Value.TryConvert of nil Type"},
+		{Value{}, TypeOf(0), "reflect: call of reflect.Value.TryConvert on zero Value"},
+	} {
+		v, err := tc.v.TryConvert(tc.t)
+		if err == nil || err.Error() != tc.want || v.IsValid() {
+			t.Errorf("TryConvert error = %v, want %q", err, tc.want)
+		}
+		if tc.t != nil && tc.v.IsValid() {
+			shouldPanic(func() { tc.v.Convert(tc.t) })
+		}
+	}
+	var opErr *ValueOpError
+	if _, err := ValueOf("s").TryConvert(TypeOf(0)); !errors.As(err, &opErr) || opErr.From != TypeOf("") || opErr.To != TypeOf(0) {
+		t.Errorf("TryConvert error = %#v, want *ValueOpError with both types", err)
+	}
+}
+
 type timp int
 
 func (t timp) W() {}
@@ -3293,6 +4525,14 @@ func shouldPanic(f func()) {
 	f()
 }
 
+// panicOf calls f and returns the value it panicked with, or nil if it
+// returned.
+func panicOf(f func()) (r any) {
+	defer func() { r = recover() }()
+	f()
+	return nil
+}
+
 func isNonNil(x any) {
 	if x == nil {
 		panic("nil interface")
@@ -3791,6 +5031,61 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+func TestConvertSliceToArray(t *testing.T) {
+	s := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	arr8 := TypeOf([8]byte{})
+	for _, tc := range []struct {
+		s   []byte
+		can bool
+	}{{s[:8], true}, {s, true}, {s[:3], false}, {nil, false}} {
+		v := ValueOf(tc.s)
+		if got := v.CanConvert(arr8); got != tc.can {
+			t.Errorf("CanConvert of slice of length %d to %v = %v, want %v", len(tc.s), arr8, got, tc.can)
+		}
+		if got := v.CanConvert(PtrTo(arr8)); got != tc.can {
+			t.Errorf("CanConvert of slice of length %d to %v = %v, want %v", len(tc.s), PtrTo(arr8), got, tc.can)
+		}
+		a, err := SliceToArray(v, 8)
+		if !tc.can {
+			if err == nil || !strings.Contains(err.Error(), "too short") {
+				t.Errorf("SliceToArray of length %d: err = %v", len(tc.s), err)
+			}
+			shouldPanic(func() { v.Convert(arr8) })
+			continue
+		}
+		if err != nil {
+			t.Fatalf("SliceToArray of length %d: %v", len(tc.s), err)
+		}
+		if got := a.Interface().([8]byte); got != [8]byte{1, 2, 3, 4, 5, 6, 7, 8} {
+			t.Errorf("SliceToArray = %v", got)
+		}
+		if got := v.Convert(arr8).Interface().([8]byte); got != [8]byte{1, 2, 3, 4, 5, 6, 7, 8} {
+			t.Errorf("Convert to %v = %v", arr8, got)
+		}
+	}
+
+	// The array is a copy, the array pointer aliases the slice.
+	a, _ := SliceToArray(ValueOf(s), 2)
+	p := ValueOf(s).Convert(PtrTo(arr8)).Interface().(*[8]byte)
+	s[0] = 10
+	if a.Index(0).Uint() != 1 {
+		t.Errorf("SliceToArray result changed with the slice")
+	}
+	if p[0] != 10 {
+		t.Errorf("array pointer does not alias the slice")
+	}
+	p[1] = 20
+	if s[1] != 20 {
+		t.Errorf("write through the array pointer not visible in the slice")
+	}
+
+	if z, err := SliceToArray(ValueOf([]string(nil)), 0); err != nil || z.Type() != TypeOf([0]string{}) {
+		t.Errorf("SliceToArray(nil, 0) = %v, %v", z, err)
+	}
+	shouldPanic(func() { SliceToArray(ValueOf([4]byte{}), 2) })
+	shouldPanic(func() { SliceToArray(ValueOf(s), -1) })
+}
+
 type ComparableStruct struct {
 	X int
 }
@@ -4028,6 +5323,30 @@ func TestArrayOfGC(t *testing.T) {
 	}
 }
 
+func TestNewBoxed(t *testing.T) {
+	type T struct {
+		P *int
+		S string
+	}
+	typ := TypeOf(T{})
+	ptr, boxed := NewBoxed(typ)
+	p, ok := boxed.(*T)
+	if !ok || p != ptr.Interface().(*T) {
+		t.Fatalf("boxed %T does not hold the new pointer", boxed)
+	}
+	n := 42
+	ptr.Elem().Set(ValueOf(T{P: &n, S: "s"}))
+	if *p.P != 42 || p.S != "s" {
+		t.Fatalf("write through ptr not visible through boxed: %+v", *p)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { NewBoxed(typ) }); allocs != 1 && !CrossCheckEnabled() {
+		t.Fatalf("NewBoxed allocated %v times, want 1", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { NewBoxed(TypeOf((*int)(nil))) }); allocs != 1 && !CrossCheckEnabled() {
+		t.Fatalf("NewBoxed allocated %v times, want 1", allocs)
+	}
+}
+
 func TestArrayOfAlg(t *testing.T) {
 	at := ArrayOf(6, TypeOf(byte(0)))
 	v1 := New(at).Elem()
@@ -4232,6 +5551,43 @@ func TestStructOfFieldName(t *testing.T) {
 	}
 }
 
+func TestStructOfUnexported(t *testing.T) {
+	type static struct {
+		A int
+		b string
+	}
+	st := TypeOf(static{})
+	dt := StructOf([]StructField{
+		{Name: "A", Type: TypeOf(0)},
+		{Name: "b", PkgPath: st.Field(1).PkgPath, Type: TypeOf("")},
+	})
+	if f := dt.Field(1); f.Name != "b" || f.PkgPath != st.Field(1).PkgPath || f.IsExported() {
+		t.Fatalf("unexpected field %+v", f)
+	}
+	sv, dv := ValueOf(&static{}).Elem(), New(dt).Elem()
+	for i := 0; i < 2; i++ {
+		if sv.Field(i).CanSet() != dv.Field(i).CanSet() || sv.Field(i).CanInterface() != dv.Field(i).CanInterface() {
+			t.Fatalf("field %d: CanSet/CanInterface differ from the static struct", i)
+		}
+	}
+	dv.Field(0).SetInt(1)
+	if dv.Field(0).Interface() != 1 {
+		t.Fatal("failed to set exported field")
+	}
+	shouldPanic(func() { dv.Field(1).SetString("x") })
+	shouldPanic(func() { dv.Field(1).Interface() })
+	if dv.Field(1).String() != "" {
+		t.Fatal("failed to read unexported field")
+	}
+
+	shouldPanic(func() {
+		StructOf([]StructField{{Name: "B", PkgPath: "example.com/p", Type: TypeOf(0)}})
+	})
+	shouldPanic(func() {
+		StructOf([]StructField{{Name: "b", Type: TypeOf(0)}})
+	})
+}
+
 func TestStructOf(t *testing.T) {
 	// check construction and use of type not in binary
 	fields := []StructField{
@@ -4601,6 +5957,39 @@ func TestStructOfDirectIface(t *testing.T) {
 	}
 }
 
+func TestIsDirectIface(t *testing.T) {
+	for _, tc := range []struct {
+		typ  Type
+		want bool
+	}{
+		{TypeOf([0]*byte{}), false},
+		{TypeOf([1]*byte{}), true},
+		{ArrayOf(1, TypeOf((*int8)(nil))), true},
+		{TypeOf(struct{ X [1]*byte }{}), true},
+		{TypeOf(struct{ X [0]*byte }{}), false},
+		{TypeOf(struct{ X, Y *byte }{}), false},
+		{TypeOf(map[string]int(nil)), true},
+		{TypeOf(struct{ M map[string]int }{}), true},
+		{MapOf(TypeOf(""), TypeOf(0)), true},
+		{TypeOf(0), false},
+		{TypeOf(""), false},
+	} {
+		if got := tc.typ.IsDirectIface(); got != tc.want {
+			t.Errorf("%v.IsDirectIface() = %v, want %v", tc.typ, got, tc.want)
+		}
+		// The data word of an interface holding the zero value of a
+		// pointer-shaped type is nil; for other types it points to the
+		// value.
+		i := Zero(tc.typ).Interface()
+		if direct := ValueOf(&i).Elem().InterfaceData()[1] == 0; direct != tc.want {
+			t.Errorf("interface holding %v stores it directly: %v, want %v", tc.typ, direct, tc.want)
+		}
+		if IfaceIndir(tc.typ) == tc.want {
+			t.Errorf("IfaceIndir(%v) = %v, want %v", tc.typ, tc.want, !tc.want)
+		}
+	}
+}
+
 type StructI int
 
 func (i StructI) Get() int { return int(i) }
@@ -5059,6 +6448,75 @@ func TestFuncOf(t *testing.T) {
 	shouldPanic(func() { FuncOf(nil, nil, true) })
 }
 
+func TestFuncOfHelpers(t *testing.T) {
+	type T1 int
+	checkSameType(t, FuncOf1[T1, string](), (func(T1) string)(nil))
+	checkSameType(t, FuncOf1[error, any](), (func(error) any)(nil))
+	if got, want := FuncOf1[int, string](), FuncOf([]Type{TypeOf(0)}, []Type{TypeOf("")}, false); got != want {
+		t.Errorf("FuncOf1[int, string]() = %v, want %v", got, want)
+	}
+
+	checkSameType(t, HandlerFuncOf(nil, false), (func())(nil))
+	checkSameType(t, HandlerFuncOf([]Type{TypeOf(""), TypeOf(T1(0))}, true), (func(string, T1) error)(nil))
+
+	checkSameType(t, VariadicFuncOf(nil, TypeOf(0), nil), (func(...int))(nil))
+	checkSameType(t, VariadicFuncOf([]Type{TypeOf("")}, TypeOf((*any)(nil)).Elem(), []Type{TypeOf(0), TypeOf((*error)(nil)).Elem()}),
+		(func(string, ...any) (int, error))(nil))
+	fixed := []Type{TypeOf(""), TypeOf(0)}
+	VariadicFuncOf(fixed[:1], TypeOf(false), nil)
+	if fixed[1] != TypeOf(0) {
+		t.Errorf("VariadicFuncOf modified the fixed slice")
+	}
+
+	shouldPanic(func() { HandlerFuncOf([]Type{nil}, true) })
+	shouldPanic(func() { VariadicFuncOf([]Type{nil}, TypeOf(0), nil) })
+	shouldPanic(func() { VariadicFuncOf(nil, nil, nil) })
+}
+
+func TestFuncOfCanonical(t *testing.T) {
+	type K string
+	in := []Type{TypeOf(K("")), TypeOf(0)}
+	out := []Type{TypeOf(false)}
+	a, b := FuncOf(in, out, false), FuncOf([]Type{TypeOf(K("")), TypeOf(0)}, []Type{TypeOf(false)}, false)
+	if a != b {
+		t.Fatalf("FuncOf returned distinct types %p and %p for the same signature", a, b)
+	}
+	checkSameType(t, a, (func(K, int) bool)(nil))
+
+	// Signatures differing only in the split between arguments and
+	// results, or in variadic, are different types.
+	sig := []Type{TypeOf(0), SliceOf(TypeOf(0))}
+	variants := []Type{
+		FuncOf(sig, nil, false),
+		FuncOf(sig, nil, true),
+		FuncOf(sig[:1], sig[1:], false),
+		FuncOf(nil, sig, false),
+	}
+	for i, x := range variants {
+		for j, y := range variants {
+			if (i == j) != (x == y) {
+				t.Errorf("FuncOf variants %d (%v) and %d (%v) compare %v", i, x, j, y, x == y)
+			}
+		}
+	}
+
+	if n := testing.AllocsPerRun(100, func() { FuncOf(in, out, false) }); n != 0 && !CrossCheckEnabled() {
+		t.Errorf("repeated FuncOf: %v allocs, want 0", n)
+	}
+}
+
+func BenchmarkFuncOf(b *testing.B) {
+	in := []Type{TypeOf(""), TypeOf(0)}
+	out := []Type{TypeOf(false), TypeOf((*error)(nil)).Elem()}
+	FuncOf(in, out, false)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			FuncOf(in, out, false)
+		}
+	})
+}
+
 type B1 struct {
 	X int
 	Y int
@@ -5226,6 +6684,28 @@ func TestAllocsInterfaceSmall(t *testing.T) {
 	}
 }
 
+func TestAllocsInterfacePointerShaped(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping malloc count in short mode")
+	}
+	if CrossCheckEnabled() {
+		t.Skip("skipping malloc count in cross-check mode")
+	}
+	x := 0
+	for _, v := range []Value{
+		ValueOf(&x),
+		ValueOf(map[string]int{"a": 1}),
+		ValueOf(make(chan int)),
+		ValueOf(func() {}),
+		ValueOf(&x).Elem().Addr(),
+		ValueOf(&struct{ P *int }{&x}).Elem().Field(0),
+	} {
+		if allocs := testing.AllocsPerRun(100, func() { v.Interface() }); allocs > 0 {
+			t.Errorf("%v: allocs: %v", v.Type(), allocs)
+		}
+	}
+}
+
 // An exhaustive is a mechanism for writing exhaustive or stochastic tests.
 // The basic usage is:
 //
@@ -5369,6 +6849,40 @@ func TestFieldByIndexNil(t *testing.T) {
 	t.Fatalf("did not panic")
 }
 
+func TestFieldByIndexErr(t *testing.T) {
+	type P struct {
+		F int
+	}
+	type T struct {
+		*P
+	}
+	type U struct {
+		A int
+		T
+	}
+	v := ValueOf(U{})
+	_, err := v.FieldByIndexErr([]int{1, 0, 0})
+	var nerr *NilEmbeddedError
+	if !errors.As(err, &nerr) {
+		t.Fatalf("got %v, want NilEmbeddedError", err)
+	}
+	if nerr.Type != TypeOf(T{}) || nerr.Field != "P" || nerr.Depth != 1 {
+		t.Fatalf("unexpected error %+v", *nerr)
+	}
+	if !strings.Contains(err.Error(), "nil pointer to embedded struct field P") {
+		t.Fatalf("unexpected message %q", err)
+	}
+
+	v = ValueOf(U{A: 1, T: T{&P{F: 2}}})
+	if f, err := v.FieldByIndexErr([]int{1, 0, 0}); err != nil || f.Int() != 2 {
+		t.Fatalf("got %v, %v", f, err)
+	}
+	if f, err := v.FieldByIndexErr([]int{0}); err != nil || f.Int() != 1 {
+		t.Fatalf("got %v, %v", f, err)
+	}
+	shouldPanic(func() { ValueOf(1).FieldByIndexErr([]int{0, 0}) })
+}
+
 // Given
 //	type Outer struct {
 //		*Inner
@@ -5764,6 +7278,69 @@ func join(b ...[]byte) []byte { return bytes.Join(b, nil) }
 
 func lit(x ...byte) []byte { return x }
 
+func TestExplain(t *testing.T) {
+	type S struct {
+		a, b uintptr
+		c, d *byte
+	}
+	type T struct {
+		x  int32
+		s  S
+		i  any
+		xs [2]string
+		f  float64
+	}
+	bools := func(bits ...byte) []bool {
+		b := make([]bool, len(bits))
+		for i, bit := range bits {
+			b[i] = bit == 1
+		}
+		return b
+	}
+
+	l := Explain(TypeOf(S{}))
+	if l.Size != 4*PtrSize || l.Align != int(PtrSize) || l.DirectIface {
+		t.Errorf("Explain(S) = %+v", l)
+	}
+	if want := bools(0, 0, 1, 1); !DeepEqual(l.PointerWords, want) {
+		t.Errorf("Explain(S).PointerWords = %v, want %v", l.PointerWords, want)
+	}
+	if len(l.Fields) != 4 || l.Fields[2].Name != "c" || l.Fields[2].Offset != 2*PtrSize || !l.Fields[2].Layout.DirectIface {
+		t.Errorf("Explain(S).Fields = %+v", l.Fields)
+	}
+
+	l = Explain(TypeOf(T{}))
+	if want := bools(0, 0, 0, 1, 1, 1, 1, 1, 0, 1, 0, 0); !DeepEqual(l.PointerWords, want) {
+		t.Errorf("Explain(T).PointerWords = %v, want %v", l.PointerWords, want)
+	}
+	if got := l.Fields[1].Layout.PointerWords; !DeepEqual(got, bools(0, 0, 1, 1)) {
+		t.Errorf("Explain(T).Fields[1].PointerWords = %v", got)
+	}
+
+	for _, tc := range []struct {
+		v          any
+		direct     bool
+		words      []bool
+		fieldAlign int
+		nilFields  bool
+	}{
+		{new(int), true, bools(1), int(PtrSize), true},
+		{map[int]int{}, true, bools(1), int(PtrSize), true},
+		{struct{ p *int }{}, true, bools(1), int(PtrSize), false},
+		{[1]*int{}, true, bools(1), int(PtrSize), true},
+		{"", false, bools(1, 0), int(PtrSize), true},
+		{[]int{}, false, bools(1, 0, 0), int(PtrSize), true},
+		{uint8(0), false, bools(0), 1, true},
+		{struct{}{}, false, bools(), 1, false},
+	} {
+		l := Explain(TypeOf(tc.v))
+		if l.DirectIface != tc.direct || !DeepEqual(l.PointerWords, tc.words) || l.FieldAlign != tc.fieldAlign || (l.Fields == nil) != tc.nilFields {
+			t.Errorf("Explain(%T) = %+v", tc.v, l)
+		}
+	}
+	shouldPanic(func() { Explain(nil) })
+}
+
 func TestTypeOfTypeOf(t *testing.T) {
 	// Check that all the type constructors return concrete *rtype implementations.
 	// It's difficult to test directly because the reflect package is only at arm's length.
@@ -6197,3 +7774,11 @@ func iterateToString(it *MapIter) string {
 	sort.Strings(got)
 	return "[" + strings.Join(got, ", ") + "]"
 }
+
+func BenchmarkSetIntNoTracer(b *testing.B) {
+	var x int
+	v := ValueOf(&x).Elem()
+	for i := 0; i < b.N; i++ {
+		v.SetInt(int64(i))
+	}
+}
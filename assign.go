@@ -0,0 +1,73 @@
+package reflect
+
+// ExplainAssign returns a description of why a value of type from is not
+// assignable to type to, or "" if it is.
+//
+// If the two types are distinct but print the same, which happens when two
+// copies of a package, such as two major versions of a module or a vendored
+// duplicate, are linked into the program, the description names the
+// package path of each side so that they can be told apart.
+func ExplainAssign(from, to Type) string {
+	mustBeNonNilType(from, "ExplainAssign")
+	mustBeNonNilType(to, "ExplainAssign")
+	if from.AssignableTo(to) {
+		return ""
+	}
+	return explainNotAssignable(from, to)
+}
+
+// explainNotAssignable is ExplainAssign for types known not to be
+// assignable.
+func explainNotAssignable(from, to Type) string {
+	if msg := explainLookalike(from, to); msg != "" {
+		return msg
+	}
+	return "value of type " + from.String() + " is not assignable to type " + to.String()
+}
+
+// explainLookalike returns a description of the distinct types from and to
+// if they print the same, and "" otherwise.
+func explainLookalike(from, to Type) string {
+	if from == to || from.String() != to.String() {
+		return ""
+	}
+	return "value of type " + from.String() + " (" + typeOrigin(from) + ") is not assignable to type " +
+		to.String() + " (" + typeOrigin(to) + "): distinct types with the same name, " +
+		"probably because duplicate copies of a package are linked in"
+}
+
+// typeOrigin describes where a type that may share its string form with
+// another type comes from: the package of a defined type, or of the
+// unexported fields of a struct type.
+func typeOrigin(t Type) string {
+	if pkg := t.PkgPath(); pkg != "" {
+		return "package " + pkg
+	}
+	if t.Kind() == Struct {
+		for i, n := 0, t.NumField(); i < n; i++ {
+			if f := t.Field(i); f.PkgPath != "" {
+				return "unexported fields of package " + f.PkgPath
+			}
+		}
+	}
+	switch t.Kind() {
+	case Array, Chan, Ptr, Slice:
+		return "element " + typeOrigin(t.Elem())
+	case Map:
+		return "key " + typeOrigin(t.Key()) + ", element " + typeOrigin(t.Elem())
+	}
+	return "unnamed type"
+}
+
+// mustBeAssignableLookalike panics with the description of explainLookalike
+// if x's type is distinct from but prints the same as typ, in which case
+// the regular panic of reflect would be unreadable. Other mismatches are
+// left to reflect.
+func mustBeAssignableLookalike(op string, x Value, typ Type) {
+	if x.typ == typ || x.typ == nil || typ.Kind() == Interface {
+		return
+	}
+	if msg := explainLookalike(x.typ, typ); msg != "" && !x.typ.AssignableTo(typ) {
+		panic("reflect: " + op + ": " + msg)
+	}
+}
@@ -0,0 +1,75 @@
+package reflect_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+func TestExplainAssign(t *testing.T) {
+	// Two copies of a package would declare types that print the same;
+	// struct types with unexported fields of different packages do too.
+	configOf := func(pkg string) reflect.Type {
+		return reflect.StructOf([]reflect.StructField{
+			{Name: "Name", Type: reflect.TypeOf("")},
+			{Name: "port", PkgPath: pkg, Type: reflect.TypeOf(0)},
+		})
+	}
+	v1, v2 := configOf("example.com/cfg"), configOf("example.com/cfg/v2")
+	if v1 == v2 || v1.String() != v2.String() {
+		t.Fatalf("test types %v and %v are not distinct lookalikes", v1, v2)
+	}
+	wantParts := []string{
+		"unexported fields of package example.com/cfg/v2",
+		"unexported fields of package example.com/cfg)",
+		"duplicate copies of a package",
+	}
+	check := func(what, msg string) {
+		t.Helper()
+		for _, part := range wantParts {
+			if !strings.Contains(msg, part) {
+				t.Errorf("%s %q does not mention %q", what, msg, part)
+			}
+		}
+	}
+	check("ExplainAssign", reflect.ExplainAssign(v2, v1))
+
+	if msg := reflect.ExplainAssign(reflect.TypeOf(0), reflect.TypeOf("")); msg != "value of type int is not assignable to type string" {
+		t.Errorf("ExplainAssign(int, string) = %q", msg)
+	}
+	if msg := reflect.ExplainAssign(reflect.TypeOf(0), reflect.TypeOf((*any)(nil)).Elem()); msg != "" {
+		t.Errorf("ExplainAssign(int, any) = %q, want empty", msg)
+	}
+	if msg := reflect.ExplainAssign(v1, v1); msg != "" {
+		t.Errorf("ExplainAssign(v1, v1) = %q, want empty", msg)
+	}
+
+	msg := fmt.Sprint(panicOf(func() { reflect.New(v1).Elem().Set(reflect.New(v2).Elem()) }))
+	if !strings.HasPrefix(msg, "reflect: Set: ") {
+		t.Errorf("Set panicked with %q", msg)
+	}
+	check("Set panic", msg)
+
+	fn := reflect.MakeFunc(reflect.FuncOf([]reflect.Type{v1}, nil, false), func([]reflect.Value) []reflect.Value { return nil })
+	msg = fmt.Sprint(panicOf(func() { fn.Call([]reflect.Value{reflect.New(v2).Elem()}) }))
+	if !strings.HasPrefix(msg, "reflect: Call of ") {
+		t.Errorf("Call panicked with %q", msg)
+	}
+	check("Call panic", msg)
+
+	variadic := reflect.MakeFunc(reflect.FuncOf([]reflect.Type{reflect.SliceOf(v1)}, nil, true), func([]reflect.Value) []reflect.Value { return nil })
+	msg = fmt.Sprint(panicOf(func() { variadic.Call([]reflect.Value{reflect.New(v1).Elem(), reflect.New(v2).Elem()}) }))
+	check("variadic Call panic", msg)
+	msg = fmt.Sprint(panicOf(func() { variadic.CallSlice([]reflect.Value{reflect.MakeSlice(reflect.SliceOf(v2), 0, 0)}) }))
+	if !strings.Contains(msg, "element unexported fields of package example.com/cfg/v2") {
+		t.Errorf("CallSlice panicked with %q", msg)
+	}
+
+	// Other mismatches keep the panics of reflect.
+	msg = fmt.Sprint(panicOf(func() { reflect.New(v1).Elem().Set(reflect.ValueOf(1)) }))
+	if strings.Contains(msg, "duplicate") {
+		t.Errorf("Set of int panicked with %q", msg)
+	}
+}
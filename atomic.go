@@ -0,0 +1,93 @@
+package reflect
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+)
+
+// atomicAddr returns the address of v if v can be accessed atomically.
+func (v Value) atomicAddr(op string) (unsafe.Pointer, error) {
+	if v.flag == 0 {
+		return nil, fmt.Errorf("reflect: %s of zero Value", op)
+	}
+	if v.flag&flagMethod != 0 {
+		return nil, fmt.Errorf("reflect: %s of method value", op)
+	}
+	if v.flag&flagAddr == 0 {
+		return nil, fmt.Errorf("reflect: %s of unaddressable value", op)
+	}
+	switch k := v.flag.kind(); k {
+	case Int, Int32, Int64, Uint, Uint32, Uint64, Uintptr, Ptr, UnsafePointer:
+	default:
+		return nil, fmt.Errorf("reflect: %s of unsupported kind %s", op, k)
+	}
+	size := v.typ.Size()
+	if size != 4 && size != 8 {
+		return nil, fmt.Errorf("reflect: %s of %d-byte value of type %s", op, size, v.typ)
+	}
+	if uintptr(v.ptr)%size != 0 {
+		return nil, fmt.Errorf("reflect: %s of misaligned value of type %s", op, v.typ)
+	}
+	return v.ptr, nil
+}
+
+// LoadAtomic atomically loads the value v refers to and returns it as a new,
+// unaddressable Value of the same type. It is the reflective counterpart of
+// the functions of sync/atomic, for struct fields or other variables that
+// are concurrently accessed with them.
+//
+// v must be addressable and of a 4 or 8-byte integer or pointer kind, suitably
+// aligned; otherwise an error is returned. The result is read-only if v is.
+func (v Value) LoadAtomic() (Value, error) {
+	addr, err := v.atomicAddr("Value.LoadAtomic")
+	if err != nil {
+		return Value{}, err
+	}
+	fl := v.flag&flagRO | flag(v.flag.kind())
+	switch v.flag.kind() {
+	case Ptr, UnsafePointer:
+		return Value{typ: v.typ, ptr: atomic.LoadPointer((*unsafe.Pointer)(addr)), flag: fl}, nil
+	}
+	p := value_New(v.typ).ptr
+	if v.typ.Size() == 4 {
+		*(*uint32)(p) = atomic.LoadUint32((*uint32)(addr))
+	} else {
+		*(*uint64)(p) = atomic.LoadUint64((*uint64)(addr))
+	}
+	return Value{typ: v.typ, ptr: p, flag: fl | flagIndir}, nil
+}
+
+// StoreAtomic atomically stores x into the value v refers to.
+// v must be settable and suitable for LoadAtomic, and x must be assignable
+// to v's type; otherwise an error is returned.
+func (v Value) StoreAtomic(x Value) error {
+	addr, err := v.atomicAddr("Value.StoreAtomic")
+	if err != nil {
+		return err
+	}
+	if v.flag&flagRO != 0 {
+		return fmt.Errorf("reflect: Value.StoreAtomic using value obtained using unexported field")
+	}
+	if x.flag == 0 || x.flag&flagMethod != 0 {
+		return fmt.Errorf("reflect: Value.StoreAtomic of invalid or method value")
+	}
+	if x.flag&flagRO != 0 {
+		return fmt.Errorf("reflect: Value.StoreAtomic of value obtained using unexported field")
+	}
+	if !x.typ.AssignableTo(v.typ) {
+		return fmt.Errorf("reflect: Value.StoreAtomic: value of type %s is not assignable to type %s", x.typ, v.typ)
+	}
+	switch v.flag.kind() {
+	case Ptr, UnsafePointer:
+		atomic.StorePointer((*unsafe.Pointer)(addr), x.pointer())
+	default:
+		if v.typ.Size() == 4 {
+			atomic.StoreUint32((*uint32)(addr), *(*uint32)(x.data()))
+		} else {
+			atomic.StoreUint64((*uint64)(addr), *(*uint64)(x.data()))
+		}
+	}
+	v.traceWrite(WriteStoreAtomic)
+	return nil
+}
@@ -0,0 +1,94 @@
+package reflect_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type atomicCounters struct {
+	Hits    int64
+	Misses  uint32
+	Last    *int
+	Pair    [2]int64
+	Name    string
+	private int64
+}
+
+func TestAtomicLoadStore(t *testing.T) {
+	var c atomicCounters
+	v := reflect.ValueOf(&c).Elem()
+	hits, last := v.Field(0), v.Field(2)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			if err := hits.StoreAtomic(reflect.ValueOf(int64(i))); err != nil {
+				t.Error(err)
+				return
+			}
+			if err := last.StoreAtomic(reflect.ValueOf(&i)); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	prev := int64(0)
+	for i := 0; i < 1000; i++ {
+		x, err := hits.LoadAtomic()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x.Int() < prev {
+			t.Fatalf("loaded %d after %d", x.Int(), prev)
+		}
+		prev = x.Int()
+		if _, err := last.LoadAtomic(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+	if atomic.LoadInt64(&c.Hits) != 1000 {
+		t.Fatalf("got %d, want 1000", c.Hits)
+	}
+
+	misses := v.Field(1)
+	if err := misses.StoreAtomic(reflect.ValueOf(uint32(7))); err != nil {
+		t.Fatal(err)
+	}
+	if x, err := misses.LoadAtomic(); err != nil || x.Uint() != 7 || x.CanAddr() {
+		t.Fatalf("got %v, %v", x, err)
+	}
+	if x, err := v.Field(5).LoadAtomic(); err != nil || x.Int() != 0 || x.CanInterface() {
+		t.Fatalf("load of unexported field must succeed read-only, got %v, %v", x, err)
+	}
+}
+
+func TestAtomicErrors(t *testing.T) {
+	var c atomicCounters
+	v := reflect.ValueOf(&c).Elem()
+	for _, f := range []reflect.Value{
+		v.Field(3),                 // 16-byte array
+		v.Field(3).Index(0).Addr(), // unaddressable pointer
+		v.Field(4),                 // string
+		reflect.ValueOf(int64(1)),  // unaddressable
+		{},                         // invalid
+	} {
+		if _, err := f.LoadAtomic(); err == nil {
+			t.Fatalf("expected error loading %v", f)
+		}
+		if err := f.StoreAtomic(reflect.ValueOf(int64(1))); err == nil {
+			t.Fatalf("expected error storing %v", f)
+		}
+	}
+	if err := v.Field(0).StoreAtomic(reflect.ValueOf(1)); err == nil {
+		t.Fatal("expected error storing int into int64")
+	}
+	if err := v.Field(5).StoreAtomic(reflect.ValueOf(int64(1))); err == nil {
+		t.Fatal("expected error storing into unexported field")
+	}
+}
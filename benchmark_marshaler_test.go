@@ -81,6 +81,7 @@ func compile(typ reflect.Type) (encoder, error) {
 
 func compileStruct(typ reflect.Type) (encoder, error) {
 	encoders := []encoder{}
+	offsets := reflect.FieldOffsets(typ)
 
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
@@ -88,7 +89,7 @@ func compileStruct(typ reflect.Type) (encoder, error) {
 		if err != nil {
 			return nil, err
 		}
-		offset := field.Offset
+		offset := offsets[i]
 		encoders = append(encoders, func(buf *buffer, p unsafe.Pointer) error {
 			return enc(buf, unsafe.Pointer(uintptr(p)+offset))
 		})
@@ -0,0 +1,86 @@
+package reflect
+
+import (
+	"strconv"
+)
+
+// Bind returns a function that calls the function v with args followed by
+// its own arguments, that is, v with its first len(args) parameters bound
+// to args. The returned function's type has those parameters removed.
+//
+// The args are converted to the types of the parameters they are bound to
+// and copied when Bind is called. If v is variadic, args beyond its fixed
+// parameters are bound as the first variadic arguments, and the returned
+// function remains variadic with the remaining arguments appended to them.
+// v may be a method value.
+//
+// Bind panics if v's Kind is not Func, if there are more args than v has
+// parameters and v is not variadic, or if an argument is invalid or not
+// assignable to its parameter.
+func (v Value) Bind(args ...Value) Value {
+	v.mustBeValid("reflect.Value.Bind")
+	if k := v.Kind(); k != Func {
+		panic(&ValueError{Method: "reflect.Value.Bind", Kind: k})
+	}
+	ft := v.Type()
+	n := ft.NumIn()
+	variadic := ft.IsVariadic()
+	fixed := n
+	if variadic {
+		fixed = n - 1
+	}
+	if !variadic && len(args) > n {
+		panic("reflect: Bind of " + strconv.Itoa(len(args)) + " arguments to function with " + strconv.Itoa(n) + " parameters")
+	}
+
+	bound := make([]Value, len(args))
+	for i, arg := range args {
+		var pt Type
+		if i < fixed {
+			pt = ft.In(i)
+		} else {
+			pt = ft.In(fixed).Elem()
+		}
+		if !arg.IsValid() {
+			panic("reflect: Bind with invalid argument " + strconv.Itoa(i))
+		}
+		arg.mustBeExported("reflect.Value.Bind")
+		if msg := ExplainAssign(arg.Type(), pt); msg != "" {
+			panic("reflect: Bind: argument " + strconv.Itoa(i) + ": " + msg)
+		}
+		p := value_Elem(value_New(pt))
+		value_Set(p, arg)
+		bound[i] = p
+	}
+
+	var extras []Value
+	if len(bound) > fixed {
+		bound, extras = bound[:fixed], bound[fixed:]
+	}
+	in := make([]Type, 0, n-len(bound))
+	for i := len(bound); i < n; i++ {
+		in = append(in, ft.In(i))
+	}
+	out := make([]Type, ft.NumOut())
+	for i := range out {
+		out[i] = ft.Out(i)
+	}
+	return MakeFunc(FuncOf(in, out, variadic), func(rest []Value) []Value {
+		full := make([]Value, 0, len(bound)+len(rest))
+		full = append(full, bound...)
+		full = append(full, rest...)
+		if !variadic {
+			return v.Call(full)
+		}
+		if len(extras) > 0 {
+			tail := full[len(full)-1]
+			merged := value_MakeSlice(tail.Type(), len(extras)+value_Len(tail), len(extras)+value_Len(tail))
+			for i, x := range extras {
+				value_Set(value_Index(merged, i), x)
+			}
+			value_Copy(value_Slice(merged, len(extras), value_Len(merged)), tail)
+			full[len(full)-1] = merged
+		}
+		return v.CallSlice(full)
+	})
+}
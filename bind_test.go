@@ -0,0 +1,86 @@
+package reflect_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+func TestBind(t *testing.T) {
+	var buf bytes.Buffer
+	fprintf := reflect.ValueOf(fmt.Fprintf)
+
+	// Bind the writer and the format, leaving the variadic arguments.
+	printf := fprintf.Bind(reflect.ValueOf(&buf), reflect.ValueOf("%s-%d;"))
+	if want := reflect.TypeOf(func(...any) (int, error) { return 0, nil }); printf.Type() != want {
+		t.Fatalf("bound type = %v, want %v", printf.Type(), want)
+	}
+	out := printf.Call([]reflect.Value{reflect.ValueOf("a"), reflect.ValueOf(1)})
+	if n := out[0].Int(); n != 4 || !out[1].IsNil() {
+		t.Fatalf("Call returned %d, %v", n, out[1])
+	}
+	f := printf.Interface().(func(...any) (int, error))
+	if _, err := f("b", 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "a-1;b-2;" {
+		t.Errorf("buffer = %q, want %q", got, "a-1;b-2;")
+	}
+
+	// Bind into the variadic tail.
+	buf.Reset()
+	tail := fprintf.Bind(reflect.ValueOf(&buf), reflect.ValueOf("%v %v %v"), reflect.ValueOf("x"))
+	tail.Interface().(func(...any) (int, error))("y", 3)
+	tail.CallSlice([]reflect.Value{reflect.ValueOf([]any{"z", 4})})
+	if got := buf.String(); got != "x y 3x z 4" {
+		t.Errorf("buffer = %q, want %q", got, "x y 3x z 4")
+	}
+
+	// Bind a non-variadic function, converting to an interface parameter.
+	join := reflect.ValueOf(func(w io.Writer, sep string, a, b string) {
+		io.WriteString(w, a+sep+b)
+	})
+	buf.Reset()
+	g := join.Bind(reflect.ValueOf(&buf), reflect.ValueOf("+")).Interface().(func(string, string))
+	g("1", "2")
+	if buf.String() != "1+2" {
+		t.Errorf("buffer = %q, want %q", buf.String(), "1+2")
+	}
+
+	// Bind all arguments of a method value.
+	buf.Reset()
+	write := reflect.ValueOf(&buf).MethodByName("WriteString").Bind(reflect.ValueOf("m"))
+	write.Call(nil)
+	if buf.String() != "m" {
+		t.Errorf("buffer = %q, want %q", buf.String(), "m")
+	}
+
+	// Bound arguments are copied at bind time.
+	s := []int{1}
+	x := 5
+	add := reflect.ValueOf(func(a int, b []int) int { return a + b[0] }).Bind(reflect.ValueOf(x))
+	x = 6
+	if got := add.Call([]reflect.Value{reflect.ValueOf(s)})[0].Int(); got != 6 {
+		t.Errorf("add = %d, want 6", got)
+	}
+
+	for _, tc := range []struct {
+		f    func()
+		want string
+	}{
+		{func() { join.Bind(reflect.ValueOf(1)) }, "argument 0: value of type int is not assignable to type io.Writer"},
+		{func() {
+			join.Bind(reflect.ValueOf(&buf), reflect.ValueOf("a"), reflect.ValueOf("b"), reflect.ValueOf("c"), reflect.ValueOf("d"))
+		}, "Bind of 5 arguments to function with 4 parameters"},
+		{func() { fprintf.Bind(reflect.Value{}) }, "invalid argument 0"},
+		{func() { reflect.ValueOf(1).Bind() }, "reflect.Value.Bind"},
+	} {
+		if msg := fmt.Sprint(panicOf(tc.f)); !strings.Contains(msg, tc.want) {
+			t.Errorf("panic %q, want %q", msg, tc.want)
+		}
+	}
+}
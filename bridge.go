@@ -33,12 +33,16 @@ func toRV(v Value) reflect.Value {
 	return *(*reflect.Value)(unsafe.Pointer(&v))
 }
 
+// Value and reflect.Value must have the same size for the slice
+// conversions below; these fail to compile if either grows.
+var (
+	_ [unsafe.Sizeof(Value{}) - unsafe.Sizeof(reflect.Value{})]struct{}
+	_ [unsafe.Sizeof(reflect.Value{}) - unsafe.Sizeof(Value{})]struct{}
+)
+
+// toRVs reinterprets v as a []reflect.Value sharing the same backing array.
 func toRVs(v []Value) []reflect.Value {
-	out := make([]reflect.Value, len(v))
-	for idx, vv := range v {
-		out[idx] = toRV(vv)
-	}
-	return out
+	return unsafe.Slice((*reflect.Value)(unsafe.Pointer(unsafe.SliceData(v))), cap(v))[:len(v)]
 }
 
 func toV(v reflect.Value) Value {
@@ -49,12 +53,9 @@ func ToV(v reflect.Value) Value {
 	return toV(v)
 }
 
+// toVs reinterprets v as a []Value sharing the same backing array.
 func toVs(v []reflect.Value) []Value {
-	out := make([]Value, len(v))
-	for idx, vv := range v {
-		out[idx] = toV(vv)
-	}
-	return out
+	return unsafe.Slice((*Value)(unsafe.Pointer(unsafe.SliceData(v))), cap(v))[:len(v)]
 }
 
 func toRSFs(v []StructField) []reflect.StructField {
@@ -107,6 +108,16 @@ func toM(v reflect.Method) Method {
 	}
 }
 
+func toRM(v Method) reflect.Method {
+	return reflect.Method{
+		Name:    v.Name,
+		PkgPath: v.PkgPath,
+		Type:    ToReflectType(v.Type),
+		Func:    toRV(v.Func),
+		Index:   v.Index,
+	}
+}
+
 func toRSC(v SelectCase) reflect.SelectCase {
 	return reflect.SelectCase{
 		Dir:  v.Dir,
@@ -122,3 +133,19 @@ func toRSCs(v []SelectCase) []reflect.SelectCase {
 	}
 	return out
 }
+
+func toSC(v reflect.SelectCase) SelectCase {
+	return SelectCase{
+		Dir:  v.Dir,
+		Chan: toV(v.Chan),
+		Send: toV(v.Send),
+	}
+}
+
+func toSCs(v []reflect.SelectCase) []SelectCase {
+	out := make([]SelectCase, len(v))
+	for idx, vv := range v {
+		out[idx] = toSC(vv)
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package reflect_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	goreflect "github.com/3JoB/go-reflect"
+)
+
+func TestBridgeDirectedChan(t *testing.T) {
+	elem := goreflect.TypeOf(0)
+	ch := make(chan int, 1)
+	for _, dir := range []goreflect.ChanDir{goreflect.RecvDir, goreflect.SendDir, goreflect.BothDir} {
+		typ := goreflect.ChanOf(dir, elem)
+		rtyp := reflect.ChanOf(reflect.ChanDir(dir), reflect.TypeOf(0))
+		if goreflect.ToRT(typ) != rtyp || goreflect.ToT(rtyp) != typ {
+			t.Errorf("ChanOf(%v) does not bridge to the identical type: %v, %v", dir, goreflect.ToRT(typ), rtyp)
+		}
+		if typ.ChanDir() != dir || goreflect.ToRT(typ).ChanDir() != reflect.ChanDir(dir) {
+			t.Errorf("ChanDir of %v = %v, bridged %v", typ, typ.ChanDir(), goreflect.ToRT(typ).ChanDir())
+		}
+
+		// Convert in this package, then bridge to reflect and back.
+		v := goreflect.ValueOf(ch).Convert(typ)
+		rv := goreflect.ToReflectValue(v)
+		if rv.Type() != rtyp || goreflect.ToV(rv).Type() != typ {
+			t.Errorf("Convert to %v bridges to type %v", typ, rv.Type())
+		}
+		// Convert in reflect, then bridge.
+		if got := goreflect.ToV(reflect.ValueOf(ch).Convert(rtyp)).Type(); got != typ {
+			t.Errorf("reflect Convert to %v bridges to type %v", rtyp, got)
+		}
+
+		send := func() { v.TrySend(goreflect.ValueOf(1)) }
+		rsend := func() { rv.TrySend(reflect.ValueOf(1)) }
+		recv := func() { v.TryRecv() }
+		rrecv := func() { rv.TryRecv() }
+		if panics := panicOf(send) != nil; panics != (dir&goreflect.SendDir == 0) {
+			t.Errorf("%v: TrySend panics = %v", typ, panics)
+		}
+		if panics := panicOf(recv) != nil; panics != (dir&goreflect.RecvDir == 0) {
+			t.Errorf("%v: TryRecv panics = %v", typ, panics)
+		}
+		for _, ops := range [][2]func(){{send, rsend}, {recv, rrecv}} {
+			if msg, rmsg := fmt.Sprint(panicOf(ops[0])), fmt.Sprint(panicOf(ops[1])); msg != rmsg {
+				t.Errorf("%v: panic %q, reflect panic %q", typ, msg, rmsg)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package reflect
+
+import (
+	"fmt"
+)
+
+// bulkSlice returns v as a slice if v is a slice, an addressable array or
+// a pointer to an array, so that its elements can be processed in bulk.
+// Unaddressable arrays are returned as is if readOnly is set.
+func bulkSlice(v Value, op string, readOnly bool) Value {
+	if v.flag.kind() == Ptr && v.typ.Elem().Kind() == Array {
+		if v.IsNil() {
+			panic("reflect: " + op + " of nil pointer to array")
+		}
+		v = value_Elem(v)
+	}
+	switch k := v.flag.kind(); k {
+	case Slice:
+		return v
+	case Array:
+		if v.flag&flagAddr == 0 {
+			if readOnly {
+				return v
+			}
+			panic("reflect: " + op + " of unaddressable array")
+		}
+		return value_Slice(v, 0, value_Len(v))
+	default:
+		panic(&ValueError{Method: "reflect." + op, Kind: k})
+	}
+}
+
+// ZeroSlice sets all elements of v to the zero value of their type.
+// v must be a slice, an addressable array or a pointer to an array.
+// The elements are cleared in a single pass that, like the built-in
+// clear, informs the garbage collector of the overwritten pointers, instead
+// of setting them one at a time with Index(i).SetZero.
+// It panics if v was obtained using unexported struct fields.
+func ZeroSlice(v Value) {
+	v.mustBeExported("reflect.ZeroSlice")
+	v = bulkSlice(v, "ZeroSlice", false)
+	toRV(v).Clear()
+}
+
+// CopySliceFast copies the elements of src into dst until either dst has
+// been filled or src has been exhausted, and returns the number of elements
+// copied. Each of dst and src may be a slice, an addressable array or a
+// pointer to an array; src may also be a string if dst's element type is byte.
+//
+// If the element types are identical, the elements are copied with a single
+// memmove that takes care of the garbage collector's write barriers, as done
+// by Copy. Otherwise each element of src is assigned to the corresponding
+// element of dst as by Set, which panics if it is not assignable.
+func CopySliceFast(dst, src Value) int {
+	dst = bulkSlice(dst, "CopySliceFast", false)
+	if src.flag.kind() != String {
+		src = bulkSlice(src, "CopySliceFast", true)
+		if de, se := dst.typ.Elem(), src.typ.Elem(); de != se {
+			n := min(value_Len(dst), value_Len(src))
+			for i := 0; i < n; i++ {
+				value_Set(value_Index(dst, i), value_Index(src, i))
+			}
+			return n
+		}
+	}
+	return value_Copy(dst, src)
+}
+
+// AppendSliceAssign appends the elements of src to the slice dst and returns
+// the resulting slice, like AppendSlice. src may be a slice or an array.
+//
+// Unlike AppendSlice, the element types only need to be assignable, not
+// identical: appending a []*bytes.Buffer to an []io.Reader stores each
+// element as an io.Reader, as a loop of append statements would.
+// If the element types are identical, the elements are copied with a
+// single memmove as AppendSlice does.
+//
+// It returns an error instead of panicking if dst is not a slice, src is
+// not a slice or array, src's element type is not assignable to dst's, or
+// src was obtained using unexported struct fields.
+func AppendSliceAssign(dst, src Value) (Value, error) {
+	if k := dst.Kind(); k != Slice {
+		return Value{}, fmt.Errorf("reflect: AppendSliceAssign to non-slice %s", k)
+	}
+	if k := src.Kind(); k != Slice && k != Array {
+		return Value{}, fmt.Errorf("reflect: AppendSliceAssign from non-slice %s", k)
+	}
+	if src.flag&flagRO != 0 {
+		return Value{}, fmt.Errorf("reflect: AppendSliceAssign using value obtained using unexported field")
+	}
+	de, se := dst.typ.Elem(), src.typ.Elem()
+	if de == se && src.flag.kind() == Slice {
+		return value_AppendSlice(dst, src), nil
+	}
+	if !se.AssignableTo(de) {
+		return Value{}, fmt.Errorf("reflect: AppendSliceAssign: element type %s is not assignable to %s", se, de)
+	}
+	n, m := value_Len(dst), value_Len(src)
+	var out Value
+	if c := value_Cap(dst); n+m <= c {
+		out = value_Slice(dst, 0, n+m)
+	} else {
+		out = value_MakeSlice(dst.typ, n+m, max(2*c, n+m))
+		value_Copy(out, dst)
+	}
+	if de == se {
+		value_Copy(value_Slice(out, n, n+m), src)
+		return out, nil
+	}
+	for i := 0; i < m; i++ {
+		value_Set(value_Index(out, n+i), value_Index(src, i))
+	}
+	return out, nil
+}
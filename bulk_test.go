@@ -0,0 +1,203 @@
+package reflect_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type bulkT struct {
+	a, b int64
+	p    *int
+}
+
+func newBulkSlice(n int) []bulkT {
+	s := make([]bulkT, n)
+	for i := range s {
+		p := new(int)
+		*p = i
+		s[i] = bulkT{a: int64(i), b: int64(-i), p: p}
+	}
+	return s
+}
+
+func TestCopySliceFastGC(t *testing.T) {
+	const n = 1000
+	dst := make([]bulkT, n)
+	var arr [n]bulkT
+	func() {
+		src := newBulkSlice(n)
+		if got := reflect.CopySliceFast(reflect.ValueOf(dst), reflect.ValueOf(src)); got != n {
+			t.Fatalf("copied %d elements, want %d", got, n)
+		}
+		if got := reflect.CopySliceFast(reflect.ValueOf(&arr), reflect.ValueOf(src)); got != n {
+			t.Fatalf("copied %d elements, want %d", got, n)
+		}
+	}()
+	runtime.GC()
+	for i := 0; i < 10; i++ {
+		newBulkSlice(n) // reuse freed memory
+	}
+	runtime.GC()
+	for i := range dst {
+		if dst[i].a != int64(i) || dst[i].b != int64(-i) || *dst[i].p != i {
+			t.Fatalf("lost dst[%d] = %d, %d, %d", i, dst[i].a, dst[i].b, *dst[i].p)
+		}
+		if arr[i] != dst[i] {
+			t.Fatalf("lost arr[%d]", i)
+		}
+	}
+}
+
+func TestCopySliceFast(t *testing.T) {
+	src := [3]int{1, 2, 3}
+	dst := make([]any, 2)
+	if n := reflect.CopySliceFast(reflect.ValueOf(dst), reflect.ValueOf(src)); n != 2 || dst[0] != 1 || dst[1] != 2 {
+		t.Fatalf("failed to copy into differing element type: %d %v", n, dst)
+	}
+	var arr [4]int
+	if n := reflect.CopySliceFast(reflect.ValueOf(&arr).Elem(), reflect.ValueOf(&src)); n != 3 || arr != [4]int{1, 2, 3, 0} {
+		t.Fatalf("failed to copy into array: %d %v", n, arr)
+	}
+	b := make([]byte, 5)
+	if n := reflect.CopySliceFast(reflect.ValueOf(b), reflect.ValueOf("hi")); n != 2 || string(b[:2]) != "hi" {
+		t.Fatalf("failed to copy string: %d %q", n, b)
+	}
+	shouldPanic(func() { reflect.CopySliceFast(reflect.ValueOf(arr), reflect.ValueOf(src)) })
+	shouldPanic(func() { reflect.CopySliceFast(reflect.ValueOf(make([]string, 1)), reflect.ValueOf(src)) })
+	shouldPanic(func() { reflect.CopySliceFast(reflect.ValueOf(1), reflect.ValueOf(src)) })
+}
+
+func TestZeroSlice(t *testing.T) {
+	const n = 1000
+	s := newBulkSlice(n)
+	reflect.ZeroSlice(reflect.ValueOf(s))
+	runtime.GC()
+	for i := range s {
+		if s[i] != (bulkT{}) {
+			t.Fatalf("s[%d] not zeroed: %v", i, s[i])
+		}
+	}
+	arr := [2]bulkT{{a: 1}, {b: 2}}
+	reflect.ZeroSlice(reflect.ValueOf(&arr))
+	if arr != [2]bulkT{} {
+		t.Fatalf("array not zeroed: %v", arr)
+	}
+	arr[1].a = 1
+	reflect.ZeroSlice(reflect.ValueOf(&arr).Elem())
+	if arr != [2]bulkT{} {
+		t.Fatalf("array not zeroed: %v", arr)
+	}
+
+	for _, v := range []reflect.Value{
+		reflect.ValueOf(arr),
+		reflect.ValueOf(1),
+		reflect.ValueOf((*[2]int)(nil)),
+		reflect.ValueOf(&struct{ s []int }{[]int{1}}).Elem().Field(0),
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("ZeroSlice(%v) did not panic", v.Type())
+				}
+			}()
+			reflect.ZeroSlice(v)
+		}()
+	}
+
+	hidden := struct {
+		s []int
+		p *[2]int
+	}{[]int{1}, &[2]int{1, 2}}
+	for i := 0; i < 2; i++ {
+		f := reflect.ValueOf(&hidden).Elem().Field(i)
+		const want = "reflect: reflect.ZeroSlice using value obtained using unexported field"
+		if msg := fmt.Sprint(panicOf(func() { reflect.ZeroSlice(f) })); msg != want {
+			t.Errorf("ZeroSlice(%v) panicked with %q, want %q", f.Type(), msg, want)
+		}
+	}
+}
+
+func BenchmarkZeroSlice(b *testing.B) {
+	s := newBulkSlice(1e6)
+	v := reflect.ValueOf(s)
+	b.Run("Loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < v.Len(); j++ {
+				v.Index(j).SetZero()
+			}
+		}
+	})
+	b.Run("ZeroSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reflect.ZeroSlice(v)
+		}
+	})
+}
+
+func BenchmarkCopySliceFast(b *testing.B) {
+	src := reflect.ValueOf(newBulkSlice(1e6))
+	dst := reflect.ValueOf(make([]bulkT, 1e6))
+	b.Run("Loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < src.Len(); j++ {
+				dst.Index(j).Set(src.Index(j))
+			}
+		}
+	})
+	b.Run("CopySliceFast", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reflect.CopySliceFast(dst, src)
+		}
+	})
+}
+
+func TestAppendSliceAssign(t *testing.T) {
+	b1, b2 := bytes.NewBufferString("a"), bytes.NewBufferString("b")
+	readers := []io.Reader{strings.NewReader("r")}
+	out, err := reflect.AppendSliceAssign(reflect.ValueOf(readers), reflect.ValueOf([]*bytes.Buffer{b1, b2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := out.Interface().([]io.Reader)
+	if len(got) != 3 || got[1] != io.Reader(b1) || got[2] != io.Reader(b2) {
+		t.Fatalf("unexpected result %v", got)
+	}
+
+	type Readers []io.Reader
+	out, err = reflect.AppendSliceAssign(reflect.ValueOf(Readers(nil)), reflect.ValueOf([1]*bytes.Buffer{b1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs, ok := out.Interface().(Readers); !ok || len(rs) != 1 || rs[0] != io.Reader(b1) {
+		t.Fatalf("unexpected result %#v", out.Interface())
+	}
+
+	ints := make([]int, 1, 4)
+	out, err = reflect.AppendSliceAssign(reflect.ValueOf(ints), reflect.ValueOf([]int{2, 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.Interface().([]int); len(got) != 3 || got[2] != 3 || &got[0] != &ints[0] {
+		t.Fatalf("unexpected result %v", got)
+	}
+
+	if _, err := reflect.AppendSliceAssign(reflect.ValueOf([]string{}), reflect.ValueOf([]int{1})); err == nil {
+		t.Fatal("expected error for []int onto []string")
+	}
+	if _, err := reflect.AppendSliceAssign(reflect.ValueOf(1), reflect.ValueOf([]int{1})); err == nil {
+		t.Fatal("expected error for non-slice destination")
+	}
+	if _, err := reflect.AppendSliceAssign(reflect.ValueOf([]int{}), reflect.ValueOf(1)); err == nil {
+		t.Fatal("expected error for non-slice source")
+	}
+}
@@ -0,0 +1,106 @@
+package reflect
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// typeCache lazily computes and caches a value per Type.
+// Types are immutable, so an entry never needs to be invalidated.
+type typeCache[V any] struct {
+	m sync.Map // map[Type]V
+}
+
+// get returns the cached value for t, building it with build on first use.
+// Concurrent first uses may build the value more than once; one of the
+// results wins and is returned to all callers.
+func (c *typeCache[V]) get(t Type, build func(Type) V) V {
+	if v, ok := c.m.Load(t); ok {
+		return v.(V)
+	}
+	v, _ := c.m.LoadOrStore(t, build(t))
+	return v.(V)
+}
+
+var methodIndexCache typeCache[map[string]int]
+
+func buildMethodIndex(t Type) map[string]int {
+	n := type_NumMethod(t)
+	index := make(map[string]int, n)
+	for i := 0; i < n; i++ {
+		index[type_Method(t, i).Name] = i
+	}
+	return index
+}
+
+var fieldOffsetsCache typeCache[[]uintptr]
+
+func buildFieldOffsets(t Type) []uintptr {
+	fields := structLayout(t, "FieldOffsets").fields
+	offsets := make([]uintptr, len(fields))
+	for i, f := range fields {
+		offsets[i] = f.offset
+	}
+	return offsets
+}
+
+// load returns the cached value for t, if any, without building it.
+func (c *typeCache[V]) load(t Type) (V, bool) {
+	v, ok := c.m.Load(t)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return v.(V), true
+}
+
+var fieldIndexCache typeCache[map[string]StructField]
+
+// buildFieldIndex maps the name of every field reachable through t,
+// including promoted fields, to the field FieldByName finds for it.
+// Names that are ambiguous at their shallowest depth are left out,
+// as they are not visible.
+func buildFieldIndex(t Type) map[string]StructField {
+	fields := reflect.VisibleFields(toRT(t))
+	index := make(map[string]StructField, len(fields))
+	for _, f := range fields {
+		index[f.Name] = toSF(f)
+	}
+	return index
+}
+
+var visibleFieldsCache typeCache[[]StructField]
+
+// buildVisibleFields lists the visible fields of t in the order of
+// reflect.VisibleFields.
+func buildVisibleFields(t Type) []StructField {
+	fields := reflect.VisibleFields(toRT(t))
+	out := make([]StructField, len(fields))
+	for i, f := range fields {
+		out[i] = toSF(f)
+	}
+	return out
+}
+
+var foldedFieldCache typeCache[map[string]StructField]
+
+// foldName returns a key under which names that are equal under simple
+// Unicode case folding, such as "ID", "Id" and "id", are the same.
+func foldName(name string) string {
+	return strings.ToLower(strings.ToUpper(name))
+}
+
+// buildFoldedFieldIndex maps the folded name of every visible field of t
+// to the first such field in field order.
+func buildFoldedFieldIndex(t Type) map[string]StructField {
+	fields := reflect.VisibleFields(toRT(t))
+	index := make(map[string]StructField, len(fields))
+	for _, f := range fields {
+		key := foldName(f.Name)
+		if _, ok := index[key]; !ok {
+			index[key] = toSF(f)
+		}
+	}
+	return index
+}
@@ -0,0 +1,120 @@
+package reflect
+
+import (
+	"strconv"
+)
+
+// A ConvertMode selects which conversions CallConvertMode applies to
+// arguments that are not assignable to their parameters.
+type ConvertMode int
+
+const (
+	// ConvertLossless applies conversions that preserve the argument:
+	// numeric conversions only if converting the result back gives the
+	// argument again, and no conversions from integers to strings.
+	ConvertLossless ConvertMode = iota
+	// ConvertAny applies every conversion Convert supports.
+	ConvertAny
+)
+
+// CallConvert is CallConvertMode with ConvertLossless.
+func (v Value) CallConvert(in []Value) []Value {
+	return v.callConvert("CallConvert", in, ConvertLossless)
+}
+
+// CallConvertMode calls the function v with the input arguments in, like
+// Call, but first converts each argument that is not assignable to its
+// parameter to the parameter's type, as Convert does, if mode allows it.
+// Arguments for the variadic parameter of v are converted to its element
+// type. It panics with a message naming the argument and both types if an
+// argument cannot be converted.
+func (v Value) CallConvertMode(in []Value, mode ConvertMode) []Value {
+	return v.callConvert("CallConvertMode", in, mode)
+}
+
+func (v Value) callConvert(op string, in []Value, mode ConvertMode) []Value {
+	v.mustBeValid("reflect.Value." + op)
+	if k := v.Kind(); k != Func {
+		panic(&ValueError{Method: "reflect.Value." + op, Kind: k})
+	}
+	ft := v.Type()
+	n := ft.NumIn()
+	fixed := n
+	if ft.IsVariadic() {
+		fixed = n - 1
+	}
+	var conv []Value
+	for i, x := range in {
+		var pt Type
+		switch {
+		case i < fixed:
+			pt = ft.In(i)
+		case fixed < n:
+			pt = ft.In(fixed).Elem()
+		default:
+			// Leave the count mismatch to Call.
+			return v.Call(in)
+		}
+		if !x.IsValid() || x.typeOf().AssignableTo(pt) {
+			continue
+		}
+		if reason := convertProblem(x, pt, mode); reason != "" {
+			panic("reflect: " + op + ": argument " + strconv.Itoa(i) + " of type " + x.typeOf().String() +
+				" cannot be converted to parameter type " + pt.String() + ": " + reason)
+		}
+		if conv == nil {
+			conv = make([]Value, len(in))
+			copy(conv, in)
+		}
+		conv[i] = x.Convert(pt)
+	}
+	if conv == nil {
+		return v.Call(in)
+	}
+	return v.Call(conv)
+}
+
+// convertProblem returns why x cannot be converted to t under mode,
+// or "" if it can.
+func convertProblem(x Value, t Type, mode ConvertMode) string {
+	xt := x.typeOf()
+	if !xt.ConvertibleTo(t) {
+		return "not convertible"
+	}
+	if mode == ConvertAny {
+		return ""
+	}
+	xk, tk := x.Kind(), t.Kind()
+	if isIntKind(xk) && tk == String {
+		return "integer to string conversion"
+	}
+	if !isNumberKind(xk) || !isNumberKind(tk) {
+		return ""
+	}
+	back := x.Convert(t).Convert(xt)
+	if !sameNumber(x, back) {
+		return "value " + string(AppendValue(nil, x, 0)) + " would change"
+	}
+	return ""
+}
+
+func isNumberKind(k Kind) bool {
+	return Int <= k && k <= Complex128
+}
+
+// sameNumber reports whether the numbers x and y of the same type are equal,
+// treating NaNs as equal to each other.
+func sameNumber(x, y Value) bool {
+	switch k := x.Kind(); {
+	case Int <= k && k <= Int64:
+		return x.Int() == y.Int()
+	case Uint <= k && k <= Uintptr:
+		return x.Uint() == y.Uint()
+	case k == Float32 || k == Float64:
+		a, b := x.Float(), y.Float()
+		return a == b || a != a && b != b
+	default:
+		a, b := x.Complex(), y.Complex()
+		return a == b || a != a && b != b
+	}
+}
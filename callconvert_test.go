@@ -0,0 +1,87 @@
+package reflect_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type celsius float64
+
+type userID int64
+
+func TestValueCallConvert(t *testing.T) {
+	wide := reflect.ValueOf(func(a int64, b float64, c uint32) string {
+		return fmt.Sprint(a, b, c)
+	})
+	out := wide.CallConvert([]reflect.Value{reflect.ValueOf(int8(-3)), reflect.ValueOf(float32(1.5)), reflect.ValueOf(uint8(7))})
+	if got := out[0].String(); got != "-3 1.5 7" {
+		t.Errorf("numeric widening gave %q", got)
+	}
+	// Narrowing is allowed as long as the value survives.
+	out = wide.CallConvert([]reflect.Value{reflect.ValueOf(2), reflect.ValueOf(3), reflect.ValueOf(4)})
+	if got := out[0].String(); got != "2 3 4" {
+		t.Errorf("lossless narrowing gave %q", got)
+	}
+
+	named := reflect.ValueOf(func(id userID, temp celsius, d time.Duration) string {
+		return fmt.Sprint(int64(id), float64(temp), d)
+	})
+	out = named.CallConvert([]reflect.Value{reflect.ValueOf(int64(42)), reflect.ValueOf(21.5), reflect.ValueOf(int64(time.Second))})
+	if got := out[0].String(); got != "42 21.5 1s" {
+		t.Errorf("named-type conversion gave %q", got)
+	}
+
+	variadic := reflect.ValueOf(func(prefix string, xs ...float64) float64 {
+		sum := 0.0
+		for _, x := range xs {
+			sum += x
+		}
+		return sum
+	})
+	out = variadic.CallConvert([]reflect.Value{reflect.ValueOf("p"), reflect.ValueOf(1), reflect.ValueOf(uint16(2))})
+	if got := out[0].Float(); got != 3 {
+		t.Errorf("variadic conversion gave %v", got)
+	}
+
+	// Method values are passed as their func type.
+	apply := reflect.ValueOf(func(f func() string) string { return f() })
+	out = apply.CallConvert([]reflect.Value{reflect.ValueOf(time.March).MethodByName("String")})
+	if got := out[0].String(); got != "March" {
+		t.Errorf("method value argument gave %q", got)
+	}
+
+	// ConvertAny allows lossy conversions.
+	out = wide.CallConvertMode([]reflect.Value{reflect.ValueOf(1), reflect.ValueOf(0.1), reflect.ValueOf(-1)}, reflect.ConvertAny)
+	if got := out[0].String(); got != "1 0.1 4294967295" {
+		t.Errorf("ConvertAny gave %q", got)
+	}
+
+	for _, tc := range []struct {
+		f    func()
+		want string
+	}{
+		{func() {
+			wide.CallConvert([]reflect.Value{reflect.ValueOf("x"), reflect.ValueOf(1.0), reflect.ValueOf(uint32(1))})
+		}, "reflect: CallConvert: argument 0 of type string cannot be converted to parameter type int64: not convertible"},
+		{func() {
+			wide.CallConvert([]reflect.Value{reflect.ValueOf(1), reflect.ValueOf(1.0), reflect.ValueOf(-1)})
+		}, "argument 2 of type int cannot be converted to parameter type uint32: value -1 would change"},
+		{func() {
+			wide.CallConvert([]reflect.Value{reflect.ValueOf(1), reflect.ValueOf(1e300), reflect.ValueOf(1)})
+		}, ""},
+		{func() {
+			reflect.ValueOf(func(string) {}).CallConvert([]reflect.Value{reflect.ValueOf(65)})
+		}, "integer to string conversion"},
+		{func() {
+			reflect.ValueOf(func(float32) {}).CallConvert([]reflect.Value{reflect.ValueOf(0.1)})
+		}, "value 0.1 would change"},
+	} {
+		if msg := fmt.Sprint(panicOf(tc.f)); !strings.Contains(msg, tc.want) || tc.want == "" && msg != "<nil>" {
+			t.Errorf("panic %q, want %q", msg, tc.want)
+		}
+	}
+}
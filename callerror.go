@@ -0,0 +1,99 @@
+package reflect
+
+import (
+	"fmt"
+)
+
+// A CallError is the panic value of Call, CallSlice and related methods
+// when the arguments do not match the parameters of the function, and of
+// a function created by MakeFunc when its implementation returns results
+// that do not match the function type.
+type CallError struct {
+	Op    string // "Call", "CallSlice" or "MakeFunc"
+	Func  Type   // type of the function called or created
+	Index int    // index of the offending argument or result, or -1 if their number is wrong
+
+	// Got is the type of the offending argument or result, or nil for the
+	// zero Value; Want is the type it must be assignable to.
+	Got, Want Type
+
+	// NumGot and NumWant are the number of arguments or results given and
+	// required, if Index is -1. For a variadic function called with Call,
+	// NumWant is the number of fixed parameters.
+	NumGot, NumWant int
+}
+
+func (e *CallError) Error() string {
+	what, prefix := "argument", "reflect: "+e.Op+" of "+e.Func.String()
+	if e.Op == "MakeFunc" {
+		what, prefix = "result", "reflect: function of type "+e.Func.String()+" created by MakeFunc"
+	}
+	switch {
+	case e.Index < 0 && e.Op == "MakeFunc":
+		return fmt.Sprintf("%s returned %d results, want %d", prefix, e.NumGot, e.NumWant)
+	case e.Index < 0:
+		few := "many"
+		if e.NumGot < e.NumWant {
+			few = "few"
+		}
+		want := fmt.Sprint(e.NumWant)
+		if e.Op == "Call" && e.Func.IsVariadic() {
+			want = "at least " + want
+		}
+		return fmt.Sprintf("%s with too %s input arguments: got %d, want %s", prefix, few, e.NumGot, want)
+	case e.Got == nil:
+		return fmt.Sprintf("%s: %s %d is the zero Value", prefix, what, e.Index)
+	}
+	return fmt.Sprintf("%s: %s %d: %s", prefix, what, e.Index, explainNotAssignable(e.Got, e.Want))
+}
+
+// mustBeCallable panics with a CallError if in does not match the
+// parameters of the function v. Other problems are left to reflect.
+func mustBeCallable(op string, v Value, in []Value) {
+	if v.flag.kind() != Func {
+		return
+	}
+	ft := v.typeOf()
+	n := type_NumIn(ft)
+	variadic := type_IsVariadic(ft)
+	fixed := n
+	if op != "CallSlice" && variadic {
+		fixed = n - 1
+	}
+	if len(in) < fixed || len(in) > fixed && (op == "CallSlice" || !variadic) {
+		if op == "CallSlice" && !variadic {
+			return
+		}
+		panic(&CallError{Op: op, Func: ft, Index: -1, NumGot: len(in), NumWant: fixed})
+	}
+	for i, x := range in {
+		var want Type
+		if i < fixed {
+			want = toT(type_In(ft, i))
+		} else {
+			want = toT(type_In(ft, fixed)).Elem()
+		}
+		mustBeAssignableArg(op, ft, i, x, want)
+	}
+}
+
+func mustBeAssignableArg(op string, ft Type, i int, x Value, want Type) {
+	if x.flag == 0 {
+		panic(&CallError{Op: op, Func: ft, Index: i, Want: want})
+	}
+	if xt := x.typeOf(); xt != want && !xt.AssignableTo(want) {
+		panic(&CallError{Op: op, Func: ft, Index: i, Got: xt, Want: want})
+	}
+}
+
+// mustBeResultsOf panics with a CallError if out does not match the
+// results of the function type ft created by MakeFunc.
+func mustBeResultsOf(ft Type, out []Value) {
+	n := type_NumOut(ft)
+	if len(out) != n {
+		panic(&CallError{Op: "MakeFunc", Func: ft, Index: -1, NumGot: len(out), NumWant: n})
+	}
+	for i, x := range out {
+		mustBeAssignableArg("MakeFunc", ft, i, x, toT(type_Out(ft, i)))
+	}
+}
@@ -0,0 +1,79 @@
+package reflect_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/3JoB/go-reflect"
+)
+
+func TestCallError(t *testing.T) {
+	fn := reflect.ValueOf(func(int, string) {})
+	variadic := reflect.ValueOf(func(string, ...int) {})
+	for _, tc := range []struct {
+		name  string
+		f     func()
+		index int
+		want  string
+	}{
+		{"too few", func() { fn.Call([]reflect.Value{reflect.ValueOf(1)}) }, -1,
+			"reflect: Call of func(int, string) with too few input arguments: got 1, want 2"},
+		{"too many", func() { fn.Call([]reflect.Value{reflect.ValueOf(1), reflect.ValueOf("a"), reflect.ValueOf(2)}) }, -1,
+			"reflect: Call of func(int, string) with too many input arguments: got 3, want 2"},
+		{"wrong type", func() { fn.Call([]reflect.Value{reflect.ValueOf(1), reflect.ValueOf(2)}) }, 1,
+			"reflect: Call of func(int, string): argument 1: value of type int is not assignable to type string"},
+		{"zero Value", func() { fn.Call([]reflect.Value{{}, reflect.ValueOf("a")}) }, 0,
+			"reflect: Call of func(int, string): argument 0 is the zero Value"},
+		{"variadic too few", func() { variadic.Call(nil) }, -1,
+			"reflect: Call of func(string, ...int) with too few input arguments: got 0, want at least 1"},
+		{"variadic element", func() { variadic.Call([]reflect.Value{reflect.ValueOf("a"), reflect.ValueOf(1), reflect.ValueOf(2.5)}) }, 2,
+			"argument 2: value of type float64 is not assignable to type int"},
+		{"CallSlice", func() { variadic.CallSlice([]reflect.Value{reflect.ValueOf("a"), reflect.ValueOf([]string{})}) }, 1,
+			"reflect: CallSlice of func(string, ...int): argument 1: value of type []string is not assignable to type []int"},
+		{"method value", func() {
+			reflect.ValueOf(&strings.Builder{}).MethodByName("WriteString").Call([]reflect.Value{reflect.ValueOf(1)})
+		}, 0, "reflect: Call of func(string) (int, error): argument 0"},
+		{"method value argument", func() {
+			fn.Call([]reflect.Value{reflect.ValueOf(1), reflect.ValueOf(time.March).MethodByName("String")})
+		}, 1, "argument 1: value of type func() string is not assignable to type string"},
+		{"MakeFunc count", func() {
+			reflect.MakeFunc(reflect.TypeOf(func() int { return 0 }), func([]reflect.Value) []reflect.Value { return nil }).Call(nil)
+		}, -1, "reflect: function of type func() int created by MakeFunc returned 0 results, want 1"},
+		{"MakeFunc type", func() {
+			reflect.MakeFunc(reflect.TypeOf(func() int { return 0 }), func([]reflect.Value) []reflect.Value {
+				return []reflect.Value{reflect.ValueOf("x")}
+			}).Call(nil)
+		}, 0, "created by MakeFunc: result 0: value of type string is not assignable to type int"},
+		{"MakeFunc method value", func() {
+			reflect.MakeFunc(reflect.TypeOf(func() int { return 0 }), func([]reflect.Value) []reflect.Value {
+				return []reflect.Value{reflect.ValueOf(time.March).MethodByName("String")}
+			}).Call(nil)
+		}, 0, "result 0: value of type func() string is not assignable to type int"},
+	} {
+		var err *reflect.CallError
+		if e, _ := panicOf(tc.f).(error); !errors.As(e, &err) {
+			t.Errorf("%s: panic %v is not a *CallError", tc.name, e)
+			continue
+		}
+		if err.Index != tc.index || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: panic %q (index %d), want %q (index %d)", tc.name, err, err.Index, tc.want, tc.index)
+		}
+	}
+}
+
+// Method values are passed and returned as their func type.
+func TestCallMethodValue(t *testing.T) {
+	month := reflect.ValueOf(time.March).MethodByName("String")
+	apply := reflect.ValueOf(func(f func() string) string { return f() })
+	if got := apply.Call([]reflect.Value{month})[0].String(); got != "March" {
+		t.Errorf("Call with a method value argument = %q, want March", got)
+	}
+	mf := reflect.MakeFunc(reflect.TypeOf(func() func() string { return nil }), func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{month}
+	})
+	if got := mf.Interface().(func() func() string)()(); got != "March" {
+		t.Errorf("MakeFunc returning a method value gave %q, want March", got)
+	}
+}
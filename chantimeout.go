@@ -0,0 +1,72 @@
+package reflect
+
+import (
+	"time"
+)
+
+// RecvTimeout is like Recv but gives up after d. It returns the zero Value,
+// false and true if no value was received within d; otherwise x and ok are
+// as for Recv and timedOut is false. A d of zero or less only receives a
+// value that is ready, as TryRecv does.
+// It panics if v's Kind is not Chan or if v is send-only.
+func (v Value) RecvTimeout(d time.Duration) (x Value, ok, timedOut bool) {
+	v.mustBeValid("reflect.Value.RecvTimeout")
+	mustBeChanDir(v, "reflect.Value.RecvTimeout", RecvDir)
+	if x, ok := value_TryRecv(v); x.flag != 0 || d <= 0 {
+		return x, ok, x.flag == 0
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	chosen, x, ok := value_Select([]SelectCase{
+		{Dir: SelectRecv, Chan: v},
+		{Dir: SelectRecv, Chan: ValueOf(t.C)},
+	})
+	if chosen == 1 {
+		return Value{}, false, true
+	}
+	return x, ok, false
+}
+
+// SendTimeout is like Send but gives up after d, and reports whether x
+// was sent. A d of zero or less only sends if the send can proceed
+// without blocking, as TrySend does.
+// It panics if v's Kind is not Chan, if v is receive-only, or if x is not
+// assignable to v's element type.
+func (v Value) SendTimeout(x Value, d time.Duration) bool {
+	v.mustBeValid("reflect.Value.SendTimeout")
+	mustBeChanDir(v, "reflect.Value.SendTimeout", SendDir)
+	// TrySend checks x as Send does.
+	if value_TrySend(v, x) {
+		v.traceWrite(WriteSend)
+		return true
+	}
+	if d <= 0 {
+		return false
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	chosen, _, _ := value_Select([]SelectCase{
+		{Dir: SelectSend, Chan: v, Send: x},
+		{Dir: SelectRecv, Chan: ValueOf(t.C)},
+	})
+	if chosen == 1 {
+		return false
+	}
+	v.traceWrite(WriteSend)
+	return true
+}
+
+// mustBeChanDir panics as Recv and Send do if v is not a channel that
+// allows the operation dir.
+func mustBeChanDir(v Value, method string, dir ChanDir) {
+	if k := v.flag.kind(); k != Chan {
+		panic(&ValueError{Method: method, Kind: k})
+	}
+	v.mustBeExported(method)
+	if v.typ.ChanDir()&dir == 0 {
+		if dir == RecvDir {
+			panic("reflect: recv on send-only channel")
+		}
+		panic("reflect: send on recv-only channel")
+	}
+}
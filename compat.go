@@ -0,0 +1,125 @@
+package reflect
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+// compatProbe is a cheap, deterministic check of one unsafe layout
+// assumption this package makes about the runtime and the reflect package.
+type compatProbe struct {
+	name string
+	ok   func() bool
+}
+
+var compatSentinel = struct {
+	A int `probe:"a"`
+	b string
+}{A: 1, b: "b"}
+
+// compatStringer is stored directly in interfaces, so an interface holding
+// one has p as its data word.
+type compatStringer struct{ p unsafe.Pointer }
+
+func (compatStringer) String() string { return "" }
+
+var compatProbes = []compatProbe{
+	{"Value size and alignment", func() bool {
+		return unsafe.Sizeof(Value{}) == unsafe.Sizeof(reflect.Value{}) &&
+			unsafe.Alignof(Value{}) == unsafe.Alignof(reflect.Value{})
+	}},
+	{"Type bridge round-trip", func() bool {
+		rt := reflect.TypeOf(compatSentinel)
+		return toRT(TypeOf(compatSentinel)) == rt && toT(rt) == TypeOf(compatSentinel)
+	}},
+	{"Value flag round-trip", func() bool {
+		x := 1
+		v := ValueOf(&x).Elem()
+		rv := toRV(v)
+		return rv.Kind() == reflect.Int && rv.CanAddr() && rv.CanSet() && toV(rv) == v
+	}},
+	{"read-only flag round-trip", func() bool {
+		v := ValueOf(&compatSentinel).Elem().Field(1)
+		rv := toRV(v)
+		return rv.Kind() == reflect.String && rv.CanAddr() && !rv.CanSet() && !rv.CanInterface() && toV(rv) == v
+	}},
+	{"struct type layout", func() bool {
+		t := TypeOf(compatSentinel)
+		fields := structLayout(t, "probe").fields
+		if len(fields) != t.NumField() {
+			return false
+		}
+		for i, f := range fields {
+			sf := type_Field(t, i)
+			if f.name.name() != sf.Name || f.offset != sf.Offset || toRT(f.typ) != sf.Type ||
+				f.name.tag() != string(sf.Tag) || f.name.isEmbedded() != sf.Anonymous {
+				return false
+			}
+		}
+		return true
+	}},
+	{"pointer Value round-trip", func() bool {
+		p := &compatSentinel
+		v := ValueOf(p)
+		rv := toRV(v)
+		return rv.Kind() == reflect.Pointer && rv.UnsafePointer() == unsafe.Pointer(p) &&
+			rv.Elem().Field(0).Int() == 1 && toV(rv) == v
+	}},
+	{"map Value round-trip", func() bool {
+		m := map[string]int{"a": 1}
+		v := ValueOf(m)
+		rv := toRV(v)
+		return rv.Kind() == reflect.Map && rv.Len() == 1 &&
+			rv.MapIndex(reflect.ValueOf("a")).Int() == 1 && toV(rv) == v
+	}},
+	{"MapIter layout", func() bool {
+		m := map[string]int{"a": 1}
+		v := ValueOf(m)
+		it := v.MapRange()
+		return MapIterMap(it) == v && MapIterKeyType(it) == TypeOf("")
+	}},
+	{"TypeAndPtrOf sentinel", func() bool {
+		p := &compatSentinel
+		typ, ptr := TypeAndPtrOf(p)
+		return typ == TypeOf(p) && typ == ValueOf(p).typ && ptr == unsafe.Pointer(p)
+	}},
+	{"itab layout", func() bool {
+		p := &compatSentinel
+		var s fmt.Stringer = compatStringer{unsafe.Pointer(p)}
+		typ, ptr := IfaceWordsOf(s)
+		return typ == TypeOf(compatStringer{}) && ptr == unsafe.Pointer(p)
+	}},
+}
+
+// runCompatProbes runs probes and returns an error listing every failed one.
+func runCompatProbes(probes []compatProbe) error {
+	var failed []string
+	for _, p := range probes {
+		if !p.ok() {
+			failed = append(failed, p.name)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("go-reflect is incompatible with this Go version (%s): failed probes: %s", runtime.Version(), strings.Join(failed, ", "))
+}
+
+// CheckCompat reports whether the unsafe layout assumptions this package
+// makes about the runtime and the reflect package hold for the Go version
+// the program was built with. The same checks run at init time, where a
+// failure panics, unless the package is built with the
+// goreflect_skipcompatcheck tag; CheckCompat lets applications built that
+// way probe at startup and handle an incompatibility themselves.
+func CheckCompat() error {
+	if err := validateTypeOf(); err != nil {
+		return err
+	}
+	if err := validateValueOf(); err != nil {
+		return err
+	}
+	return runCompatProbes(compatProbes)
+}
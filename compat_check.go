@@ -0,0 +1,7 @@
+//go:build !goreflect_skipcompatcheck
+
+package reflect
+
+// SkipCompatCheck reports whether the init-time compatibility probes are
+// skipped. Build with the goreflect_skipcompatcheck tag to skip them.
+const SkipCompatCheck = false
@@ -0,0 +1,12 @@
+//go:build goreflect_compatfake
+
+package reflect
+
+// SimulateCompatMismatch reports whether the tests run with a failing
+// compatibility probe. Build with the goreflect_compatfake tag to add it;
+// it is registered after the init-time check, so only CheckCompat sees it.
+const SimulateCompatMismatch = true
+
+func init() {
+	compatProbes = append(compatProbes, compatProbe{"simulated layout mismatch", func() bool { return false }})
+}
@@ -0,0 +1,7 @@
+//go:build !goreflect_compatfake
+
+package reflect
+
+// SimulateCompatMismatch reports whether the tests run with a failing
+// compatibility probe. Build with the goreflect_compatfake tag to add it.
+const SimulateCompatMismatch = false
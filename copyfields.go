@@ -0,0 +1,100 @@
+package reflect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CopyStructFields copies the exported fields of the struct src to the
+// fields of the same name of the struct dst, typically between a DTO and a
+// domain type, and returns the number of fields copied. Tags are ignored.
+//
+// Fields promoted from embedded structs are matched by name like fields of
+// the struct itself, and nil embedded pointers of dst on the way to a field
+// are allocated; a field promoted through a nil embedded pointer of src is
+// not copied. A value is copied if its type is assignable to the type of the
+// dst field, as for Set, or else if it is convertible to it, as for Convert,
+// except from an integer to a string.
+//
+// Fields that only exist in one of the structs are skipped silently. Fields
+// that exist in both but cannot be copied are skipped as well, and reported
+// together in the returned error after all other fields have been copied.
+// It returns an error without copying anything if dst is not a settable
+// struct or src is not a struct whose fields can be read.
+func CopyStructFields(dst, src Value) (copied int, err error) {
+	if k := dst.Kind(); k != Struct {
+		return 0, fmt.Errorf("reflect: CopyStructFields to non-struct %s", k)
+	}
+	if !dst.CanSet() {
+		return 0, errors.New("reflect: CopyStructFields to unaddressable or unexported struct " + dst.typ.String())
+	}
+	if k := src.Kind(); k != Struct {
+		return 0, fmt.Errorf("reflect: CopyStructFields from non-struct %s", k)
+	}
+	if src.flag&flagRO != 0 {
+		return 0, errors.New("reflect: CopyStructFields from struct obtained using unexported field")
+	}
+	srcFields := fieldIndexCache.get(src.typ, buildFieldIndex)
+	var errs []error
+	for _, df := range visibleFieldsCache.get(dst.typ, buildVisibleFields) {
+		if !df.IsExported() || df.Anonymous && isStructOrPtrToStruct(df.Type) {
+			// Embedded structs are copied through their promoted fields.
+			continue
+		}
+		sf, ok := srcFields[df.Name]
+		if !ok || !sf.IsExported() {
+			continue
+		}
+		s, ok := fieldByIndexPath(src, sf.Index, false)
+		if !ok {
+			continue
+		}
+		st := s.typ
+		convert := false
+		if !st.AssignableTo(df.Type) {
+			if !st.ConvertibleTo(df.Type) || isIntKind(st.Kind()) && df.Type.Kind() == String {
+				errs = append(errs, fmt.Errorf("reflect: CopyStructFields: field %s of type %s cannot be copied to type %s", df.Name, st, df.Type))
+				continue
+			}
+			convert = true
+		}
+		d, err := settableFieldByIndex(dst, df.Index)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reflect: CopyStructFields: field %s: %w", df.Name, err))
+			continue
+		}
+		if convert {
+			s = value_Convert(s, df.Type)
+		}
+		value_Set(d, s)
+		copied++
+	}
+	return copied, errors.Join(errs...)
+}
+
+func isStructOrPtrToStruct(t Type) bool {
+	return t.Kind() == Struct || t.Kind() == Ptr && t.Elem().Kind() == Struct
+}
+
+func isIntKind(k Kind) bool {
+	return Int <= k && k <= Uintptr
+}
+
+// settableFieldByIndex returns the field of the settable struct v at index,
+// allocating nil embedded pointers on the way to it. It returns an error if
+// such a pointer is itself an unexported field and cannot be set.
+func settableFieldByIndex(v Value, index []int) (Value, error) {
+	for i, x := range index {
+		if i > 0 && v.flag.kind() == Ptr {
+			if v.IsNil() {
+				if v.flag&flagRO != 0 {
+					return Value{}, errors.New("nil pointer to unexported embedded struct " + v.typ.Elem().String())
+				}
+				value_Set(v, New(v.typ.Elem()))
+			}
+			v = value_Elem(v)
+		}
+		v = value_Field(v, x)
+	}
+	return v, nil
+}
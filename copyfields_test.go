@@ -0,0 +1,95 @@
+package reflect_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type CopyAudit struct {
+	CreatedBy string
+	Version   int32
+}
+
+type copyHidden struct {
+	Secret string
+}
+
+type userDTO struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Age   float64
+	Admin string
+	CopyAudit
+	Extra string
+}
+
+type userModel struct {
+	ID      int64 `db:"id"`
+	Name    string
+	Email   string `db:"email"`
+	Age     int
+	Admin   bool
+	Version int64
+	*CopyAudit
+	*copyHidden
+	Secret string
+	local  string
+}
+
+func TestCopyStructFields(t *testing.T) {
+	src := userDTO{ID: 1, Name: "n", Email: "e", Age: 42.9, Admin: "yes", CopyAudit: CopyAudit{CreatedBy: "c", Version: 3}}
+	var dst userModel
+	n, err := reflect.CopyStructFields(reflect.ValueOf(&dst).Elem(), reflect.ValueOf(src))
+	// Admin has the same name but string is not convertible to bool.
+	if err == nil || !strings.Contains(err.Error(), "field Admin of type string cannot be copied to type bool") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// ID, Name, Email, Age, Version and CreatedBy.
+	if n != 6 {
+		t.Fatalf("copied %d fields, want 6", n)
+	}
+	if dst.ID != 1 || dst.Name != "n" || dst.Email != "e" || dst.Age != 42 || dst.Admin {
+		t.Fatalf("unexpected result %+v", dst)
+	}
+	// Version of the model shadows the promoted field and is converted.
+	if dst.Version != 3 || dst.CopyAudit == nil || dst.CopyAudit.CreatedBy != "c" || dst.CopyAudit.Version != 0 {
+		t.Fatalf("unexpected promoted fields %+v, %+v", dst, dst.CopyAudit)
+	}
+
+	// Back again: promoted fields of a nil embedded pointer are skipped,
+	// while Version is promoted in the destination.
+	dst.CopyAudit = nil
+	var back userDTO
+	n, err = reflect.CopyStructFields(reflect.ValueOf(&back).Elem(), reflect.ValueOf(dst))
+	if err == nil || n != 5 {
+		t.Fatalf("copied %d fields with error %v, want 5 and an error for Admin", n, err)
+	}
+	if back.ID != 1 || back.Name != "n" || back.Email != "e" || back.Age != 42 || back.CopyAudit != (CopyAudit{Version: 3}) {
+		t.Fatalf("unexpected result %+v", back)
+	}
+
+	type withSecret struct{ Secret string }
+	var m userModel
+	n, err = reflect.CopyStructFields(reflect.ValueOf(&m).Elem(), reflect.ValueOf(withSecret{"s"}))
+	if err != nil || n != 1 || m.Secret != "s" || m.copyHidden != nil {
+		t.Fatalf("copied %d fields with error %v: %+v", n, err, m)
+	}
+
+	type intID struct{ Name int }
+	if n, err := reflect.CopyStructFields(reflect.ValueOf(&m).Elem(), reflect.ValueOf(intID{65})); err == nil || n != 0 {
+		t.Fatalf("int to string conversion: copied %d fields with error %v", n, err)
+	}
+
+	if _, err := reflect.CopyStructFields(reflect.ValueOf(m), reflect.ValueOf(src)); err == nil {
+		t.Fatal("expected error for unaddressable destination")
+	}
+	if _, err := reflect.CopyStructFields(reflect.ValueOf(&m).Elem(), reflect.ValueOf(1)); err == nil {
+		t.Fatal("expected error for non-struct source")
+	}
+	if _, err := reflect.CopyStructFields(reflect.ValueOf(&m).Elem().Field(9), reflect.ValueOf(src)); err == nil {
+		t.Fatal("expected error for non-struct destination")
+	}
+}
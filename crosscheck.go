@@ -0,0 +1,199 @@
+package reflect
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync/atomic"
+)
+
+var crossCheckEnabled atomic.Bool
+
+// EnableCrossCheck turns the cross-check mode on or off.
+//
+// While enabled, TypeOf, ValueOf, Value.Field, MethodByName, Value.Convert
+// and Value.Call additionally run the equivalent operation of the standard
+// reflect package and panic if the results or the panic behavior diverge.
+// It is meant for CI runs that verify the unsafe layout assumptions of this
+// package against the Go toolchain in use, and is off by default.
+//
+// Call is never executed twice, because the function may have side effects.
+// Instead its arguments and results are checked to survive the conversion
+// to and from reflect.Value unchanged.
+func EnableCrossCheck(enable bool) {
+	crossCheckEnabled.Store(enable)
+}
+
+// CrossCheckEnabled reports whether the cross-check mode is enabled.
+func CrossCheckEnabled() bool {
+	return crossCheckEnabled.Load()
+}
+
+func crossCheckFail(op, format string, args ...any) {
+	panic(fmt.Sprintf("reflect: cross-check of %s failed: %s", op, fmt.Sprintf(format, args...)))
+}
+
+// catchPanic runs f and returns the recovered panic value, if any.
+func catchPanic(f func()) (r any, panicked bool) {
+	defer func() {
+		if panicked {
+			r = recover()
+		}
+	}()
+	panicked = true
+	f()
+	panicked = false
+	return nil, false
+}
+
+// panicClass normalizes a panic value so that panics raised on the two sides
+// of the bridge can be compared.
+func panicClass(r any) string {
+	switch e := r.(type) {
+	case *ValueError:
+		return "ValueError:" + e.Method + ":" + e.Kind.String()
+	case runtime.Error:
+		return "runtime.Error"
+	case string:
+		return "string:" + e
+	case error:
+		return fmt.Sprintf("%T", e)
+	default:
+		return fmt.Sprintf("%T", r)
+	}
+}
+
+func crossCheckPanics(op string, ours, std any, oursPanicked, stdPanicked bool) {
+	switch {
+	case oursPanicked && !stdPanicked:
+		crossCheckFail(op, "panicked with %v but reflect did not", ours)
+	case !oursPanicked && stdPanicked:
+		crossCheckFail(op, "did not panic but reflect panicked with %v", std)
+	case oursPanicked && stdPanicked:
+		if oc, sc := panicClass(ours), panicClass(std); oc != sc {
+			crossCheckFail(op, "panic %q does not match reflect panic %q", oc, sc)
+		}
+		panic(ours)
+	}
+}
+
+// diffValue describes how v differs from rv, or returns "" if they agree.
+// The raw kind and type words of v are compared without going through the
+// bridge so that a change of the flag layout is caught.
+func diffValue(v Value, rv reflect.Value) string {
+	if (v.flag != 0) != rv.IsValid() {
+		return fmt.Sprintf("validity %v, want %v", v.flag != 0, rv.IsValid())
+	}
+	if !rv.IsValid() {
+		return ""
+	}
+	if k := Kind(v.flag & flagKindMask); k != rv.Kind() {
+		return fmt.Sprintf("kind %s, want %s", k, rv.Kind())
+	}
+	bv := toRV(v)
+	t := toRT(v.typ)
+	if v.flag&flagMethod != 0 {
+		// The type word of a method value holds the receiver type.
+		t = bv.Type()
+	}
+	if t != rv.Type() {
+		return fmt.Sprintf("type %s, want %s", t, rv.Type())
+	}
+	if a := v.flag&flagAddr != 0; a != rv.CanAddr() {
+		return fmt.Sprintf("addressable %v, want %v", a, rv.CanAddr())
+	}
+	if ro := v.flag&flagRO != 0; ro == rv.CanInterface() {
+		return fmt.Sprintf("read-only %v, want %v", ro, !rv.CanInterface())
+	}
+	if bv.CanSet() != rv.CanSet() {
+		return fmt.Sprintf("settable %v, want %v", bv.CanSet(), rv.CanSet())
+	}
+	if !rv.CanInterface() {
+		return ""
+	}
+	if rv.Kind() == reflect.Map {
+		// fmt orders NaN keys unpredictably, so compare the maps themselves.
+		if bv.Pointer() != rv.Pointer() {
+			return fmt.Sprintf("map %#x, want %#x", bv.Pointer(), rv.Pointer())
+		}
+		return ""
+	}
+	if got, want := fmt.Sprintf("%#v", bv.Interface()), fmt.Sprintf("%#v", rv.Interface()); got != want {
+		return fmt.Sprintf("value %s, want %s", got, want)
+	}
+	return ""
+}
+
+func crossCheckValue(op string, ours func() Value, std func() reflect.Value) Value {
+	var (
+		v  Value
+		rv reflect.Value
+	)
+	or, op1 := catchPanic(func() { v = ours() })
+	sr, op2 := catchPanic(func() { rv = std() })
+	crossCheckPanics(op, or, sr, op1, op2)
+	if reason := diffValue(v, rv); reason != "" {
+		crossCheckFail(op, "%s", reason)
+	}
+	return v
+}
+
+func crossCheckTypeOf(v any, t Type) {
+	std := reflect.TypeOf(v)
+	if t == nil {
+		if std != nil {
+			crossCheckFail("TypeOf", "got nil Type, want %s", std)
+		}
+		return
+	}
+	if toRT(t) != std {
+		crossCheckFail("TypeOf", "got %s, want %s", toRT(t), std)
+	}
+}
+
+func crossCheckMethod(op string, ours func() (Method, bool), std func() (reflect.Method, bool)) (Method, bool) {
+	var (
+		mtd   Method
+		rmtd  reflect.Method
+		m, ok bool
+	)
+	or, op1 := catchPanic(func() { mtd, m = ours() })
+	sr, op2 := catchPanic(func() { rmtd, ok = std() })
+	crossCheckPanics(op, or, sr, op1, op2)
+	if m != ok {
+		crossCheckFail(op, "found %v, want %v", m, ok)
+	}
+	if mtd.Name != rmtd.Name || mtd.PkgPath != rmtd.PkgPath || mtd.Index != rmtd.Index {
+		crossCheckFail(op, "method %s.%s#%d, want %s.%s#%d",
+			mtd.PkgPath, mtd.Name, mtd.Index, rmtd.PkgPath, rmtd.Name, rmtd.Index)
+	}
+	if (mtd.Type == nil) != (rmtd.Type == nil) || mtd.Type != nil && toRT(mtd.Type) != rmtd.Type {
+		crossCheckFail(op, "method type %v, want %v", toRT(mtd.Type), rmtd.Type)
+	}
+	if reason := diffValue(mtd.Func, rmtd.Func); reason != "" {
+		crossCheckFail(op, "method func: %s", reason)
+	}
+	return mtd, m
+}
+
+// crossCheckCall verifies that the arguments and results of a call survive
+// the bridge and that the results match the function signature.
+func crossCheckCall(op string, fn Value, in, out []Value) {
+	for i, arg := range in {
+		if reason := diffValue(arg, toRV(arg)); reason != "" {
+			crossCheckFail(op, "argument %d: %s", i, reason)
+		}
+	}
+	ft := toRV(fn).Type()
+	if len(out) != ft.NumOut() {
+		crossCheckFail(op, "got %d results, want %d", len(out), ft.NumOut())
+	}
+	for i, res := range out {
+		if reason := diffValue(res, toRV(res)); reason != "" {
+			crossCheckFail(op, "result %d: %s", i, reason)
+		}
+		if toRT(res.typ) != ft.Out(i) {
+			crossCheckFail(op, "result %d has type %s, want %s", i, toRT(res.typ), ft.Out(i))
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package reflect_test
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+var crossCheck = flag.Bool("crosscheck", false, "run the test suite with cross-check mode enabled")
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if *crossCheck {
+		reflect.EnableCrossCheck(true)
+	}
+	os.Exit(m.Run())
+}
+
+type crossCheckT struct {
+	A int
+	b string
+}
+
+func (crossCheckT) M(x int) int { return x * 2 }
+
+func withCrossCheck(t *testing.T) {
+	if !reflect.CrossCheckEnabled() {
+		reflect.EnableCrossCheck(true)
+		t.Cleanup(func() { reflect.EnableCrossCheck(false) })
+	}
+}
+
+func TestCrossCheck(t *testing.T) {
+	withCrossCheck(t)
+
+	if reflect.TypeOf(crossCheckT{}).Kind() != reflect.Struct {
+		t.Fatal("failed to get type")
+	}
+	if reflect.TypeOf(nil) != nil {
+		t.Fatal("failed to get nil type")
+	}
+	v := reflect.ValueOf(&crossCheckT{A: 1, b: "b"}).Elem()
+	if v.Field(0).Int() != 1 || v.Field(1).String() != "b" {
+		t.Fatal("failed to get field")
+	}
+	if _, ok := v.Type().MethodByName("M"); !ok {
+		t.Fatal("failed to get method by Type.MethodByName")
+	}
+	out := v.MethodByName("M").Call([]reflect.Value{reflect.ValueOf(21)})
+	if len(out) != 1 || out[0].Int() != 42 {
+		t.Fatal("failed to call method")
+	}
+	if v.MethodByName("Missing").IsValid() {
+		t.Fatal("found missing method")
+	}
+	if f := reflect.ValueOf(1.5).Convert(reflect.TypeOf(int64(0))); f.Int() != 1 {
+		t.Fatal("failed to convert")
+	}
+}
+
+func TestCrossCheckPanicParity(t *testing.T) {
+	withCrossCheck(t)
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("did not panic")
+		}
+		if s, ok := r.(string); ok && strings.Contains(s, "cross-check") {
+			t.Fatalf("unexpected divergence: %s", s)
+		}
+	}()
+	reflect.ValueOf(1).Field(0)
+}
+
+func TestCrossCheckDisabledByDefault(t *testing.T) {
+	if *crossCheck {
+		t.Skip("cross-check mode requested by flag")
+	}
+	if reflect.CrossCheckEnabled() {
+		t.Fatal("cross-check mode must be disabled by default")
+	}
+}
@@ -0,0 +1,130 @@
+package reflect
+
+import "unsafe"
+
+// DeepCopy returns a deep copy of v, as made by DeepCopyValue.
+// DeepCopy(nil) returns nil.
+func DeepCopy(v any) any {
+	if v == nil {
+		return nil
+	}
+	return value_Interface(DeepCopyValue(ValueOf(v)))
+}
+
+// DeepCopyValue returns an addressable deep copy of v that shares no
+// memory with it: the pointers, slices, maps and interfaces reachable
+// from v, including through unexported struct fields, are copied
+// recursively. Pointers, slices and maps that are shared within v are
+// shared within the copy too, so cycles are preserved rather than unrolled.
+// Slices are copied up to their capacity, so the elements past the length
+// of a slice are copied as well.
+// Channels, funcs and unsafe pointers are copied by reference.
+//
+// The result has v's type and can be used to obtain unexported fields
+// freely, even if v was obtained using one. If v is the zero Value,
+// DeepCopyValue returns the zero Value.
+func DeepCopyValue(v Value) Value {
+	if v.flag == 0 {
+		return Value{}
+	}
+	c := deepCopier{copies: make(map[deepRef]Value)}
+	dst := value_New(v.typ).Elem()
+	c.copy(dst, v.readable())
+	return dst
+}
+
+// A deepRef identifies the memory a pointer, slice or map refers to.
+// Slices are told apart by their length too, as slices of different
+// lengths may share the first element.
+type deepRef struct {
+	ptr unsafe.Pointer
+	typ Type
+	len int
+}
+
+type deepCopier struct {
+	copies map[deepRef]Value
+}
+
+// readable returns v without the read-only flag, so that it can be
+// interfaced and set.
+func (v Value) readable() Value {
+	v.flag &^= flagRO
+	return v
+}
+
+// copy sets dst, a settable zero Value of src's type, to a deep copy of src.
+func (c *deepCopier) copy(dst, src Value) {
+	switch src.flag.kind() {
+	case Ptr:
+		if src.pointer() == nil {
+			return
+		}
+		key := deepRef{src.pointer(), src.typ, 0}
+		if p, ok := c.copies[key]; ok {
+			value_Set(dst, p)
+			return
+		}
+		p := value_New(src.typ.Elem())
+		c.copies[key] = p
+		value_Set(dst, p)
+		c.copy(value_Elem(p), value_Elem(src))
+	case Interface:
+		if value_IsNil(src) {
+			return
+		}
+		elem := value_Elem(src)
+		e := value_New(elem.typ).Elem()
+		c.copy(e, elem)
+		value_Set(dst, e)
+	case Slice:
+		if value_IsNil(src) {
+			return
+		}
+		n, cp := value_Len(src), value_Cap(src)
+		key := deepRef{*(*unsafe.Pointer)(src.data()), src.typ, n}
+		if s, ok := c.copies[key]; ok {
+			value_Set(dst, s)
+			return
+		}
+		s := value_MakeSlice(src.typ, n, cp)
+		c.copies[key] = s
+		value_Set(dst, s)
+		// Copy up to the capacity, so that reslicing the copy beyond its
+		// length shows what reslicing src would.
+		s, src = value_Slice(s, 0, cp), value_Slice(src, 0, cp)
+		for i := 0; i < cp; i++ {
+			c.copy(value_Index(s, i), value_Index(src, i))
+		}
+	case Map:
+		if value_IsNil(src) {
+			return
+		}
+		key := deepRef{src.pointer(), src.typ, 0}
+		if m, ok := c.copies[key]; ok {
+			value_Set(dst, m)
+			return
+		}
+		m := value_MakeMapWithSize(src.typ, value_Len(src))
+		c.copies[key] = m
+		value_Set(dst, m)
+		it := value_MapRange(src)
+		for it.Next() {
+			k := value_New(src.typ.Key()).Elem()
+			c.copy(k, toV(it.Key()))
+			e := value_New(src.typ.Elem()).Elem()
+			c.copy(e, toV(it.Value()))
+			value_SetMapIndex(m, k, e)
+		}
+	case Array:
+		for i, n := 0, value_Len(src); i < n; i++ {
+			c.copy(value_Index(dst, i), value_Index(src, i))
+		}
+	case Struct:
+		for i, n := 0, src.typ.NumField(); i < n; i++ {
+			c.copy(value_Field(dst, i).readable(), value_Field(src, i).readable())
+		}
+	default:
+		value_Set(dst, src)
+	}
+}
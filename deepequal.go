@@ -0,0 +1,378 @@
+package reflect
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"unsafe"
+)
+
+// DeepEqualOptions relax the comparison made by DeepEqualWithOptions.
+// The zero DeepEqualOptions make it behave exactly like DeepEqual.
+type DeepEqualOptions struct {
+	// TreatNaNsEqual makes a NaN equal to any other NaN.
+	TreatNaNsEqual bool
+
+	// FloatTolerance makes floats equal if their absolute difference is
+	// at most FloatTolerance. Complex numbers are compared by their real
+	// and imaginary parts.
+	FloatTolerance float64
+
+	// UseEqualMethod makes values of a type T that has a method
+	// Equal(T) bool, with a value or pointer receiver, equal if Equal
+	// reports so, instead of comparing their contents. For example,
+	// time.Time values are then equal if they represent the same instant,
+	// whatever their locations. Nil pointers and interfaces are compared
+	// as usual without calling Equal.
+	UseEqualMethod bool
+}
+
+// DeepEqualWithOptions is like DeepEqual, but compares floating-point
+// numbers and values with an Equal method, wherever they appear in x and
+// y, as set by opts. Values of different types are never equal.
+//
+// Map keys are first matched exactly, as by DeepEqual; if opts are not the
+// zero DeepEqualOptions, the remaining keys of x are then matched, in turn,
+// to a remaining key of y that is equal under opts and whose element is
+// equal too.
+func DeepEqualWithOptions(x, y any, opts DeepEqualOptions) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	v1, v2 := ValueOf(x), ValueOf(y)
+	if v1.typ != v2.typ {
+		return false
+	}
+	d := deepEqualer{opts: opts, visited: make(map[deepVisit]bool)}
+	return d.equal(v1, v2)
+}
+
+// A deepVisit records a comparison in progress, so that cyclic data
+// structures are compared only once.
+type deepVisit struct {
+	a1, a2 unsafe.Pointer
+	typ    Type
+}
+
+type deepEqualer struct {
+	opts    DeepEqualOptions
+	visited map[deepVisit]bool
+
+	// diff, if not nil, receives the first difference found. Its path is
+	// collected in reverse order while returning from the mismatch.
+	diff *Difference
+	path []string
+}
+
+func (d *deepEqualer) equal(v1, v2 Value) bool {
+	eq := d.equalValues(v1, v2)
+	if !eq && d.diff != nil && d.diff.A == "" {
+		d.diff.A, d.diff.B = diffString(v1), diffString(v2)
+	}
+	return eq
+}
+
+// at records that the difference being returned from is found at the path
+// element elem.
+func (d *deepEqualer) at(elem func() string) {
+	if d.diff != nil {
+		d.path = append(d.path, elem())
+	}
+}
+
+func (d *deepEqualer) equalValues(v1, v2 Value) bool {
+	if !v1.IsValid() || !v2.IsValid() {
+		return v1.IsValid() == v2.IsValid()
+	}
+	if v1.typ != v2.typ {
+		return false
+	}
+	if d.opts.UseEqualMethod {
+		if eq, ok := callEqualMethod(v1, v2); ok {
+			return eq
+		}
+	}
+
+	switch k := v1.Kind(); k {
+	case Map, Slice, Interface, Ptr:
+		if !value_IsNil(v1) && !value_IsNil(v2) {
+			addr1, addr2 := v1.ptr, v2.ptr
+			if k == Map || k == Ptr {
+				addr1, addr2 = v1.pointer(), v2.pointer()
+			}
+			if uintptr(addr1) > uintptr(addr2) {
+				addr1, addr2 = addr2, addr1
+			}
+			visit := deepVisit{addr1, addr2, v1.typ}
+			if d.visited[visit] {
+				return true
+			}
+			d.visited[visit] = true
+		}
+	}
+
+	switch v1.Kind() {
+	case Array:
+		return d.equalElems(v1, v2)
+	case Slice:
+		if value_IsNil(v1) != value_IsNil(v2) || value_Len(v1) != value_Len(v2) {
+			return false
+		}
+		if value_Pointer(v1) == value_Pointer(v2) {
+			return true
+		}
+		if v1.typ.Elem().Kind() == Uint8 {
+			return bytes.Equal(value_Bytes(v1), value_Bytes(v2))
+		}
+		return d.equalElems(v1, v2)
+	case Interface:
+		if value_IsNil(v1) || value_IsNil(v2) {
+			return value_IsNil(v1) == value_IsNil(v2)
+		}
+		return d.equal(value_Elem(v1), value_Elem(v2))
+	case Ptr:
+		if value_Pointer(v1) == value_Pointer(v2) {
+			return true
+		}
+		if value_IsNil(v1) || value_IsNil(v2) {
+			return false
+		}
+		return d.equal(value_Elem(v1), value_Elem(v2))
+	case Struct:
+		for i, n := 0, v1.typ.NumField(); i < n; i++ {
+			if !d.equal(value_Field(v1, i), value_Field(v2, i)) {
+				d.at(func() string { return "." + v1.typ.Field(i).Name })
+				return false
+			}
+		}
+		return true
+	case Map:
+		return d.equalMaps(v1, v2)
+	case Func:
+		return value_IsNil(v1) && value_IsNil(v2)
+	case Int, Int8, Int16, Int32, Int64:
+		return value_Int(v1) == value_Int(v2)
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		return value_Uint(v1) == value_Uint(v2)
+	case String:
+		return value_String(v1) == value_String(v2)
+	case Bool:
+		return value_Bool(v1) == value_Bool(v2)
+	case Float32, Float64:
+		return d.equalFloats(value_Float(v1), value_Float(v2))
+	case Complex64, Complex128:
+		c1, c2 := value_Complex(v1), value_Complex(v2)
+		return d.equalFloats(real(c1), real(c2)) && d.equalFloats(imag(c1), imag(c2))
+	case Chan, UnsafePointer:
+		return value_Pointer(v1) == value_Pointer(v2)
+	}
+	panic("reflect: DeepEqualWithOptions of unexpected kind " + v1.Kind().String())
+}
+
+// equalElems compares the elements of the arrays or slices v1 and v2,
+// which have the same length.
+func (d *deepEqualer) equalElems(v1, v2 Value) bool {
+	for i, n := 0, value_Len(v1); i < n; i++ {
+		if !d.equal(value_Index(v1, i), value_Index(v2, i)) {
+			d.at(func() string { return "[" + strconv.Itoa(i) + "]" })
+			return false
+		}
+	}
+	return true
+}
+
+func (d *deepEqualer) equalFloats(a, b float64) bool {
+	switch {
+	case a == b:
+		return true
+	case math.IsNaN(a) || math.IsNaN(b):
+		return d.opts.TreatNaNsEqual && math.IsNaN(a) && math.IsNaN(b)
+	}
+	return math.Abs(a-b) <= d.opts.FloatTolerance
+}
+
+func (d *deepEqualer) equalMaps(v1, v2 Value) bool {
+	if value_IsNil(v1) != value_IsNil(v2) {
+		return false
+	}
+	if value_Len(v1) != value_Len(v2) {
+		if d.diff != nil {
+			d.diffMissingKey(v1, v2)
+		}
+		return false
+	}
+	if v1.pointer() == v2.pointer() {
+		return true
+	}
+	// Entries of one map whose key is not in the other. Iterating rather
+	// than looking up the elements keeps keys containing NaNs, which
+	// cannot be looked up, paired with their elements.
+	type entry struct{ key, elem Value }
+	var rest1 []entry
+	it := value_MapRange(v1)
+	for it.Next() {
+		k, e1 := toV(it.Key()), toV(it.Value())
+		e2 := value_MapIndex(v2, k)
+		if !e2.IsValid() {
+			rest1 = append(rest1, entry{k, e1})
+			continue
+		}
+		if !d.equal(e1, e2) {
+			d.at(func() string { return "[" + diffString(k) + "]" })
+			return false
+		}
+	}
+	if len(rest1) == 0 {
+		return true
+	}
+	if d.opts == (DeepEqualOptions{}) {
+		if d.diff != nil {
+			d.diffMissingKey(v1, v2)
+		}
+		return false
+	}
+	var rest2 []entry
+	it = value_MapRange(v2)
+	for it.Next() {
+		if k := toV(it.Key()); !value_MapIndex(v1, k).IsValid() {
+			rest2 = append(rest2, entry{k, toV(it.Value())})
+		}
+	}
+	for _, e1 := range rest1 {
+		found := false
+		for j, e2 := range rest2 {
+			if d.equal(e1.key, e2.key) && d.equal(e1.elem, e2.elem) {
+				rest2 = append(rest2[:j], rest2[j+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// An equalMethod locates the Equal method of a type, if it has one.
+type equalMethod struct {
+	index int // -1 if there is no Equal method
+	ptr   bool
+}
+
+var equalMethodCache typeCache[equalMethod]
+
+func buildEqualMethod(t Type) equalMethod {
+	if t.Kind() == Interface {
+		return equalMethod{index: -1}
+	}
+	boolType := TypeFor[bool]()
+	for _, ptr := range []bool{false, true} {
+		recv := t
+		if ptr {
+			recv = PtrTo(t)
+		}
+		m, ok := recv.MethodByName("Equal")
+		if ok && m.Type.NumIn() == 2 && m.Type.In(1) == t && m.Type.NumOut() == 1 && m.Type.Out(0) == boolType {
+			return equalMethod{m.Index, ptr}
+		}
+	}
+	return equalMethod{index: -1}
+}
+
+// callEqualMethod calls v1.Equal(v2) if the type of v1 and v2 has an
+// Equal method and neither is a nil pointer, and reports whether it did.
+func callEqualMethod(v1, v2 Value) (eq, ok bool) {
+	m := equalMethodCache.get(v1.typ, buildEqualMethod)
+	if m.index < 0 || v1.Kind() == Ptr && (value_IsNil(v1) || value_IsNil(v2)) {
+		return false, false
+	}
+	recv, arg := v1.readable(), v2.readable()
+	if m.ptr {
+		if recv.flag&flagAddr != 0 {
+			recv = value_Addr(recv)
+		} else {
+			p := value_New(recv.typ)
+			value_Set(value_Elem(p), recv)
+			recv = p
+		}
+	}
+	out := value_Call(value_Method(recv, m.index), []Value{arg})
+	return value_Bool(out[0]), true
+}
+
+// diffMissingKey records a key of one of the maps v1 and v2 that is not
+// in the other one as the difference, if there is one.
+func (d *deepEqualer) diffMissingKey(v1, v2 Value) {
+	for _, m := range [2][2]Value{{v1, v2}, {v2, v1}} {
+		it := value_MapRange(m[0])
+		for it.Next() {
+			k := toV(it.Key())
+			if value_MapIndex(m[1], k).IsValid() {
+				continue
+			}
+			d.diff.A, d.diff.B = diffString(toV(it.Value())), "<missing>"
+			if m[0] != v1 {
+				d.diff.A, d.diff.B = d.diff.B, d.diff.A
+			}
+			d.at(func() string { return "[" + diffString(k) + "]" })
+			return
+		}
+	}
+}
+
+// A Difference describes where two values compared by DeepDifference
+// first differ.
+type Difference struct {
+	// Path leads from the compared values to the differing values, as
+	// in .Field[3].m["key"]. Pointers and interfaces are followed
+	// implicitly. It is empty if the compared values differ themselves,
+	// for example in type.
+	Path string
+
+	// A and B are the differing values in Go syntax. Either is <missing>
+	// if the difference is a map key only present in the other map.
+	A, B string
+}
+
+func (d Difference) String() string {
+	return d.Path + ": " + d.A + " != " + d.B
+}
+
+// DeepDifference reports where a and b first differ, walking them as
+// DeepEqual does. It returns false if a and b are deeply equal.
+func DeepDifference(a, b any) (Difference, bool) {
+	diff := new(Difference)
+	var eq bool
+	if a == nil || b == nil {
+		eq = a == b
+		if !eq {
+			diff.A, diff.B = diffString(ValueOf(a)), diffString(ValueOf(b))
+		}
+	} else {
+		d := deepEqualer{visited: make(map[deepVisit]bool), diff: diff}
+		eq = d.equal(ValueOf(a), ValueOf(b))
+		for i := len(d.path) - 1; i >= 0; i-- {
+			diff.Path += d.path[i]
+		}
+	}
+	if eq {
+		return Difference{}, false
+	}
+	return *diff, true
+}
+
+// DeepDiff returns the path to the first difference between a and b, as
+// reported by DeepDifference, and false if a and b are deeply equal.
+func DeepDiff(a, b any) (path string, ok bool) {
+	diff, ok := DeepDifference(a, b)
+	return diff.Path, ok
+}
+
+func diffString(v Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return fmt.Sprintf("%#v", toRV(v))
+}
@@ -0,0 +1,195 @@
+package reflect
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"unsafe"
+)
+
+// deepHashCycleDepth is the number of pointers, maps and slices DeepHash
+// follows from the root of a cyclic value.
+const deepHashCycleDepth = 8
+
+// DeepHash returns a hash of x that is consistent with DeepEqual: if
+// DeepEqual(x, y), then DeepHash(x, seed) == DeepHash(y, seed). Like
+// maphash, it is only stable within a process and for the same seed.
+//
+// DeepHash walks x the way DeepEqual does. The entries of maps are hashed
+// independently of their order, and values that are shared or appear more
+// than once in x are hashed only once. If x contains a cycle, which
+// DeepEqual considers equal to any other cycle with the same unrolling,
+// only the parts of x up to a fixed depth are hashed.
+//
+// Values containing NaNs hash arbitrarily, since they are never DeepEqual
+// to anything, not even themselves.
+func DeepHash(x any, seed maphash.Seed) uint64 {
+	d := deepHasher{seed: seed, memo: make(map[deepRef]uint64), walking: make(map[deepRef]bool)}
+	v := ValueOf(x)
+	h := d.hash(v)
+	if d.cyclic {
+		d = deepHasher{seed: seed, limit: deepHashCycleDepth}
+		h = d.hash(v)
+	}
+	return h
+}
+
+type deepHasher struct {
+	seed maphash.Seed
+
+	// memo holds the hashes of the values pointers, maps and slices refer
+	// to, and walking those whose hash is being computed. They are not
+	// used once a cycle has been found and limit is set.
+	memo    map[deepRef]uint64
+	walking map[deepRef]bool
+	cyclic  bool
+
+	// limit is the number of references left to follow if it is positive.
+	limit int
+}
+
+func (d *deepHasher) hash(v Value) uint64 {
+	var h maphash.Hash
+	h.SetSeed(d.seed)
+	d.write(&h, v)
+	return h.Sum64()
+}
+
+// follow returns the hash of the value ref refers to, as computed by
+// hashTarget, using the memo if possible.
+func (d *deepHasher) follow(ref deepRef, hashTarget func() uint64) uint64 {
+	if d.limit > 0 {
+		if d.limit == 1 {
+			return 0
+		}
+		d.limit--
+		defer func() { d.limit++ }()
+		return hashTarget()
+	}
+	if h, ok := d.memo[ref]; ok {
+		return h
+	}
+	if d.walking[ref] || d.cyclic {
+		d.cyclic = true
+		return 0
+	}
+	d.walking[ref] = true
+	h := hashTarget()
+	delete(d.walking, ref)
+	d.memo[ref] = h
+	return h
+}
+
+func (d *deepHasher) write(h *maphash.Hash, v Value) {
+	if v.flag == 0 {
+		h.WriteByte(byte(Invalid))
+		return
+	}
+	k := v.flag.kind()
+	h.WriteByte(byte(k))
+	switch k {
+	case Array:
+		for i, n := 0, value_Len(v); i < n; i++ {
+			d.write(h, value_Index(v, i))
+		}
+	case Slice:
+		if value_IsNil(v) {
+			h.WriteByte(0)
+			return
+		}
+		n := value_Len(v)
+		writeUint64(h, uint64(n))
+		if v.typ.Elem().Kind() == Uint8 {
+			h.Write(value_Bytes(v))
+			return
+		}
+		writeUint64(h, d.follow(deepRef{*(*unsafe.Pointer)(v.data()), v.typ, n}, func() uint64 {
+			var eh maphash.Hash
+			eh.SetSeed(d.seed)
+			for i := 0; i < n; i++ {
+				d.write(&eh, value_Index(v, i))
+			}
+			return eh.Sum64()
+		}))
+	case Interface:
+		if value_IsNil(v) {
+			h.WriteByte(0)
+			return
+		}
+		e := value_Elem(v)
+		writeUint64(h, uint64(uintptr(unsafe.Pointer(e.typ))))
+		d.write(h, e)
+	case Ptr:
+		if v.pointer() == nil {
+			h.WriteByte(0)
+			return
+		}
+		writeUint64(h, d.follow(deepRef{v.pointer(), v.typ, 0}, func() uint64 {
+			return d.hash(value_Elem(v))
+		}))
+	case Struct:
+		for i, n := 0, v.typ.NumField(); i < n; i++ {
+			d.write(h, value_Field(v, i))
+		}
+	case Map:
+		if value_IsNil(v) {
+			h.WriteByte(0)
+			return
+		}
+		writeUint64(h, uint64(value_Len(v)))
+		writeUint64(h, d.follow(deepRef{v.pointer(), v.typ, 0}, func() uint64 {
+			var sum uint64
+			it := value_MapRange(v)
+			for it.Next() {
+				var eh maphash.Hash
+				eh.SetSeed(d.seed)
+				d.write(&eh, toV(it.Key()))
+				d.write(&eh, toV(it.Value()))
+				sum ^= eh.Sum64()
+			}
+			return sum
+		}))
+	case Func:
+		// Non-nil funcs are never DeepEqual.
+		h.WriteByte(boolByte(value_IsNil(v)))
+	case Int, Int8, Int16, Int32, Int64:
+		writeUint64(h, uint64(value_Int(v)))
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		writeUint64(h, value_Uint(v))
+	case String:
+		s := value_String(v)
+		writeUint64(h, uint64(len(s)))
+		h.WriteString(s)
+	case Bool:
+		h.WriteByte(boolByte(value_Bool(v)))
+	case Float32, Float64:
+		writeFloat(h, value_Float(v))
+	case Complex64, Complex128:
+		c := value_Complex(v)
+		writeFloat(h, real(c))
+		writeFloat(h, imag(c))
+	case Chan, UnsafePointer:
+		writeUint64(h, uint64(value_Pointer(v)))
+	}
+}
+
+func writeUint64(h *maphash.Hash, x uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	h.Write(b[:])
+}
+
+func writeFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		// -0 == +0.
+		f = 0
+	}
+	writeUint64(h, math.Float64bits(f))
+}
+
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
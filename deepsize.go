@@ -0,0 +1,210 @@
+package reflect
+
+import "unsafe"
+
+// A SizeOption configures DeepSize.
+type SizeOption func(*sizeConfig)
+
+type sizeConfig struct {
+	mapOverhead bool
+	boundaries  map[Type]bool
+}
+
+// WithMapOverhead sets whether DeepSize includes an estimate of the
+// internal overhead of maps, such as their header, the unused slots of
+// their buckets and per-slot metadata, in addition to the size of their
+// keys and elements. It does by default.
+func WithMapOverhead(include bool) SizeOption {
+	return func(c *sizeConfig) { c.mapOverhead = include }
+}
+
+// WithSizeBoundary makes DeepSize stop at values of the given types, for
+// example handles of shared resources whose memory is not owned by the
+// measured value. Such a value stored within a counted value counts with
+// its Type.Size, but memory it refers to does not count, and neither do
+// values of these types only referred to by pointers or interfaces.
+func WithSizeBoundary(types ...Type) SizeOption {
+	return func(c *sizeConfig) {
+		if c.boundaries == nil {
+			c.boundaries = make(map[Type]bool)
+		}
+		for _, t := range types {
+			c.boundaries[t] = true
+		}
+	}
+}
+
+// DeepSize returns an estimate of the number of bytes of memory v retains:
+// the Type.Size of v plus the memory reachable from it through pointers,
+// slices, strings, maps, channel buffers and interfaces. Memory is counted
+// once, so values shared within v and cycles are counted once; this holds
+// for overlapping references that start at the same address, such as a
+// pointer to the first element of a slice and the slice itself, while
+// references into the middle of counted memory may count it again. The
+// memory referred to by funcs and unsafe pointers is not counted.
+// DeepSize(nil) returns 0.
+//
+// The size of maps is an approximation: their layout is internal to the
+// runtime and differs between Go versions. The keys and elements count
+// with their Type.Size each, plus the estimate described at
+// WithMapOverhead. Allocation size classes are not accounted for either.
+func DeepSize(v any, opts ...SizeOption) uint64 {
+	if v == nil {
+		return 0
+	}
+	s := sizer{config: sizeConfig{mapOverhead: true}, seen: make(map[unsafe.Pointer]uintptr)}
+	for _, opt := range opts {
+		opt(&s.config)
+	}
+	root := ValueOf(v)
+	s.size = uint64(root.typ.Size())
+	s.walk(root)
+	return s.size
+}
+
+type sizer struct {
+	config sizeConfig
+	seen   map[unsafe.Pointer]uintptr // bytes counted from each address
+	size   uint64
+}
+
+// claim counts the n bytes of memory at p, less those already counted from
+// the same address, and reports whether any of them are new, in which case
+// the memory needs to be walked.
+func (s *sizer) claim(p unsafe.Pointer, n uintptr) bool {
+	if p == nil {
+		return false
+	}
+	counted, ok := s.seen[p]
+	if ok && counted >= n {
+		return false
+	}
+	s.seen[p] = n
+	s.size += uint64(n - counted)
+	return true
+}
+
+// follow counts the memory of the value of type t at p and walks it.
+func (s *sizer) follow(t Type, p unsafe.Pointer) {
+	if s.config.boundaries[t] || !s.claim(p, t.Size()) {
+		return
+	}
+	s.walk(Value{t, p, flag(t.Kind()) | flagIndir}.readable())
+}
+
+// walk counts the memory v refers to, but not the memory of v itself.
+func (s *sizer) walk(v Value) {
+	if s.config.boundaries[v.typ] || !refersCache.get(v.typ, buildRefers) {
+		return
+	}
+	switch v.flag.kind() {
+	case Ptr:
+		s.follow(v.typ.Elem(), v.pointer())
+	case Interface:
+		if value_IsNil(v) {
+			return
+		}
+		elem := value_Elem(v)
+		if !ifaceIndir(elem.typ) {
+			// The interface holds the pointer itself.
+			s.walk(elem)
+			return
+		}
+		s.follow(elem.typ, elem.ptr)
+	case String:
+		str := value_String(v)
+		if len(str) > 0 {
+			s.claim(unsafe.Pointer(unsafe.StringData(str)), uintptr(len(str)))
+		}
+	case Slice:
+		data := *(*unsafe.Pointer)(v.data())
+		if value_Cap(v) == 0 || !s.claim(data, uintptr(value_Cap(v))*v.typ.Elem().Size()) {
+			return
+		}
+		s.walkElems(v)
+	case Array:
+		s.walkElems(v)
+	case Struct:
+		for i, n := 0, v.typ.NumField(); i < n; i++ {
+			s.walk(value_Field(v, i).readable())
+		}
+	case Chan:
+		s.claim(v.pointer(), hchanSize+uintptr(value_Cap(v))*v.typ.Elem().Size())
+	case Map:
+		n := value_Len(v)
+		if !s.claim(v.pointer(), uintptr(s.mapSize(v.typ, n))) {
+			return
+		}
+		if n == 0 || !refersCache.get(v.typ.Key(), buildRefers) && !refersCache.get(v.typ.Elem(), buildRefers) {
+			return
+		}
+		value_RangeMap(v, func(key, val Value) bool {
+			s.walk(key)
+			s.walk(val)
+			return true
+		})
+	}
+}
+
+func (s *sizer) walkElems(v Value) {
+	if !refersCache.get(v.typ.Elem(), buildRefers) {
+		return
+	}
+	for i, n := 0, value_Len(v); i < n; i++ {
+		s.walk(value_Index(v, i))
+	}
+}
+
+// Estimates of runtime internals.
+const (
+	hchanSize       = 12 * unsafe.Sizeof(uintptr(0))
+	hmapSize        = 6 * unsafe.Sizeof(uintptr(0))
+	mapSlotsPerSet  = 8
+	mapLoadFactor   = 6.5
+	mapSlotMetadata = 1
+)
+
+// mapSize estimates the memory of a map of type t with n entries, not
+// counting what its keys and elements refer to.
+func (s *sizer) mapSize(t Type, n int) uint64 {
+	slot := uint64(t.Key().Size() + t.Elem().Size())
+	if !s.config.mapOverhead {
+		return uint64(n) * slot
+	}
+	sets := uint64(1)
+	for float64(sets)*mapLoadFactor < float64(n) {
+		sets *= 2
+	}
+	// Each set of slots carries one metadata byte per slot and an overflow
+	// pointer.
+	set := mapSlotsPerSet*(slot+mapSlotMetadata) + uint64(unsafe.Sizeof(uintptr(0)))
+	return uint64(hmapSize) + sets*set
+}
+
+// refersCache records whether values of a type may refer to memory that
+// DeepSize counts.
+var refersCache typeCache[bool]
+
+func buildRefers(t Type) bool {
+	return refers(t, map[Type]bool{})
+}
+
+func refers(t Type, seen map[Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case Ptr, Interface, String, Slice, Chan, Map:
+		return true
+	case Array:
+		return t.Len() > 0 && refers(t.Elem(), seen)
+	case Struct:
+		for i, n := 0, t.NumField(); i < n; i++ {
+			if refers(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package reflect_test
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/3JoB/go-reflect"
+)
+
+func TestDeepSize(t *testing.T) {
+	const mb = 1 << 20
+	ptr := uint64(unsafe.Sizeof(uintptr(0)))
+	type blob struct {
+		Name string
+		Data string
+	}
+	b := blob{Name: "x", Data: strings.Repeat("a", mb)}
+	if got, want := reflect.DeepSize(b), uint64(unsafe.Sizeof(b))+1+mb; got != want {
+		t.Errorf("DeepSize(blob) = %d, want %d", got, want)
+	}
+
+	type payload struct {
+		ID   int
+		Body []byte
+	}
+	p := payload{ID: 1, Body: make([]byte, mb)}
+	if got, want := reflect.DeepSize(&p), ptr+uint64(unsafe.Sizeof(p))+mb; got != want {
+		t.Errorf("DeepSize(payload) = %d, want %d", got, want)
+	}
+
+	type leaf struct{ A, B int64 }
+	type pair struct{ L, R *leaf }
+	shared := &leaf{1, 2}
+	if got, want := reflect.DeepSize(pair{shared, shared}), 2*ptr+16; got != want {
+		t.Errorf("DeepSize(shared) = %d, want %d", got, want)
+	}
+	if got, want := reflect.DeepSize(pair{shared, &leaf{}}), 2*ptr+32; got != want {
+		t.Errorf("DeepSize(distinct) = %d, want %d", got, want)
+	}
+
+	r := &Recursive{x: 1, r: &Recursive{x: 2}}
+	r.r.r = r
+	node := uint64(unsafe.Sizeof(Recursive{}))
+	if got, want := reflect.DeepSize(r), ptr+2*node; got != want {
+		t.Errorf("DeepSize(recursive) = %d, want %d", got, want)
+	}
+
+	s := make([]int32, 2, 10)
+	if got, want := reflect.DeepSize(s), 3*ptr+40; got != want {
+		t.Errorf("DeepSize(slice) = %d, want %d", got, want)
+	}
+	var iface any = leaf{1, 2}
+	if got, want := reflect.DeepSize(&iface), ptr+2*ptr+16; got != want {
+		t.Errorf("DeepSize(interface) = %d, want %d", got, want)
+	}
+
+	m := map[int64]int64{1: 2, 3: 4}
+	if got, want := reflect.DeepSize(m, reflect.WithMapOverhead(false)), ptr+2*16; got != want {
+		t.Errorf("DeepSize(map without overhead) = %d, want %d", got, want)
+	}
+	if got, exact := reflect.DeepSize(m), reflect.DeepSize(m, reflect.WithMapOverhead(false)); got <= exact {
+		t.Errorf("DeepSize(map) = %d, want more than %d", got, exact)
+	}
+
+	type handle struct{ buf []byte }
+	type owner struct {
+		H   handle
+		Ref *handle
+	}
+	o := owner{H: handle{make([]byte, mb)}, Ref: &handle{make([]byte, mb)}}
+	if got, want := reflect.DeepSize(o, reflect.WithSizeBoundary(reflect.TypeOf(handle{}))), uint64(unsafe.Sizeof(o)); got != want {
+		t.Errorf("DeepSize(owner) with boundary = %d, want %d", got, want)
+	}
+	if got, want := reflect.DeepSize(o), uint64(unsafe.Sizeof(o))+3*ptr+2*mb; got != want {
+		t.Errorf("DeepSize(owner) = %d, want %d", got, want)
+	}
+
+	// A pointer to the first element of a slice does not hide the rest of
+	// the backing array, whichever is reached first.
+	type elem struct{ B []byte }
+	type aliased struct {
+		P *elem
+		S []elem
+	}
+	elems := []elem{{}, {make([]byte, mb)}}
+	want := uint64(unsafe.Sizeof(aliased{})) + 2*uint64(unsafe.Sizeof(elem{})) + mb
+	if got := reflect.DeepSize(aliased{P: &elems[0], S: elems}); got != want {
+		t.Errorf("DeepSize(pointer to first element, slice) = %d, want %d", got, want)
+	}
+	if got := reflect.DeepSize(aliased{S: elems}); got != want {
+		t.Errorf("DeepSize(slice) = %d, want %d", got, want)
+	}
+	// Elements past the length are counted, but not walked.
+	if got, want := reflect.DeepSize(aliased{P: &elems[0], S: elems[:1]}), want-mb; got != want {
+		t.Errorf("DeepSize(pointer to first element, short slice) = %d, want %d", got, want)
+	}
+	str := strings.Repeat("b", 100)
+	if got, want := reflect.DeepSize([2]string{str[:10], str}), 2*2*ptr+100; got != want {
+		t.Errorf("DeepSize(string and its prefix) = %d, want %d", got, want)
+	}
+
+	if got := reflect.DeepSize(nil); got != 0 {
+		t.Errorf("DeepSize(nil) = %d, want 0", got)
+	}
+}
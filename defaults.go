@@ -0,0 +1,106 @@
+package reflect
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SetDefaults sets each exported field of the struct v that holds its zero
+// value and has a `default` tag to the value the tag gives, and does the
+// same in the fields of nested structs, embedded or not, that have no
+// default tag themselves. Fields that are not zero are left alone, so
+// SetDefaults can be applied after decoding.
+//
+// The tag value is parsed according to the kind of the field: for integers,
+// as the name of a value of an enum type registered with RegisterEnum, or
+// else as a Go integer literal; with strconv for bools, floats and complex
+// numbers; and as is for strings.
+//
+// It returns an error naming the first field whose tag value cannot be
+// parsed or whose kind has no defaults, leaving the fields after it unset.
+// It panics if v's Kind is not Struct, or if v is not settable.
+func SetDefaults(v Value) error {
+	v.mustBeValid("reflect.SetDefaults")
+	if k := v.flag.kind(); k != Struct {
+		panic(&ValueError{Method: "reflect.SetDefaults", Kind: k})
+	}
+	v.mustBeExported("reflect.SetDefaults")
+	if v.flag&flagAddr == 0 {
+		panic("reflect: reflect.SetDefaults using unaddressable value")
+	}
+	return setDefaults(v)
+}
+
+func setDefaults(v Value) error {
+	t := v.typ
+	for i, n := 0, t.NumField(); i < n; i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		f := value_Field(v, i)
+		tag, ok := sf.Tag.Lookup("default")
+		if !ok {
+			if f.flag.kind() == Struct {
+				if err := setDefaults(f); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !value_IsZero(f) {
+			continue
+		}
+		if err := setDefault(f, tag); err != nil {
+			return fmt.Errorf("reflect: SetDefaults: field %s of %s: %w", sf.Name, t, err)
+		}
+	}
+	return nil
+}
+
+// setDefault sets f to the value s gives as a default tag value.
+func setDefault(f Value, s string) error {
+	switch k := f.flag.kind(); k {
+	case Int, Int8, Int16, Int32, Int64, Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		if x, ok := EnumFromString(f.typ, s); ok {
+			value_Set(f, x)
+			return nil
+		}
+		if k <= Int64 {
+			x, err := strconv.ParseInt(s, 0, f.typ.Bits())
+			if err != nil {
+				return err
+			}
+			value_SetInt(f, x)
+			return nil
+		}
+		x, err := strconv.ParseUint(s, 0, f.typ.Bits())
+		if err != nil {
+			return err
+		}
+		value_SetUint(f, x)
+	case Bool:
+		x, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		value_SetBool(f, x)
+	case Float32, Float64:
+		x, err := strconv.ParseFloat(s, f.typ.Bits())
+		if err != nil {
+			return err
+		}
+		value_SetFloat(f, x)
+	case Complex64, Complex128:
+		x, err := strconv.ParseComplex(s, f.typ.Bits())
+		if err != nil {
+			return err
+		}
+		value_SetComplex(f, x)
+	case String:
+		value_SetString(f, s)
+	default:
+		return fmt.Errorf("no default for kind %s", k)
+	}
+	return nil
+}
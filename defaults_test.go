@@ -0,0 +1,79 @@
+package reflect_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type defaultsMode uint8
+
+type DefaultsLimits struct {
+	Max  int     `default:"0x10"`
+	Rate float64 `default:"0.5"`
+}
+
+type defaultsConfig struct {
+	DefaultsLimits
+	Name   string       `default:"svc"`
+	Mode   defaultsMode `default:"fast"`
+	Level  defaultsMode `default:"2"`
+	Debug  bool         `default:"true"`
+	Port   int          `default:"8080"`
+	Nested struct {
+		Z complex64 `default:"1+2i"`
+	}
+	Kept    string `default:"unused"`
+	private int    `default:"x"`
+}
+
+func TestSetDefaults(t *testing.T) {
+	reflect.RegisterEnum(map[defaultsMode]string{0: "slow", 1: "fast"})
+
+	c := defaultsConfig{Kept: "set"}
+	if err := reflect.SetDefaults(reflect.ValueOf(&c).Elem()); err != nil {
+		t.Fatal(err)
+	}
+	want := defaultsConfig{
+		DefaultsLimits: DefaultsLimits{Max: 16, Rate: 0.5},
+		Name:           "svc",
+		Mode:           1,
+		Level:          2,
+		Debug:          true,
+		Port:           8080,
+		Kept:           "set",
+	}
+	want.Nested.Z = 1 + 2i
+	if c != want {
+		t.Errorf("SetDefaults gave %+v, want %+v", c, want)
+	}
+
+	for _, tc := range []struct {
+		v    any
+		want string
+	}{
+		{&struct {
+			M defaultsMode `default:"medium"`
+		}{}, "field M of struct { M reflect_test.defaultsMode \"default:\\\"medium\\\"\" }: strconv.ParseUint"},
+		{&struct {
+			B int8 `default:"300"`
+		}{}, "value out of range"},
+		{&struct {
+			P *int `default:"1"`
+		}{}, "no default for kind ptr"},
+		{&struct {
+			Inner struct {
+				F bool `default:"yes"`
+			}
+		}{}, "field F of"},
+	} {
+		err := reflect.SetDefaults(reflect.ValueOf(tc.v).Elem())
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("SetDefaults(%T) error = %v, want %q", tc.v, err, tc.want)
+		}
+	}
+
+	shouldPanic(func() { reflect.SetDefaults(reflect.ValueOf(c)) })
+	shouldPanic(func() { reflect.SetDefaults(reflect.ValueOf(new(int)).Elem()) })
+}
@@ -0,0 +1,116 @@
+package reflect
+
+import (
+	"fmt"
+	"sync"
+)
+
+type enumInfo struct {
+	names  map[int64]string
+	values map[string]int64
+}
+
+var enums sync.Map // map[Type]*enumInfo
+
+// EnumInteger is the constraint of the types accepted by RegisterEnum.
+type EnumInteger interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// RegisterEnum registers the names of the values of the named integer type T,
+// so that EnumString and EnumFromString can translate between them.
+// Stringify, AppendValue, Value.Format and SetDefaults use the names too.
+// It panics if T is not a type defined in a package.
+func RegisterEnum[T EnumInteger](values map[T]string) {
+	names := make(map[int64]string, len(values))
+	for v, name := range values {
+		names[int64(v)] = name
+	}
+	var zero T
+	if err := RegisterEnumType(TypeOf(zero), names); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterEnumType registers the names of the values of the named integer type t.
+// Values of unsigned types are given as their two's complement int64 bit pattern.
+// Registering a type again replaces its names.
+// It returns an error if t is not an integer type defined in a package,
+// or if two values share a name.
+func RegisterEnumType(t Type, names map[int64]string) error {
+	mustBeNonNilType(t, "RegisterEnumType")
+	if !isIntegerKind(t.Kind()) {
+		return fmt.Errorf("reflect: RegisterEnumType of non-integer type %s", t)
+	}
+	if t.Name() == "" || t.PkgPath() == "" {
+		return fmt.Errorf("reflect: RegisterEnumType of predeclared or unnamed type %s", t)
+	}
+	info := &enumInfo{
+		names:  make(map[int64]string, len(names)),
+		values: make(map[string]int64, len(names)),
+	}
+	for v, name := range names {
+		if other, dup := info.values[name]; dup {
+			return fmt.Errorf("reflect: RegisterEnumType: %s values %d and %d share the name %q", t, other, v, name)
+		}
+		info.names[v] = name
+		info.values[name] = v
+	}
+	enums.Store(t, info)
+	return nil
+}
+
+func isIntegerKind(k Kind) bool {
+	return Int <= k && k <= Uintptr
+}
+
+func enumOf(t Type) *enumInfo {
+	if info, ok := enums.Load(t); ok {
+		return info.(*enumInfo)
+	}
+	return nil
+}
+
+// EnumString returns the registered name of v's value and true,
+// or "" and false if v's type is not a registered enum type or
+// the value has no name.
+func EnumString(v Value) (string, bool) {
+	if !v.IsValid() {
+		return "", false
+	}
+	info := enumOf(v.Type())
+	if info == nil {
+		return "", false
+	}
+	var x int64
+	switch k := v.Kind(); {
+	case k >= Int && k <= Int64:
+		x = v.Int()
+	default:
+		x = int64(v.Uint())
+	}
+	name, ok := info.names[x]
+	return name, ok
+}
+
+// EnumFromString returns a Value of the registered enum type t holding the
+// value named s, and true. It returns the zero Value and false if t is not
+// a registered enum type or s is not one of its names.
+func EnumFromString(t Type, s string) (Value, bool) {
+	info := enumOf(t)
+	if info == nil {
+		return Value{}, false
+	}
+	x, ok := info.values[s]
+	if !ok {
+		return Value{}, false
+	}
+	v := value_New(t).Elem()
+	if k := t.Kind(); k >= Int && k <= Int64 {
+		v.SetInt(x)
+	} else {
+		v.SetUint(uint64(x))
+	}
+	return v, true
+}
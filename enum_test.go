@@ -0,0 +1,76 @@
+package reflect_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type color int
+
+const (
+	red color = iota
+	green
+	blue
+)
+
+type level uint8
+
+func TestEnum(t *testing.T) {
+	reflect.RegisterEnum(map[color]string{red: "red", green: "green", blue: "blue"})
+	reflect.RegisterEnum(map[level]string{200: "high"})
+
+	s := struct {
+		C color
+		L level
+	}{C: green, L: 200}
+	v := reflect.ValueOf(s)
+	if name, ok := reflect.EnumString(v.Field(0)); !ok || name != "green" {
+		t.Fatalf("got %q, %v", name, ok)
+	}
+	if name, ok := reflect.EnumString(v.Field(1)); !ok || name != "high" {
+		t.Fatalf("got %q, %v", name, ok)
+	}
+	// Formatters print registered names.
+	if got := fmt.Sprint(v.Field(0)); got != "green" {
+		t.Errorf("Sprint of field C = %q, want green", got)
+	}
+	if got := fmt.Sprintf("%#v", v); got != "struct { C reflect_test.color; L reflect_test.level }{green, high}" {
+		t.Errorf("%%#v of struct = %q", got)
+	}
+	if got := string(reflect.AppendValue(nil, v, 0)); got != "{C:green L:high}" {
+		t.Errorf("AppendValue of struct = %q", got)
+	}
+	if got := fmt.Sprint(reflect.ValueOf(color(7))); got != "7" {
+		t.Errorf("Sprint of unnamed value = %q, want 7", got)
+	}
+	if _, ok := reflect.EnumString(reflect.ValueOf(color(7))); ok {
+		t.Fatal("unnamed value must not have a name")
+	}
+	if _, ok := reflect.EnumString(reflect.ValueOf(1)); ok {
+		t.Fatal("int is not a registered enum")
+	}
+
+	c, ok := reflect.EnumFromString(reflect.TypeOf(red), "blue")
+	if !ok || c.Interface().(color) != blue {
+		t.Fatalf("got %v, %v", c, ok)
+	}
+	l, ok := reflect.EnumFromString(reflect.TypeOf(level(0)), "high")
+	if !ok || l.Interface().(level) != 200 {
+		t.Fatalf("got %v, %v", l, ok)
+	}
+	if _, ok := reflect.EnumFromString(reflect.TypeOf(red), "purple"); ok {
+		t.Fatal("unknown name must not parse")
+	}
+
+	if err := reflect.RegisterEnumType(reflect.TypeOf(""), nil); err == nil {
+		t.Fatal("expected error for non-integer type")
+	}
+	if err := reflect.RegisterEnumType(reflect.TypeOf(0), nil); err == nil {
+		t.Fatal("expected error for unnamed type")
+	}
+	if err := reflect.RegisterEnumType(reflect.TypeOf(red), map[int64]string{0: "x", 1: "x"}); err == nil {
+		t.Fatal("expected error for duplicate names")
+	}
+}
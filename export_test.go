@@ -3,3 +3,24 @@ package reflect
 type Buffer struct {
 	buf []byte
 }
+
+// RunCompatProbes runs the compatibility checker with probes that report
+// the given results, so that failures can be simulated.
+func RunCompatProbes(results map[string]bool) error {
+	probes := make([]compatProbe, 0, len(results))
+	for _, p := range compatProbes {
+		if ok, found := results[p.name]; found {
+			probes = append(probes, compatProbe{p.name, func() bool { return ok }})
+		}
+	}
+	return runCompatProbes(probes)
+}
+
+// CompatProbeNames returns the names of the compatibility probes.
+func CompatProbeNames() []string {
+	names := make([]string, len(compatProbes))
+	for i, p := range compatProbes {
+		names[i] = p.name
+	}
+	return names
+}
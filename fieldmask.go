@@ -0,0 +1,164 @@
+package reflect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A FieldMask selects a subset of the fields of a struct type, so that
+// values of that type can be compared or copied field by field.
+// It is resolved once by NewFieldMask and may be used concurrently.
+type FieldMask struct {
+	typ   Type
+	paths []string
+	index [][]int
+}
+
+// NewFieldMask returns a FieldMask selecting the given fields of the struct type t.
+//
+// Each path is a dot-separated list of segments, where every segment is
+// either a field name or a decimal field index of the struct reached so far,
+// for example "Spec", "Spec.Replicas" or "1.0". Names resolve promoted fields
+// of embedded structs the same way Type.FieldByName does, and pointers to
+// structs are followed implicitly.
+//
+// It returns an error if t is not a struct type or if a path does not name
+// an exported field.
+func NewFieldMask(t Type, paths ...string) (*FieldMask, error) {
+	if t == nil {
+		return nil, errNilType("NewFieldMask")
+	}
+	if t.Kind() != Struct {
+		return nil, fmt.Errorf("reflect: NewFieldMask of non-struct type %s", t)
+	}
+	m := &FieldMask{
+		typ:   t,
+		paths: paths,
+		index: make([][]int, 0, len(paths)),
+	}
+	for _, path := range paths {
+		index, err := resolveFieldPath(t, path)
+		if err != nil {
+			return nil, err
+		}
+		m.index = append(m.index, index)
+	}
+	return m, nil
+}
+
+func resolveFieldPath(t Type, path string) ([]int, error) {
+	var index []int
+	cur := t
+	for _, seg := range strings.Split(path, ".") {
+		if cur.Kind() == Ptr && cur.Elem().Kind() == Struct {
+			cur = cur.Elem()
+		}
+		if cur.Kind() != Struct {
+			return nil, fmt.Errorf("reflect: field path %q of %s: %s is not a struct", path, t, cur)
+		}
+		var f StructField
+		if i, err := strconv.Atoi(seg); err == nil {
+			if i < 0 || i >= cur.NumField() {
+				return nil, fmt.Errorf("reflect: field path %q of %s: field index %d out of range", path, t, i)
+			}
+			f = cur.Field(i)
+		} else {
+			var ok bool
+			if f, ok = cur.FieldByName(seg); !ok {
+				return nil, fmt.Errorf("reflect: field path %q of %s: no field %q in %s", path, t, seg, cur)
+			}
+		}
+		if !f.IsExported() {
+			return nil, fmt.Errorf("reflect: field path %q of %s: field %s is unexported", path, t, f.Name)
+		}
+		index = append(index, f.Index...)
+		cur = f.Type
+	}
+	return index, nil
+}
+
+// Type returns the struct type the mask was built for.
+func (m *FieldMask) Type() Type {
+	return m.typ
+}
+
+// Paths returns the paths the mask was built from.
+func (m *FieldMask) Paths() []string {
+	return m.paths
+}
+
+func (m *FieldMask) mustBeMaskType(v Value, fn string) {
+	if !v.IsValid() {
+		panic(&ValueError{Method: "reflect." + fn, Kind: Invalid})
+	}
+	if t := v.Type(); t != m.typ {
+		panic("reflect: " + fn + " of " + t.String() + " with FieldMask of " + m.typ.String())
+	}
+}
+
+// fieldByIndexPath returns the field of v at index.
+// It reports false if the path runs through a nil embedded pointer,
+// unless alloc is set, in which case such pointers are allocated.
+func fieldByIndexPath(v Value, index []int, alloc bool) (Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return Value{}, false
+				}
+				v.Set(New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// EqualMasked reports whether the fields of a and b selected by m are deeply
+// equal, as defined by DeepEqual. Fields outside the mask are ignored.
+// A field promoted through a nil embedded pointer only equals another such field.
+// It panics if a or b is not a value of m's struct type.
+func EqualMasked(a, b Value, m *FieldMask) bool {
+	m.mustBeMaskType(a, "EqualMasked")
+	m.mustBeMaskType(b, "EqualMasked")
+	for _, index := range m.index {
+		fa, oka := fieldByIndexPath(a, index, false)
+		fb, okb := fieldByIndexPath(b, index, false)
+		if oka != okb {
+			return false
+		}
+		if !oka {
+			continue
+		}
+		// The fields are exported, but a or b may itself have been obtained
+		// through an unexported field; comparing them exposes nothing.
+		fa.flag &^= flagRO
+		fb.flag &^= flagRO
+		if !DeepEqual(fa.Interface(), fb.Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+// CopyMasked copies the fields of src selected by m to dst, leaving the
+// other fields of dst untouched. A field promoted through a nil embedded
+// pointer of src is copied as its zero value; a nil embedded pointer of dst
+// on the way to a field is allocated when there is a value to copy.
+// It panics if dst or src is not a value of m's struct type, if dst is not
+// settable, or if an embedded pointer to an unexported struct type of dst
+// would have to be allocated.
+func CopyMasked(dst, src Value, m *FieldMask) {
+	m.mustBeMaskType(dst, "CopyMasked")
+	m.mustBeMaskType(src, "CopyMasked")
+	for _, index := range m.index {
+		if s, ok := fieldByIndexPath(src, index, false); ok {
+			d, _ := fieldByIndexPath(dst, index, true)
+			d.Set(s)
+		} else if d, ok := fieldByIndexPath(dst, index, false); ok {
+			d.SetZero()
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package reflect_test
+
+import (
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type MaskMeta struct {
+	Name   string
+	Labels map[string]string
+}
+
+type maskSpec struct {
+	Replicas int
+	Image    string
+}
+
+type maskObject struct {
+	*MaskMeta
+	Spec   maskSpec
+	Status string
+	hidden int
+}
+
+func TestFieldMask(t *testing.T) {
+	typ := reflect.TypeOf(maskObject{})
+	m, err := reflect.NewFieldMask(typ, "Spec", "Name", "Labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := maskObject{
+		MaskMeta: &MaskMeta{Name: "a", Labels: map[string]string{"k": "v"}},
+		Spec:     maskSpec{Replicas: 3, Image: "img"},
+		Status:   "ready",
+		hidden:   1,
+	}
+	b := a
+	b.MaskMeta = &MaskMeta{Name: "a", Labels: map[string]string{"k": "v"}}
+	b.Status = "pending"
+	b.hidden = 2
+	if !reflect.EqualMasked(reflect.ValueOf(a), reflect.ValueOf(b), m) {
+		t.Fatal("structs differing only outside the mask must compare equal")
+	}
+	b.Spec.Replicas = 4
+	if reflect.EqualMasked(reflect.ValueOf(a), reflect.ValueOf(b), m) {
+		t.Fatal("structs differing inside the mask must not compare equal")
+	}
+	b.Spec.Replicas = 3
+	b.Labels["k"] = "w"
+	if reflect.EqualMasked(reflect.ValueOf(a), reflect.ValueOf(b), m) {
+		t.Fatal("structs differing in a promoted field must not compare equal")
+	}
+	if reflect.EqualMasked(reflect.ValueOf(a), reflect.ValueOf(maskObject{Spec: a.Spec}), m) {
+		t.Fatal("nil embedded pointer must not equal a populated one")
+	}
+
+	nested, err := reflect.NewFieldMask(typ, "Spec.Image", "1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := nested.Paths(); len(got) != 2 || nested.Type() != typ {
+		t.Fatalf("unexpected mask %v of %s", got, nested.Type())
+	}
+	dst := maskObject{Spec: maskSpec{Replicas: 1}, Status: "keep", hidden: 7}
+	reflect.CopyMasked(reflect.ValueOf(&dst).Elem(), reflect.ValueOf(a), nested)
+	if dst.Spec != a.Spec {
+		t.Fatalf("failed to copy masked fields: %+v", dst.Spec)
+	}
+	if dst.Status != "keep" || dst.hidden != 7 || dst.MaskMeta != nil {
+		t.Fatalf("CopyMasked touched unmasked fields: %+v", dst)
+	}
+	reflect.CopyMasked(reflect.ValueOf(&dst).Elem(), reflect.ValueOf(a), m)
+	if dst.MaskMeta == nil || dst.MaskMeta == a.MaskMeta || dst.Name != "a" || dst.Status != "keep" {
+		t.Fatalf("failed to copy promoted fields: %+v", dst)
+	}
+}
+
+func TestFieldMaskInvalidPath(t *testing.T) {
+	typ := reflect.TypeOf(maskObject{})
+	for _, path := range []string{"Missing", "Spec.Missing", "Status.Len", "hidden", "9", ""} {
+		if _, err := reflect.NewFieldMask(typ, path); err == nil {
+			t.Fatalf("expected error for path %q", path)
+		}
+	}
+	if _, err := reflect.NewFieldMask(reflect.TypeOf(0), "A"); err == nil {
+		t.Fatal("expected error for non-struct type")
+	}
+	if _, err := reflect.NewFieldMask(nil); err == nil {
+		t.Fatal("expected error for nil type")
+	}
+}
@@ -0,0 +1,299 @@
+package reflect
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// tagIndex lists the fields of a struct type carrying one tag key.
+type tagIndex struct {
+	fields  []StructField
+	byValue map[string]int // index into fields
+}
+
+var tagIndexCache typeCache[*sync.Map] // map[string]*tagIndex by tag key
+
+// tagName returns the part of a tag value before the first comma,
+// which names the field for encoders.
+func tagName(value string) string {
+	name, _, _ := strings.Cut(value, ",")
+	return name
+}
+
+// walkFields calls fn for each field of the struct type t, followed, for an
+// embedded struct or pointer to struct, by the fields promoted from it, with
+// Index holding the index sequence from t. Unlike VisibleFields, it does not
+// hide fields whose Go names collide or are shadowed, since their tag names
+// may differ; like encoding/json, callers resolve dominance by tag name.
+// An embedded struct type is not entered again within itself.
+func walkFields(t reflect.Type, fn func(reflect.StructField)) {
+	visiting := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		visiting[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			f.Index = append(append(make([]int, 0, len(index)+1), index...), i)
+			fn(f)
+			if !f.Anonymous {
+				continue
+			}
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !visiting[ft] {
+				walk(ft, f.Index)
+			}
+		}
+		delete(visiting, t)
+	}
+	walk(t, nil)
+}
+
+func buildTagIndex(t Type, key string) *tagIndex {
+	type candidate struct {
+		field StructField
+		name  string
+		depth int
+	}
+	var candidates []candidate
+	walkFields(toRT(t), func(f reflect.StructField) {
+		if !f.IsExported() {
+			return
+		}
+		value, ok := f.Tag.Lookup(key)
+		if !ok {
+			return
+		}
+		candidates = append(candidates, candidate{field: toSF(f), name: tagName(value), depth: len(f.Index)})
+	})
+
+	// A field shadows the fields with the same tag name nested deeper,
+	// and fields with the same tag name at the same depth annihilate
+	// each other, like fields with the same name do.
+	shallowest := make(map[string]int)
+	count := make(map[string]int)
+	for _, c := range candidates {
+		if c.name == "" {
+			continue
+		}
+		if d, ok := shallowest[c.name]; !ok || c.depth < d {
+			shallowest[c.name] = c.depth
+			count[c.name] = 1
+		} else if c.depth == d {
+			count[c.name]++
+		}
+	}
+	idx := &tagIndex{byValue: make(map[string]int)}
+	for _, c := range candidates {
+		if c.name != "" {
+			if c.depth != shallowest[c.name] || count[c.name] != 1 {
+				continue
+			}
+			idx.byValue[c.name] = len(idx.fields)
+		}
+		idx.fields = append(idx.fields, c.field)
+	}
+	return idx
+}
+
+func (t *rtype) tagIndex(key, op string) *tagIndex {
+	if t.Kind() != Struct {
+		panic("reflect: " + op + " of non-struct type " + t.String())
+	}
+	keys := tagIndexCache.get(t, func(Type) *sync.Map { return new(sync.Map) })
+	if idx, ok := keys.Load(key); ok {
+		return idx.(*tagIndex)
+	}
+	idx, _ := keys.LoadOrStore(key, buildTagIndex(t, key))
+	return idx.(*tagIndex)
+}
+
+// FieldByTag returns the exported struct field, possibly promoted from an
+// embedded struct, whose tag for key names it value, and a boolean
+// indicating if the field was found. The name is the part of the tag value
+// before the first comma, so FieldByTag("json", "id") finds a field tagged
+// `json:"id,omitempty"`.
+//
+// Like field names, a tag name of a shallower field shadows the same name
+// deeper in embedded structs, and equal names at the same depth cancel each
+// other out, in which case no field is found. Go names play no part, so
+// promoted fields with the same Go name but different tag names are all
+// found, as encoding/json sees them.
+// The fields are indexed once per type and key, and the result is cached.
+// It panics if the type's Kind is not Struct.
+func (t *rtype) FieldByTag(key, value string) (StructField, bool) {
+	idx := t.tagIndex(key, "FieldByTag")
+	i, ok := idx.byValue[value]
+	if !ok {
+		return StructField{}, false
+	}
+	f := idx.fields[i]
+	f.Index = append([]int(nil), f.Index...)
+	return f, true
+}
+
+// FieldsByTagKey returns the exported struct fields, including promoted
+// ones, whose tag contains key, in field order. Fields shadowed by or
+// conflicting with another field of the same tag name are left out,
+// as described for FieldByTag; fields with an empty tag name never are.
+// The result is cached per type and key; it must not be modified.
+// It panics if the type's Kind is not Struct.
+func (t *rtype) FieldsByTagKey(key string) []StructField {
+	return t.tagIndex(key, "FieldsByTagKey").fields
+}
+
+// A Schema indexes the fields of a struct type by the names several tag
+// keys give them, as built by TagSchema. It is safe for concurrent use.
+type Schema struct {
+	typ    Type
+	fields map[string][]StructField          // by tag key, in field order
+	byName map[string]map[string]StructField // by tag key and name
+}
+
+var schemaCache typeCache[*sync.Map] // map[string]*Schema by NUL-joined sorted keys
+
+// TagSchema returns the fields of the struct type t indexed by the names
+// each of the tag keys gives them, as decoders supporting several tag
+// dialects, such as json, db and env, need. The fields are gathered in a
+// single pass for all keys, and the result is cached per type and set of
+// keys, regardless of their order.
+//
+// For each key, every exported field, including those promoted from
+// embedded structs whether or not their Go names collide, is named as
+// encoding/json names fields: by the part of its tag value before the
+// first comma, or by its Go name if that part is empty or the tag does not
+// contain the key. Fields tagged "-" are skipped, while a tag value of
+// "-," names the field "-". An embedded struct, or pointer to struct,
+// without a tag name is not a field itself; its fields are promoted. One
+// with a tag name is a field like any other, and neither it nor one tagged
+// "-" promotes its fields.
+// Among fields of the same name, the shallowest one wins; at the same
+// depth, a single field with a tag name wins over the others, and if
+// there is no such field, all of them are dropped.
+//
+// It panics if t is nil or its Kind is not Struct.
+func TagSchema(t Type, keys ...string) *Schema {
+	mustBeNonNilType(t, "TagSchema")
+	if t.Kind() != Struct {
+		panic("reflect: TagSchema of non-struct type " + t.String())
+	}
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+	id := strings.Join(sorted, "\x00")
+	schemas := schemaCache.get(t, func(Type) *sync.Map { return new(sync.Map) })
+	if s, ok := schemas.Load(id); ok {
+		return s.(*Schema)
+	}
+	s, _ := schemas.LoadOrStore(id, buildSchema(t, sorted))
+	return s.(*Schema)
+}
+
+func buildSchema(t Type, keys []string) *Schema {
+	type candidate struct {
+		field  StructField
+		name   string
+		tagged bool
+		depth  int
+	}
+	rt := toRT(t)
+	candidates := make(map[string][]candidate, len(keys))
+	walkFields(rt, func(rf reflect.StructField) {
+		if !rf.IsExported() {
+			return
+		}
+		f := toSF(rf)
+		for _, key := range keys {
+			name := tagName(f.Tag.Get(key))
+			if name == "-" && f.Tag.Get(key) == "-" || !promoted(rt, f.Index, key) {
+				continue
+			}
+			tagged := name != ""
+			if !tagged {
+				if f.Anonymous && isStructOrPtrToStruct(f.Type) {
+					continue
+				}
+				name = f.Name
+			}
+			candidates[key] = append(candidates[key], candidate{f, name, tagged, len(f.Index)})
+		}
+	})
+
+	s := &Schema{
+		typ:    t,
+		fields: make(map[string][]StructField, len(keys)),
+		byName: make(map[string]map[string]StructField, len(keys)),
+	}
+	for _, key := range keys {
+		// The winner by name, or -1 if fields of that name cancel out.
+		type best struct {
+			i, depth int
+			tagged   int // number of tagged fields at depth
+			count    int // number of fields at depth
+		}
+		winners := make(map[string]*best)
+		for i, c := range candidates[key] {
+			b, ok := winners[c.name]
+			if !ok || c.depth < b.depth {
+				b = &best{i: i, depth: c.depth}
+				winners[c.name] = b
+			} else if c.depth > b.depth {
+				continue
+			}
+			b.count++
+			if c.tagged {
+				b.tagged++
+				if b.tagged == 1 {
+					b.i = i
+				}
+			}
+		}
+		byName := make(map[string]StructField)
+		var fields []StructField
+		for i, c := range candidates[key] {
+			b := winners[c.name]
+			if b.i != i || b.count > 1 && b.tagged != 1 {
+				continue
+			}
+			byName[c.name] = c.field
+			fields = append(fields, c.field)
+		}
+		s.byName[key] = byName
+		s.fields[key] = fields
+	}
+	return s
+}
+
+// promoted reports whether the tag key lets the field at index be promoted
+// through each of the embedded structs it is nested in: a struct embedded
+// with a tag name, or tagged "-", keeps its fields to itself.
+func promoted(t reflect.Type, index []int, key string) bool {
+	for i := 1; i < len(index); i++ {
+		if tagName(t.FieldByIndex(index[:i]).Tag.Get(key)) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Type returns the struct type s indexes.
+func (s *Schema) Type() Type {
+	return s.typ
+}
+
+// Lookup returns the field that the tag key names name, and a boolean
+// indicating if there is one. The Index of the field must not be modified.
+// It reports false for keys the Schema was not built for.
+func (s *Schema) Lookup(key, name string) (StructField, bool) {
+	f, ok := s.byName[key][name]
+	return f, ok
+}
+
+// Fields returns the fields named by the tag key in field order. The
+// result is shared and must not be modified.
+func (s *Schema) Fields(key string) []StructField {
+	return s.fields[key]
+}
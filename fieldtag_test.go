@@ -0,0 +1,255 @@
+package reflect_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type TagInner struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Extra string `json:"extra"`
+}
+
+type TagOther struct {
+	Extra string `json:"extra"`
+}
+
+// TagIDA and TagIDB have fields of the same Go name but different tag
+// names, which encoding/json both promotes.
+type TagIDA struct {
+	ID int `json:"a_id"`
+}
+
+type TagIDB struct {
+	ID int `json:"b_id"`
+}
+
+type tagIDs struct {
+	TagIDA
+	TagIDB
+}
+
+type tagOuter struct {
+	TagInner
+	*TagOther
+	Key    string `json:"id"`
+	Note   string `json:",omitempty"`
+	Plain  string
+	hidden string `db:"hidden"`
+	Alias  int    `json:"name" db:"alias"`
+	Count  int    `db:"count"`
+}
+
+func TestFieldByTag(t *testing.T) {
+	typ := reflect.TypeOf(tagOuter{})
+	f, ok := typ.FieldByTag("json", "id")
+	if !ok || f.Name != "Key" || len(f.Index) != 1 {
+		t.Fatalf("outer tag must shadow the inner one, got %+v, %v", f, ok)
+	}
+	f, ok = typ.FieldByTag("json", "name")
+	if !ok || f.Name != "Alias" {
+		t.Fatalf("got %+v, %v", f, ok)
+	}
+	if f, ok := typ.FieldByTag("json", "extra"); ok {
+		t.Fatalf("conflicting tags at the same depth must not be found, got %+v", f)
+	}
+	if _, ok := typ.FieldByTag("db", "hidden"); ok {
+		t.Fatal("unexported field must not be found")
+	}
+	f, ok = typ.FieldByTag("db", "count")
+	if !ok || f.Name != "Count" {
+		t.Fatalf("got %+v, %v", f, ok)
+	}
+	f.Index[0] = -1
+	if f, _ := typ.FieldByTag("db", "count"); f.Index[0] == -1 {
+		t.Fatal("FieldByTag returned the cached index sequence")
+	}
+
+	ids := reflect.TypeOf(tagIDs{})
+	for _, name := range []string{"a_id", "b_id"} {
+		if f, ok := ids.FieldByTag("json", name); !ok || f.Name != "ID" || len(f.Index) != 2 {
+			t.Errorf("FieldByTag(json, %s) of tagIDs = %+v, %v", name, f, ok)
+		}
+	}
+
+	inner, ok := reflect.TypeOf(struct{ TagInner }{}).FieldByTag("json", "id")
+	if !ok || inner.Name != "ID" || len(inner.Index) != 2 {
+		t.Fatalf("failed to find promoted field, got %+v, %v", inner, ok)
+	}
+}
+
+func TestFieldsByTagKey(t *testing.T) {
+	typ := reflect.TypeOf(tagOuter{})
+	var names []string
+	for _, f := range typ.FieldsByTagKey("json") {
+		names = append(names, f.Name)
+	}
+	want := []string{"Key", "Note", "Alias"}
+	if len(names) != len(want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got %v, want %v", names, want)
+		}
+	}
+	if fields := typ.FieldsByTagKey("db"); len(fields) != 2 || fields[0].Name != "Alias" || fields[1].Name != "Count" {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+	if fields := typ.FieldsByTagKey("xml"); len(fields) != 0 {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+	if n := testing.AllocsPerRun(100, func() { typ.FieldsByTagKey("json") }); n != 0 {
+		t.Fatalf("cached lookup allocated %v times", n)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("did not panic for non-struct type")
+		}
+	}()
+	reflect.TypeOf(0).FieldsByTagKey("json")
+}
+
+type tenantBase struct {
+	Region string `json:"region" env:"REGION"`
+}
+
+type tenantConfig struct {
+	tenantBase
+	ID       int    `json:"id" db:"tenant_id" env:"TENANT_ID"`
+	Name     string `json:"name,omitempty" db:"name" env:"-"`
+	Email    string `json:"contact" db:"name"`
+	Password string `json:"-" db:"password_hash" env:"PASSWORD"`
+	Dash     string `json:"-," db:"-"`
+	Region   string `db:"region"`
+	Untagged bool
+	secret   string `env:"SECRET"`
+}
+
+func TestTagSchema(t *testing.T) {
+	typ := reflect.TypeOf(tenantConfig{})
+	s := reflect.TagSchema(typ, "json", "db", "env")
+	if s.Type() != typ {
+		t.Errorf("Type() = %v, want %v", s.Type(), typ)
+	}
+	for _, tc := range []struct {
+		key, name string
+		field     string // "" if not found
+	}{
+		{"json", "id", "ID"},
+		{"db", "tenant_id", "ID"},
+		{"env", "TENANT_ID", "ID"},
+		{"json", "name", "Name"},
+		{"json", "contact", "Email"},
+		{"json", "Password", ""},
+		{"json", "password_hash", ""},
+		{"db", "password_hash", "Password"},
+		{"json", "-", "Dash"},
+		{"db", "Dash", ""},
+		{"env", "Name", ""},
+		{"json", "Untagged", "Untagged"},
+		{"env", "SECRET", ""},
+		// Two fields named "name" at the same depth cancel out.
+		{"db", "name", ""},
+		// Region of tenantConfig does not hide the promoted field of
+		// tenantBase, since their names differ by tag.
+		{"json", "region", "Region"},
+		{"json", "Region", "Region"},
+		{"db", "region", "Region"},
+		{"env", "REGION", "Region"},
+		{"env", "Region", "Region"},
+		{"xml", "ID", ""},
+	} {
+		f, ok := s.Lookup(tc.key, tc.name)
+		if ok != (tc.field != "") || ok && f.Name != tc.field {
+			t.Errorf("Lookup(%q, %q) = %s, %v, want %q", tc.key, tc.name, f.Name, ok, tc.field)
+		}
+	}
+	type withBase struct {
+		tenantBase
+		Extra string
+	}
+	f, _ := reflect.TagSchema(reflect.TypeOf(withBase{}), "env").Lookup("env", "REGION")
+	if len(f.Index) != 2 || f.Index[0] != 0 || f.Index[1] != 0 {
+		t.Errorf("Lookup(env, REGION).Index = %v, want [0 0]", f.Index)
+	}
+
+	// Embedded structs with a tag name, or tagged "-", keep their fields.
+	type Inner struct{ A int }
+	type Skip struct{ B int }
+	type embedding struct {
+		Inner `json:"in" db:"-"`
+		Skip  `json:"-"`
+		C     int
+	}
+	es := reflect.TagSchema(reflect.TypeOf(embedding{}), "json", "db")
+	for _, tc := range []struct {
+		key, fields string
+	}{
+		{"json", "Inner,C"},
+		{"db", "B,C"},
+	} {
+		var names []string
+		for _, f := range es.Fields(tc.key) {
+			names = append(names, f.Name)
+		}
+		if got := strings.Join(names, ","); got != tc.fields {
+			t.Errorf("Fields(%s) of embedding = %s, want %s", tc.key, got, tc.fields)
+		}
+	}
+	if f, ok := es.Lookup("json", "in"); !ok || f.Name != "Inner" {
+		t.Errorf("Lookup(json, in) = %s, %v, want Inner", f.Name, ok)
+	}
+	if _, ok := es.Lookup("json", "A"); ok {
+		t.Errorf("Lookup(json, A) found a field of a named embedded struct")
+	}
+	if _, ok := es.Lookup("json", "B"); ok {
+		t.Errorf("Lookup(json, B) found a field of an embedded struct tagged \"-\"")
+	}
+
+	var names []string
+	for _, f := range s.Fields("json") {
+		names = append(names, f.Name)
+	}
+	if got, want := strings.Join(names, ","), "Region,ID,Name,Email,Dash,Region,Untagged"; got != want {
+		t.Errorf("Fields(json) = %s, want %s", got, want)
+	}
+
+	var ids []string
+	for _, f := range reflect.TagSchema(reflect.TypeOf(tagIDs{}), "json").Fields("json") {
+		ids = append(ids, f.Tag.Get("json"))
+	}
+	if got, want := strings.Join(ids, ","), "a_id,b_id"; got != want {
+		t.Errorf("Fields(json) of tagIDs has tags %s, want %s", got, want)
+	}
+
+	if reflect.TagSchema(typ, "env", "db", "json") != s {
+		t.Errorf("TagSchema is not cached independently of key order")
+	}
+	shouldPanic(func() { reflect.TagSchema(reflect.TypeOf(0), "json") })
+}
+
+func BenchmarkTagSchema(b *testing.B) {
+	typ := reflect.TypeOf(tenantConfig{})
+	keys := []string{"json", "db", "env"}
+	b.Run("Schema", func(b *testing.B) {
+		s := reflect.TagSchema(typ, keys...)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, key := range keys {
+				s.Lookup(key, "id")
+			}
+		}
+	})
+	b.Run("FieldByTag", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, key := range keys {
+				typ.FieldByTag(key, "id")
+			}
+		}
+	})
+}
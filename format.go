@@ -0,0 +1,217 @@
+package reflect
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Format implements fmt.Formatter, so that fmt prints the value v holds
+// rather than the result of String, such as "<int Value>". The %#v verb
+// prints v as Stringify does; other verbs print it as fmt prints
+// v.Interface() with the same verb and flags, calling its String, Error
+// or Format method if it has one. Values obtained using unexported fields
+// are printed as well, without calling methods. %T is handled by fmt
+// itself and prints the type of v, not of the value it holds.
+//
+// For %v and %s, a value of an enum type registered with RegisterEnum
+// prints as its name, if it has one and the type has no String method.
+func (v Value) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, Stringify(v))
+		return
+	}
+	if (verb == 'v' || verb == 's') && isIntegerKind(v.flag.kind()) {
+		if name, ok := EnumString(v); ok && (v.flag&flagRO != 0 || ImplementsStringer(v.typ) != ImplByValue) {
+			fmt.Fprintf(f, fmt.FormatString(f, verb), name)
+			return
+		}
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), toRV(v))
+}
+
+// appendValueMaxDepth bounds the nesting AppendValue follows, which also
+// stops it on cyclic data reached through pointers, maps or interfaces.
+const appendValueMaxDepth = 16
+
+// formatScratch holds the values map entries are copied into by AppendValue,
+// so that reading them does not allocate.
+var formatScratch TypePool
+
+// AppendValue appends a human-readable rendering of v to dst and returns
+// the extended buffer, for use in log fields.
+//
+// The rendering resembles the %+v verb of fmt: structs are written as
+// {Name:value ...}, slices and arrays as [elem ...], maps as map[key:elem ...]
+// and strings quoted. Pointers are followed and written as &value, other
+// pointer-like values as their address. Slices, arrays, maps and structs are
+// truncated after limit elements or fields, and strings after limit bytes,
+// with a suffix "…+N more" giving the number of elements or bytes left out;
+// a limit of zero or less disables truncation. Values nested more than 16
+// levels deep, where every pointer, interface and composite value counts as
+// a level, are written as "…", which also cuts cycles.
+//
+// Values of enum types registered with RegisterEnum are written as their
+// name, if they have one. Unlike fmt, AppendValue does not call String or
+// Error methods. It reads
+// unexported fields like exported ones, never panics, and does not allocate
+// except to grow dst.
+func AppendValue(dst []byte, v Value, limit int) []byte {
+	// Reading does not leak unexported values, so read-only values are
+	// treated like any other.
+	v.flag &^= flagRO
+	return appendValue(dst, v, limit, 0)
+}
+
+func appendValue(b []byte, v Value, limit, depth int) []byte {
+	if v.flag == 0 {
+		return append(b, "<invalid Value>"...)
+	}
+	if depth > appendValueMaxDepth {
+		return append(b, "…"...)
+	}
+	if v.flag&flagMethod != 0 {
+		return append(b, "<method value>"...)
+	}
+	switch k := v.flag.kind(); k {
+	case Bool:
+		return strconv.AppendBool(b, value_Bool(v))
+	case Int, Int8, Int16, Int32, Int64:
+		if name, ok := EnumString(v); ok {
+			return append(b, name...)
+		}
+		return strconv.AppendInt(b, value_Int(v), 10)
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		if name, ok := EnumString(v); ok {
+			return append(b, name...)
+		}
+		return strconv.AppendUint(b, value_Uint(v), 10)
+	case Float32:
+		return strconv.AppendFloat(b, value_Float(v), 'g', -1, 32)
+	case Float64:
+		return strconv.AppendFloat(b, value_Float(v), 'g', -1, 64)
+	case Complex64, Complex128:
+		bits := 64
+		if k == Complex64 {
+			bits = 32
+		}
+		c := value_Complex(v)
+		b = append(b, '(')
+		b = strconv.AppendFloat(b, real(c), 'g', -1, bits)
+		if imag(c) >= 0 {
+			b = append(b, '+')
+		}
+		b = strconv.AppendFloat(b, imag(c), 'g', -1, bits)
+		return append(b, "i)"...)
+	case String:
+		return appendTruncatedString(b, value_String(v), limit)
+	case Array, Slice:
+		if k == Slice && value_IsNil(v) {
+			return append(b, "[]"...)
+		}
+		n := value_Len(v)
+		b = append(b, '[')
+		for i := 0; i < n; i++ {
+			if limit > 0 && i == limit {
+				b = appendMore(b, n-i)
+				break
+			}
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = appendValue(b, value_Index(v, i), limit, depth+1)
+		}
+		return append(b, ']')
+	case Map:
+		return appendMap(b, v, limit, depth)
+	case Struct:
+		fields := structLayout(v.typ, "AppendValue").fields
+		b = append(b, '{')
+		for i := range fields {
+			if limit > 0 && i == limit {
+				b = appendMore(b, len(fields)-i)
+				break
+			}
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = append(b, fields[i].name.name()...)
+			b = append(b, ':')
+			f := value_Field(v, i)
+			f.flag &^= flagRO
+			b = appendValue(b, f, limit, depth+1)
+		}
+		return append(b, '}')
+	case Interface:
+		if value_IsNil(v) {
+			return append(b, "<nil>"...)
+		}
+		return appendValue(b, value_Elem(v), limit, depth+1)
+	case Ptr:
+		if value_IsNil(v) {
+			return append(b, "<nil>"...)
+		}
+		b = append(b, '&')
+		return appendValue(b, value_Elem(v), limit, depth+1)
+	default: // Chan, Func, UnsafePointer
+		p := value_Pointer(v)
+		if p == 0 {
+			return append(b, "<nil>"...)
+		}
+		b = append(b, "0x"...)
+		return strconv.AppendUint(b, uint64(p), 16)
+	}
+}
+
+func appendMap(b []byte, v Value, limit, depth int) []byte {
+	if value_IsNil(v) {
+		return append(b, "map[]"...)
+	}
+	n := value_Len(v)
+	b = append(b, "map["...)
+	if n == 0 {
+		return append(b, ']')
+	}
+	mt := v.typ
+	key := formatScratch.Get(mt.Key())
+	elem := formatScratch.Get(mt.Elem())
+	var it MapIter
+	it.Reset(toRV(v))
+	for i := 0; it.Next(); i++ {
+		if limit > 0 && i == limit {
+			b = appendMore(b, n-i)
+			break
+		}
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		toRV(key).SetIterKey(&it)
+		toRV(elem).SetIterValue(&it)
+		b = appendValue(b, key, limit, depth+1)
+		b = append(b, ':')
+		b = appendValue(b, elem, limit, depth+1)
+	}
+	formatScratch.Put(key)
+	formatScratch.Put(elem)
+	return append(b, ']')
+}
+
+// appendTruncatedString appends s quoted, cut after at most limit bytes
+// at a rune boundary.
+func appendTruncatedString(b []byte, s string, limit int) []byte {
+	if limit <= 0 || len(s) <= limit {
+		return strconv.AppendQuote(b, s)
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	b = strconv.AppendQuote(b, s[:cut])
+	return appendMore(b, len(s)-cut)
+}
+
+func appendMore(b []byte, n int) []byte {
+	b = append(b, "…+"...)
+	b = strconv.AppendInt(b, int64(n), 10)
+	return append(b, " more"...)
+}
@@ -0,0 +1,160 @@
+package reflect_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type fmtInner struct {
+	ID   int
+	tags []string
+}
+
+type fmtPayload struct {
+	Name   string
+	Score  float64
+	OK     bool
+	Inner  *fmtInner
+	Any    any
+	secret map[string]int
+	C      complex64
+	Nil    *int
+	Fn     func()
+}
+
+type fmtDeep struct {
+	Level int
+	Next  *fmtDeep
+}
+
+func TestAppendValue(t *testing.T) {
+	p := fmtPayload{
+		Name:   "log",
+		Score:  1.5,
+		OK:     true,
+		Inner:  &fmtInner{ID: 7, tags: []string{"a", "b"}},
+		Any:    uint8(3),
+		secret: map[string]int{"k": 1},
+		C:      1 - 2i,
+	}
+	want := `{Name:"log" Score:1.5 OK:true Inner:&{ID:7 tags:["a" "b"]} Any:3 secret:map["k":1] C:(1-2i) Nil:<nil> Fn:<nil>}`
+	if got := string(reflect.AppendValue(nil, reflect.ValueOf(p), 0)); got != want {
+		t.Errorf("AppendValue:\ngot  %s\nwant %s", got, want)
+	}
+
+	// Values obtained through unexported fields are rendered too.
+	secret := reflect.ValueOf(p).FieldByName("secret")
+	if got := string(reflect.AppendValue([]byte("x="), secret, 0)); got != `x=map["k":1]` {
+		t.Errorf("AppendValue of unexported field = %s", got)
+	}
+
+	for _, tc := range []struct {
+		v     any
+		limit int
+		want  string
+	}{
+		{[]int{1, 2, 3, 4}, 2, "[1 2…+2 more]"},
+		{[3]string{"a", "b", "c"}, 3, `["a" "b" "c"]`},
+		{"héllo", 2, `"h"…+5 more`},
+		{"héllo", 3, `"hé"…+3 more`},
+		{fmtInner{ID: 1, tags: []string{"x"}}, 1, "{ID:1…+1 more}"},
+		{map[int]bool{1: true, 2: false}, 1, "…+1 more]"},
+		{[]byte(nil), 1, "[]"},
+		{map[string]int(nil), 1, "map[]"},
+	} {
+		got := string(reflect.AppendValue(nil, reflect.ValueOf(tc.v), tc.limit))
+		if !strings.HasSuffix(got, tc.want) {
+			t.Errorf("AppendValue(%#v, %d) = %s, want %s", tc.v, tc.limit, got, tc.want)
+		}
+	}
+	if got := string(reflect.AppendValue(nil, reflect.Value{}, 1)); got != "<invalid Value>" {
+		t.Errorf("AppendValue of invalid Value = %s", got)
+	}
+}
+
+func TestAppendValueLargeSlice(t *testing.T) {
+	big := make([]int, 1e6)
+	got := string(reflect.AppendValue(nil, reflect.ValueOf(big), 3))
+	if want := "[0 0 0…+999997 more]"; got != want {
+		t.Errorf("AppendValue of 1e6 elements = %s, want %s", got, want)
+	}
+}
+
+func TestAppendValueDeep(t *testing.T) {
+	var d *fmtDeep
+	for i := 100; i > 0; i-- {
+		d = &fmtDeep{Level: i, Next: d}
+	}
+	got := string(reflect.AppendValue(nil, reflect.ValueOf(d), 0))
+	if !strings.HasPrefix(got, "&{Level:1 Next:&{Level:2 ") || !strings.HasSuffix(got, "Next:&…}}}}}}}}") || strings.Contains(got, "Level:9 ") {
+		t.Errorf("AppendValue of deep struct = %s", got)
+	}
+
+	// Pointers and structs each count as a level, and cycles are cut at
+	// the same depth.
+	c := &fmtDeep{Level: 1}
+	c.Next = c
+	if got := string(reflect.AppendValue(nil, reflect.ValueOf(c), 0)); !strings.HasSuffix(got, "Next:&…}}}}}}}}") {
+		t.Errorf("AppendValue of cycle = %s", got)
+	}
+}
+
+func TestAppendValueAllocs(t *testing.T) {
+	if reflect.CrossCheckEnabled() {
+		t.Skip("allocations differ in cross-check mode")
+	}
+	v := reflect.ValueOf(fmtPayload{Name: "n", Inner: &fmtInner{tags: []string{"t"}}, secret: map[string]int{"a": 1, "b": 2}, Any: 1.5})
+	buf := make([]byte, 0, 1024)
+	reflect.AppendValue(buf, v, 8)
+	if n := testing.AllocsPerRun(100, func() { buf = reflect.AppendValue(buf[:0], v, 8) }); n != 0 {
+		t.Errorf("AppendValue into pre-sized buffer: %v allocs, want 0", n)
+	}
+}
+
+func BenchmarkAppendValue(b *testing.B) {
+	v := reflect.ValueOf(fmtPayload{Name: "n", Inner: &fmtInner{tags: []string{"t"}}, secret: map[string]int{"a": 1, "b": 2}, Any: 1.5})
+	buf := make([]byte, 0, 1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = reflect.AppendValue(buf[:0], v, 8)
+	}
+}
+
+type fmtStringer int
+
+func (fmtStringer) String() string { return "stringer" }
+
+func TestValueFormat(t *testing.T) {
+	p := fmtPayload{Name: "n", Inner: &fmtInner{ID: 7, tags: []string{"a", "b"}}}
+	inner := reflect.ValueOf(p.Inner).Elem()
+	tests := []struct {
+		format string
+		v      reflect.Value
+		want   string
+	}{
+		{"%v", reflect.ValueOf(42), "42"},
+		{"%5d", reflect.ValueOf(42), "   42"},
+		{"%-4v|", reflect.ValueOf(42), "42  |"},
+		{"%x", reflect.ValueOf(42), "2a"},
+		{"%q", reflect.ValueOf("hi"), `"hi"`},
+		{"%v", reflect.ValueOf(fmtInner{ID: 1}), "{1 []}"},
+		{"%+v", inner, "{ID:7 tags:[a b]}"},
+		{"%v", inner.Field(1), "[a b]"},
+		{"%v", reflect.ValueOf(fmtStringer(3)), "stringer"},
+		{"%d", reflect.ValueOf(fmtStringer(3)), "3"},
+		{"%v", reflect.ValueOf(struct{ s fmtStringer }{3}).Field(0), "3"},
+		{"%#v", reflect.ValueOf(42), "42"},
+		{"%#v", inner, "reflect_test.fmtInner{7, []string{a, b}}"},
+		{"%#v", reflect.ValueOf(&fmtInner{ID: 2}), "*reflect_test.fmtInner(&reflect_test.fmtInner{2, []string{}})"},
+		{"%v", reflect.Value{}, "<invalid reflect.Value>"},
+		{"%T", reflect.ValueOf(42), "reflect.Value"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, tt.v); got != tt.want {
+			t.Errorf("Sprintf(%q, %#v) = %q, want %q", tt.format, tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package reflect
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// stubPCs holds the code pointers shared by all functions created at run
+// time: the entry point of MakeFunc results and that of method values
+// converted to funcs with Interface.
+var stubPCs = [...]uintptr{
+	reflect.MakeFunc(reflect.TypeOf(func() {}), nil).Pointer(),
+	reflect.ValueOf(reflect.ValueOf(time.Duration(0)).Method(0).Interface()).Pointer(),
+}
+
+// FuncID returns an identifier of the function v, or 0 if v is a nil
+// func Value. Unlike Pointer, which returns the same code pointer for all
+// functions created by MakeFunc, it tells such functions apart:
+//
+//   - A compiled function, including every closure of the same function
+//     literal, has the same FuncID wherever it is obtained from.
+//   - A function created by MakeFunc, or a method value converted to a func
+//     by Interface, has a FuncID of its own, which survives Interface and
+//     ValueOf round trips. It is drawn from a counter when FuncID is first
+//     called on the function, and is never reused, even after the function
+//     has been garbage collected.
+//   - A method value obtained with Method or MethodByName has the FuncID of
+//     the method's function; the receiver is not part of it.
+//
+// It panics if v's Kind is not Func.
+func (v Value) FuncID() uint64 {
+	v.mustBeValid("reflect.Value.FuncID")
+	if k := v.flag.kind(); k != Func {
+		panic(&ValueError{Method: "reflect.Value.FuncID", Kind: k})
+	}
+	if v.flag&flagMethod != 0 {
+		return methodFuncID(v)
+	}
+	closure := v.pointer()
+	if closure == nil {
+		return 0
+	}
+	pc := *(*uintptr)(closure)
+	for _, stub := range stubPCs {
+		if pc == stub {
+			return runtimeFuncID(closure)
+		}
+	}
+	return uint64(pc)
+}
+
+// runtimeFuncIDBit is set in the FuncIDs of functions created at run time,
+// which keeps them apart from code pointers.
+const runtimeFuncIDBit = 1 << 63
+
+var runtimeFuncIDs struct {
+	sync.Mutex
+	next uint64
+	ids  map[uintptr]uint64
+}
+
+// runtimeFuncID returns the FuncID of the function created at run time
+// whose closure is at p, assigning the next one if it has none yet. The
+// closures are keyed by address so that the table does not keep them
+// alive; an entry is dropped when its closure is garbage collected, before
+// the address can be reused.
+func runtimeFuncID(p unsafe.Pointer) uint64 {
+	r := &runtimeFuncIDs
+	r.Lock()
+	defer r.Unlock()
+	if id, ok := r.ids[uintptr(p)]; ok {
+		return id
+	}
+	if r.ids == nil {
+		r.ids = make(map[uintptr]uint64)
+	}
+	r.next++
+	id := runtimeFuncIDBit | r.next
+	r.ids[uintptr(p)] = id
+	runtime.SetFinalizer((*byte)(p), func(p *byte) {
+		r.Lock()
+		delete(r.ids, uintptr(unsafe.Pointer(p)))
+		r.Unlock()
+	})
+	return id
+}
+
+// methodFuncID returns the FuncID of the function implementing the method
+// value v.
+func methodFuncID(v Value) uint64 {
+	i := int(v.flag >> flagMethodShift)
+	rcvr := Value{v.typ, v.ptr, v.flag&(flagRO|flagIndir) | flag(v.typ.Kind())}
+	name := type_Method(v.typ, i).Name
+	if v.typ.Kind() == Interface {
+		rcvr = value_Elem(rcvr)
+	}
+	m, _ := rcvr.typ.MethodByName(name)
+	return m.Func.FuncID()
+}
@@ -0,0 +1,119 @@
+package reflect_test
+
+import (
+	corereflect "reflect"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type GList[T any] struct {
+	Items []T
+	N     int
+}
+
+type GMap[K comparable, V any] map[K]V
+
+type GFunc[A, B, C any] func(A, B) C
+
+type intList struct {
+	Items []int
+	N     int
+}
+
+type genericElem struct{}
+
+func TestInstantiatedTypes(t *testing.T) {
+	type localElem struct{}
+	for _, tc := range []struct {
+		v        any
+		name     string
+		typeArgs string
+	}{
+		{GList[int]{}, "GList[int]", "int"},
+		{GList[int64]{}, "GList[int64]", "int64"},
+		{GList[genericElem]{}, "GList[github.com/3JoB/go-reflect_test.genericElem]", "github.com/3JoB/go-reflect_test.genericElem"},
+		{GMap[string, GList[int]]{}, "GMap[string,github.com/3JoB/go-reflect_test.GList[int]]", "string,github.com/3JoB/go-reflect_test.GList[int]"},
+		{GFunc[int, string, []byte](nil), "GFunc[int,string,[]uint8]", "int,string,[]uint8"},
+		{intList{}, "intList", ""},
+		{[]GList[int]{}, "", ""},
+	} {
+		typ := reflect.TypeOf(tc.v)
+		if got := typ.Name(); got != tc.name {
+			t.Errorf("%v: Name() = %q, want %q", typ, got, tc.name)
+		}
+		if got := typ.IsInstantiated(); got != (tc.typeArgs != "") {
+			t.Errorf("%v: IsInstantiated() = %v", typ, got)
+		}
+		if got := typ.TypeArgsString(); got != tc.typeArgs {
+			t.Errorf("%v: TypeArgsString() = %q, want %q", typ, got, tc.typeArgs)
+		}
+		if rt := reflect.ToReflectType(typ); rt != corereflect.TypeOf(tc.v) || reflect.ToType(rt) != typ {
+			t.Errorf("%v: bridge round-trip gives %v", typ, rt)
+		}
+		v := reflect.ValueOf(tc.v)
+		if rv := reflect.ToReflectValue(v); rv.Type() != corereflect.TypeOf(tc.v) || reflect.ToValue(rv).Type() != typ {
+			t.Errorf("%v: Value bridge round-trip gives %v", typ, rv.Type())
+		}
+	}
+
+	// Distinct instantiations are distinct types with distinct TypeIDs.
+	ids := map[uintptr]any{}
+	for _, v := range []any{
+		GList[int]{}, GList[int64]{}, GList[genericElem]{}, GList[localElem]{},
+		GMap[string, int]{}, GMap[int, string]{}, GMap[string, GList[int]]{},
+		GFunc[int, string, bool](nil), GFunc[string, int, bool](nil),
+		intList{},
+	} {
+		id := reflect.TypeID(v)
+		if prev, ok := ids[id]; ok {
+			t.Errorf("TypeID of %T collides with %T", v, prev)
+		}
+		ids[id] = v
+		if reflect.TypeID(v) != id {
+			t.Errorf("TypeID of %T is not stable", v)
+		}
+	}
+	if reflect.TypeOf(GList[int]{}) != reflect.TypeOf(GList[int]{Items: []int{1}}) {
+		t.Error("TypeOf differs for values of the same instantiation")
+	}
+
+	// Conversion and assignability follow the underlying types.
+	list := reflect.TypeOf(GList[int]{})
+	plain := reflect.TypeOf(intList{})
+	unnamed := reflect.TypeOf(struct {
+		Items []int
+		N     int
+	}{})
+	if !list.ConvertibleTo(plain) || !plain.ConvertibleTo(list) {
+		t.Error("GList[int] and intList are not convertible")
+	}
+	if list.AssignableTo(plain) || plain.AssignableTo(list) {
+		t.Error("GList[int] and intList are assignable")
+	}
+	if !unnamed.AssignableTo(list) || !list.AssignableTo(unnamed) {
+		t.Error("GList[int] and its unnamed underlying type are not assignable")
+	}
+	if list.ConvertibleTo(reflect.TypeOf(GList[int64]{})) {
+		t.Error("GList[int] is convertible to GList[int64]")
+	}
+	in := GList[int]{Items: []int{1, 2}, N: 2}
+	out := reflect.ValueOf(in).Convert(plain)
+	if out.Type() != plain || !reflect.DeepEqual(out.Interface(), intList(in)) {
+		t.Errorf("Convert to intList gave %#v", out.Interface())
+	}
+	back := out.Convert(list)
+	if back.Type() != list || !reflect.DeepEqual(back.Interface(), in) {
+		t.Errorf("Convert back to GList[int] gave %#v", back.Interface())
+	}
+
+	m := reflect.ValueOf(GMap[string, int]{"a": 1}).Convert(reflect.TypeOf(map[string]int(nil)))
+	if got := m.Interface().(map[string]int); got["a"] != 1 {
+		t.Errorf("Convert GMap to map gave %v", got)
+	}
+	f := reflect.ValueOf(GFunc[int, int, int](func(a, b int) int { return a + b }))
+	g := f.Convert(reflect.TypeOf(func(int, int) int { return 0 })).Interface().(func(int, int) int)
+	if g(1, 2) != 3 {
+		t.Error("Convert GFunc to func gave wrong function")
+	}
+}
@@ -0,0 +1,96 @@
+package reflect
+
+import (
+	"encoding"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// Interface types that encoders commonly check for.
+var (
+	ErrorType           = TypeOf((*error)(nil)).Elem()
+	StringerType        = TypeOf((*fmt.Stringer)(nil)).Elem()
+	TextMarshalerType   = TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	TextUnmarshalerType = TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// An ImplKind reports how a type implements an interface, as found by
+// ImplCheck.
+type ImplKind uint8
+
+const (
+	// ImplNo means that neither the type nor a pointer to it implements
+	// the interface.
+	ImplNo ImplKind = iota
+	// ImplByValue means that the type implements the interface, and so
+	// does a pointer to it unless the type is itself an interface type.
+	ImplByValue
+	// ImplByPointer means that only a pointer to the type implements the
+	// interface, so an addressable value is needed to call its methods.
+	ImplByPointer
+)
+
+var implNames = []string{
+	ImplNo:        "No",
+	ImplByValue:   "ByValue",
+	ImplByPointer: "ByPointer",
+}
+
+// String returns the name of i without its Impl prefix.
+func (i ImplKind) String() string {
+	if int(i) < len(implNames) {
+		return implNames[i]
+	}
+	return "ImplKind(" + strconv.Itoa(int(i)) + ")"
+}
+
+type implKey struct {
+	t, iface Type
+}
+
+var implCache sync.Map // map[implKey]ImplKind
+
+// ImplCheck reports whether the type t or a pointer to it implements the
+// interface type iface. The result is computed once per pair of types and
+// cached, so that checking it again is a single map read.
+// It panics if t or iface is nil or if iface is not an interface type.
+func ImplCheck(t, iface Type) ImplKind {
+	key := implKey{t: t, iface: iface}
+	if impl, ok := implCache.Load(key); ok {
+		return impl.(ImplKind)
+	}
+	mustBeNonNilType(t, "ImplCheck")
+	mustBeNonNilType(iface, "ImplCheck")
+	if iface.Kind() != Interface {
+		panic("reflect: non-interface type passed to ImplCheck")
+	}
+	impl := ImplNo
+	if t.Implements(iface) {
+		impl = ImplByValue
+	} else if t.Kind() != Interface && PtrTo(t).Implements(iface) {
+		impl = ImplByPointer
+	}
+	implCache.Store(key, impl)
+	return impl
+}
+
+// ImplementsError is ImplCheck(t, ErrorType).
+func ImplementsError(t Type) ImplKind {
+	return ImplCheck(t, ErrorType)
+}
+
+// ImplementsStringer is ImplCheck(t, StringerType).
+func ImplementsStringer(t Type) ImplKind {
+	return ImplCheck(t, StringerType)
+}
+
+// ImplementsTextMarshaler is ImplCheck(t, TextMarshalerType).
+func ImplementsTextMarshaler(t Type) ImplKind {
+	return ImplCheck(t, TextMarshalerType)
+}
+
+// ImplementsTextUnmarshaler is ImplCheck(t, TextUnmarshalerType).
+func ImplementsTextUnmarshaler(t Type) ImplKind {
+	return ImplCheck(t, TextUnmarshalerType)
+}
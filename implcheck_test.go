@@ -0,0 +1,92 @@
+package reflect_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type implValueStringer struct{}
+
+func (implValueStringer) String() string { return "v" }
+
+type implPtrMarshaler struct{}
+
+func (*implPtrMarshaler) MarshalText() ([]byte, error) { return nil, nil }
+
+func (*implPtrMarshaler) Error() string { return "p" }
+
+func TestImplCheck(t *testing.T) {
+	for _, tc := range []struct {
+		typ   reflect.Type
+		iface reflect.Type
+		want  reflect.ImplKind
+	}{
+		{reflect.TypeOf(implValueStringer{}), reflect.StringerType, reflect.ImplByValue},
+		{reflect.TypeOf(&implValueStringer{}), reflect.StringerType, reflect.ImplByValue},
+		{reflect.TypeOf(implPtrMarshaler{}), reflect.TextMarshalerType, reflect.ImplByPointer},
+		{reflect.TypeOf(&implPtrMarshaler{}), reflect.TextMarshalerType, reflect.ImplByValue},
+		{reflect.TypeOf(implPtrMarshaler{}), reflect.ErrorType, reflect.ImplByPointer},
+		{reflect.TypeOf(implPtrMarshaler{}), reflect.StringerType, reflect.ImplNo},
+		{reflect.TypeOf(0), reflect.StringerType, reflect.ImplNo},
+		{reflect.TypeOf(time.Time{}), reflect.TextUnmarshalerType, reflect.ImplByPointer},
+		{reflect.TypeOf(time.Time{}), reflect.TextMarshalerType, reflect.ImplByValue},
+		{reflect.StringerType, reflect.StringerType, reflect.ImplByValue},
+		{reflect.ErrorType, reflect.StringerType, reflect.ImplNo},
+	} {
+		// The second check is served from the cache.
+		for i := 0; i < 2; i++ {
+			if got := reflect.ImplCheck(tc.typ, tc.iface); got != tc.want {
+				t.Errorf("ImplCheck(%v, %v) = %v, want %v", tc.typ, tc.iface, got, tc.want)
+			}
+		}
+	}
+
+	if got := reflect.ImplementsStringer(reflect.TypeOf(implValueStringer{})); got != reflect.ImplByValue {
+		t.Errorf("ImplementsStringer = %v", got)
+	}
+	if got := reflect.ImplementsError(reflect.TypeOf(implPtrMarshaler{})); got != reflect.ImplByPointer {
+		t.Errorf("ImplementsError = %v", got)
+	}
+	if got := reflect.ImplementsTextMarshaler(reflect.TypeOf("")); got != reflect.ImplNo {
+		t.Errorf("ImplementsTextMarshaler = %v", got)
+	}
+	if got := reflect.ImplementsTextUnmarshaler(reflect.TypeOf(&time.Time{})); got != reflect.ImplByValue {
+		t.Errorf("ImplementsTextUnmarshaler = %v", got)
+	}
+	if s := fmt.Sprint(reflect.ImplNo, reflect.ImplByValue, reflect.ImplByPointer); s != "No ByValue ByPointer" {
+		t.Errorf("String = %q", s)
+	}
+
+	typ := reflect.TypeOf(implPtrMarshaler{})
+	reflect.ImplementsTextMarshaler(typ)
+	if n := testing.AllocsPerRun(100, func() { reflect.ImplementsTextMarshaler(typ) }); n != 0 {
+		t.Errorf("cached ImplCheck: %v allocs, want 0", n)
+	}
+
+	if panicOf(func() { reflect.ImplCheck(typ, reflect.TypeOf(0)) }) == nil {
+		t.Errorf("ImplCheck with a non-interface type did not panic")
+	}
+	if panicOf(func() { reflect.ImplCheck(nil, reflect.StringerType) }) == nil {
+		t.Errorf("ImplCheck with a nil type did not panic")
+	}
+}
+
+func BenchmarkImplCheck(b *testing.B) {
+	types := []reflect.Type{
+		reflect.TypeOf(implValueStringer{}),
+		reflect.TypeOf(implPtrMarshaler{}),
+		reflect.TypeOf(time.Time{}),
+		reflect.TypeOf(0),
+	}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			reflect.ImplementsTextMarshaler(types[i%len(types)])
+			i++
+		}
+	})
+}
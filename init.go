@@ -159,6 +159,11 @@ func validate() error {
 	if err := validateValueOf(); err != nil {
 		return err
 	}
+	if !SkipCompatCheck {
+		if err := runCompatProbes(compatProbes); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
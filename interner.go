@@ -0,0 +1,81 @@
+package reflect
+
+import (
+	"sync"
+)
+
+// DefaultKeyInternerSize is the number of keys a KeyInterner keeps when
+// its MaxKeys field is zero.
+const DefaultKeyInternerSize = 1024
+
+// A KeyInterner caches string Values by content, so that decoders filling
+// maps with SetMapIndex can reuse one Value per distinct key instead of
+// allocating a new string for every record.
+//
+// The Values it returns are not addressable and their strings are never
+// modified, so they are safe to store as map keys.
+// A KeyInterner is safe for concurrent use.
+type KeyInterner struct {
+	// MaxKeys bounds the number of cached keys. When the cache is full,
+	// an arbitrary key is evicted to make room for a new one.
+	// If MaxKeys is zero, DefaultKeyInternerSize is used.
+	MaxKeys int
+
+	mu   sync.Mutex
+	keys map[string]Value
+}
+
+// NewKeyInterner returns a KeyInterner that keeps up to
+// DefaultKeyInternerSize keys.
+func NewKeyInterner() *KeyInterner {
+	return &KeyInterner{}
+}
+
+// InternString returns a string Value holding s.
+// Repeated calls with the same content return the same Value
+// for as long as it stays in the cache.
+func (k *KeyInterner) InternString(s string) Value {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if v, ok := k.keys[s]; ok {
+		return v
+	}
+	return k.add(s)
+}
+
+// InternBytes is like InternString(string(b)), but does not allocate
+// if the key is cached.
+func (k *KeyInterner) InternBytes(b []byte) Value {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if v, ok := k.keys[string(b)]; ok {
+		return v
+	}
+	return k.add(string(b))
+}
+
+// Len returns the number of cached keys.
+func (k *KeyInterner) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.keys)
+}
+
+func (k *KeyInterner) add(s string) Value {
+	limit := k.MaxKeys
+	if limit <= 0 {
+		limit = DefaultKeyInternerSize
+	}
+	if k.keys == nil {
+		k.keys = make(map[string]Value)
+	}
+	for key := range k.keys {
+		if len(k.keys) < limit {
+			break
+		}
+		delete(k.keys, key)
+	}
+	v := ValueOf(s)
+	k.keys[s] = v
+	return v
+}
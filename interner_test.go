@@ -0,0 +1,80 @@
+package reflect_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+func TestKeyInterner(t *testing.T) {
+	k := reflect.NewKeyInterner()
+	a := k.InternString("name")
+	b := k.InternBytes([]byte("name"))
+	if a.String() != "name" || b.String() != "name" || a.Kind() != reflect.String {
+		t.Fatalf("unexpected values %v, %v", a, b)
+	}
+	if a.CanAddr() || a.CanSet() {
+		t.Fatal("interned Value must not be settable")
+	}
+	if k.Len() != 1 {
+		t.Fatalf("got %d keys, want 1", k.Len())
+	}
+
+	m := map[string]int{}
+	mv := reflect.ValueOf(m)
+	buf := []byte("key")
+	mv.SetMapIndex(k.InternBytes(buf), reflect.ValueOf(1))
+	copy(buf, "xyz")
+	mv.SetMapIndex(k.InternBytes(buf), reflect.ValueOf(2))
+	if m["key"] != 1 || m["xyz"] != 2 || len(m) != 2 {
+		t.Fatalf("unexpected map %v", m)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { k.InternBytes(buf) }); n != 0 {
+		t.Fatalf("InternBytes of a cached key allocated %v times", n)
+	}
+}
+
+func TestKeyInternerEviction(t *testing.T) {
+	k := &reflect.KeyInterner{MaxKeys: 8}
+	for i := 0; i < 100; i++ {
+		s := strconv.Itoa(i)
+		if v := k.InternString(s); v.String() != s {
+			t.Fatalf("got %q, want %q", v.String(), s)
+		}
+		if k.Len() > 8 {
+			t.Fatalf("cache grew to %d keys", k.Len())
+		}
+	}
+	if v := k.InternString("99"); v.String() != "99" {
+		t.Fatal("most recent key must be cached")
+	}
+}
+
+func BenchmarkKeyInterner(b *testing.B) {
+	var records [20][]byte
+	for i := range records {
+		records[i] = []byte("field_" + strconv.Itoa(i))
+	}
+	val := reflect.ValueOf(1)
+	b.Run("ValueOf", func(b *testing.B) {
+		mv := reflect.ValueOf(map[string]int{})
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, key := range records {
+				mv.SetMapIndex(reflect.ValueOf(string(key)), val)
+			}
+		}
+	})
+	b.Run("KeyInterner", func(b *testing.B) {
+		mv := reflect.ValueOf(map[string]int{})
+		k := reflect.NewKeyInterner()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, key := range records {
+				mv.SetMapIndex(k.InternBytes(key), val)
+			}
+		}
+	})
+}
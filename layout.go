@@ -0,0 +1,170 @@
+package reflect
+
+import (
+	"unsafe"
+)
+
+// abiType mirrors the header of the runtime type descriptor (internal/abi.Type).
+type abiType struct {
+	size       uintptr
+	ptrBytes   uintptr
+	hash       uint32
+	tflag      uint8
+	align      uint8
+	fieldAlign uint8
+	kind       uint8
+	equal      func(unsafe.Pointer, unsafe.Pointer) bool
+	gcdata     *byte
+	str        int32
+	ptrToThis  int32
+}
+
+// structTypeLayout mirrors internal/abi.StructType.
+type structTypeLayout struct {
+	abiType
+	pkgPath nameLayout
+	fields  []structFieldLayout
+}
+
+// structFieldLayout mirrors internal/abi.StructField.
+type structFieldLayout struct {
+	name   nameLayout
+	typ    Type
+	offset uintptr
+}
+
+// nameLayout mirrors internal/abi.Name, an encoded name with optional tag.
+type nameLayout struct {
+	bytes *byte
+}
+
+func (n nameLayout) data(off int) *byte {
+	return (*byte)(unsafe.Add(unsafe.Pointer(n.bytes), off))
+}
+
+func (n nameLayout) isExported() bool {
+	return (*n.bytes)&(1<<0) != 0
+}
+
+func (n nameLayout) hasTag() bool {
+	return (*n.bytes)&(1<<1) != 0
+}
+
+func (n nameLayout) isEmbedded() bool {
+	return (*n.bytes)&(1<<3) != 0
+}
+
+func (n nameLayout) readVarint(off int) (int, int) {
+	v := 0
+	for i := 0; ; i++ {
+		x := *n.data(off + i)
+		v += int(x&0x7f) << (7 * i)
+		if x&0x80 == 0 {
+			return i + 1, v
+		}
+	}
+}
+
+func (n nameLayout) name() string {
+	if n.bytes == nil {
+		return ""
+	}
+	i, l := n.readVarint(1)
+	return unsafe.String(n.data(1+i), l)
+}
+
+func (n nameLayout) tag() string {
+	if !n.hasTag() {
+		return ""
+	}
+	i, l := n.readVarint(1)
+	i2, l2 := n.readVarint(1 + i + l)
+	return unsafe.String(n.data(1+i+l+i2), l2)
+}
+
+// structLayout returns the struct descriptor of t.
+// It panics if t's Kind is not Struct.
+func structLayout(t Type, op string) *structTypeLayout {
+	if t.Kind() != Struct {
+		panic("reflect: " + op + " of non-struct type " + t.String())
+	}
+	return (*structTypeLayout)(unsafe.Pointer(t))
+}
+
+// A TypeLayout describes how values of a type are laid out in memory.
+type TypeLayout struct {
+	Type       Type
+	Size       uintptr
+	Align      int
+	FieldAlign int
+
+	// DirectIface reports whether the type is pointer-shaped, that is,
+	// whether an interface holds a value of it directly rather than a
+	// pointer to a copy.
+	DirectIface bool
+
+	// PointerWords has one element per pointer-sized word of a value,
+	// which reports whether the garbage collector scans the word as a
+	// pointer.
+	PointerWords []bool
+
+	// Fields describes the fields of a struct type, and is nil otherwise.
+	Fields []FieldLayout
+}
+
+// A FieldLayout describes a struct field within a TypeLayout.
+type FieldLayout struct {
+	Name   string
+	Offset uintptr
+	Layout TypeLayout
+}
+
+// Explain returns the memory layout of t, for code that accesses values
+// through unsafe pointers, such as encoders built on TypeAndPtrOf.
+// It panics if t is nil.
+func Explain(t Type) TypeLayout {
+	mustBeNonNilType(t, "Explain")
+	l := TypeLayout{
+		Type:         t,
+		Size:         t.Size(),
+		Align:        t.Align(),
+		FieldAlign:   t.FieldAlign(),
+		DirectIface:  !ifaceIndir(t),
+		PointerWords: make([]bool, (t.Size()+ptrSize-1)/ptrSize),
+	}
+	markPointerWords(l.PointerWords, 0, t)
+	if t.Kind() == Struct {
+		l.Fields = make([]FieldLayout, t.NumField())
+		for i := range l.Fields {
+			f := t.Field(i)
+			l.Fields[i] = FieldLayout{Name: f.Name, Offset: f.Offset, Layout: Explain(f.Type)}
+		}
+	}
+	return l
+}
+
+const ptrSize = unsafe.Sizeof(uintptr(0))
+
+// markPointerWords marks the elements of words for the words of a value
+// of type t at offset off that hold pointers.
+func markPointerWords(words []bool, off uintptr, t Type) {
+	if (*abiType)(unsafe.Pointer(t)).ptrBytes == 0 {
+		return
+	}
+	switch t.Kind() {
+	case Chan, Func, Map, Ptr, Slice, String, UnsafePointer:
+		words[off/ptrSize] = true
+	case Interface:
+		words[off/ptrSize] = true
+		words[off/ptrSize+1] = true
+	case Array:
+		for i, n := 0, t.Len(); i < n; i++ {
+			markPointerWords(words, off+uintptr(i)*t.Elem().Size(), t.Elem())
+		}
+	case Struct:
+		for i, n := 0, t.NumField(); i < n; i++ {
+			f := t.Field(i)
+			markPointerWords(words, off+f.Offset, f.Type)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package reflect
+
+import (
+	"fmt"
+	"sort"
+)
+
+// StructLiteral returns a new value of the struct type t with its fields
+// set from fieldValues in order, like the composite literal T{v0, v1, ...}.
+//
+// As for the composite literal, there must be exactly one value per field.
+// Each value must be assignable to the type of its field, as for Set; an
+// invalid Value leaves the field zero. Since a positional literal cannot
+// skip a field, it returns an error if t has unexported fields; use
+// StructLiteralKeyed to set only the exported fields of such a type.
+func StructLiteral(t Type, fieldValues ...Value) (Value, error) {
+	if t == nil {
+		return Value{}, errNilType("StructLiteral")
+	}
+	if t.Kind() != Struct {
+		return Value{}, fmt.Errorf("reflect: StructLiteral of non-struct type %s", t)
+	}
+	if n := t.NumField(); len(fieldValues) != n {
+		return Value{}, fmt.Errorf("reflect: StructLiteral of %s with %d values for %d fields", t, len(fieldValues), n)
+	}
+	fields := structLayout(t, "StructLiteral").fields
+	for i := range fields {
+		if !fields[i].name.isExported() {
+			return Value{}, fmt.Errorf("reflect: StructLiteral of %s would set unexported field %s", t, fields[i].name.name())
+		}
+	}
+	v := value_Elem(New(t))
+	for i, x := range fieldValues {
+		if err := setLiteralField(value_Field(v, i), x, t, fields[i].name.name()); err != nil {
+			return Value{}, err
+		}
+	}
+	return v, nil
+}
+
+// StructLiteralKeyed returns a new value of the struct type t with the
+// fields named by the keys of kv set to the corresponding values, like the
+// composite literal T{Name0: v0, Name1: v1, ...}. Fields not in kv are zero.
+//
+// Unlike the composite literal, a key may also name a field promoted from
+// an embedded struct, in which case nil embedded pointers on the way to it
+// are allocated. Each value must be assignable to the type of its field, as
+// for Set; an invalid Value leaves the field zero. It returns an error if a
+// key does not name an exported field of t, or names a field promoted
+// through a pointer to an unexported embedded struct type.
+func StructLiteralKeyed(t Type, kv map[string]Value) (Value, error) {
+	if t == nil {
+		return Value{}, errNilType("StructLiteralKeyed")
+	}
+	if t.Kind() != Struct {
+		return Value{}, fmt.Errorf("reflect: StructLiteralKeyed of non-struct type %s", t)
+	}
+	// Visit the keys in order so that the reported error does not depend
+	// on map iteration.
+	names := make([]string, 0, len(kv))
+	for name := range kv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	v := value_Elem(New(t))
+	for _, name := range names {
+		index, ok := t.FieldIndexByName(name)
+		if !ok || !isExportedName(name) {
+			return Value{}, fmt.Errorf("reflect: StructLiteralKeyed: no exported field %s in %s", name, t)
+		}
+		f, err := settableFieldByIndex(v, index)
+		if err != nil {
+			return Value{}, fmt.Errorf("reflect: StructLiteralKeyed of %s: field %s is promoted through %v", t, name, err)
+		}
+		if err := setLiteralField(f, kv[name], t, name); err != nil {
+			return Value{}, err
+		}
+	}
+	return v, nil
+}
+
+// setLiteralField sets the field named name of a value of the struct type
+// owner to x, unless x is the zero Value.
+func setLiteralField(f, x Value, owner Type, name string) error {
+	if !x.IsValid() {
+		return nil
+	}
+	if x.flag&flagRO != 0 {
+		return fmt.Errorf("reflect: value for field %s of %s obtained using unexported field", name, owner)
+	}
+	if xt := x.typeOf(); !xt.AssignableTo(f.typ) {
+		return fmt.Errorf("reflect: value of type %s is not assignable to field %s of %s of type %s", xt, name, owner, f.typ)
+	}
+	value_Set(f, x)
+	return nil
+}
@@ -0,0 +1,135 @@
+package reflect_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type LitBase struct {
+	ID int
+}
+
+type LitPoint struct {
+	X, Y int
+	R    io.Reader
+}
+
+type LitOuter struct {
+	*LitBase
+	Name string
+}
+
+type litHidden struct {
+	Z int
+}
+
+type LitFunc struct {
+	F func() int
+}
+
+func (p LitBase) Get() int { return p.ID }
+
+type LitMixed struct {
+	A int
+	b int
+	*litHidden
+}
+
+func TestStructLiteral(t *testing.T) {
+	buf := bytes.NewBufferString("r")
+	v, err := reflect.StructLiteral(reflect.TypeOf(LitPoint{}), reflect.ValueOf(1), reflect.ValueOf(2), reflect.ValueOf(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Interface().(LitPoint); got.X != 1 || got.Y != 2 || got.R != io.Reader(buf) {
+		t.Fatalf("unexpected result %+v", got)
+	}
+	if !v.CanSet() {
+		t.Fatal("StructLiteral result is not settable")
+	}
+
+	v, err = reflect.StructLiteral(reflect.TypeOf(LitPoint{}), reflect.ValueOf(1), reflect.Value{}, reflect.Value{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Interface().(LitPoint); got != (LitPoint{X: 1}) {
+		t.Fatalf("unexpected result %+v", got)
+	}
+
+	// As for Set, a method value is assignable to a field of its func type.
+	v, err = reflect.StructLiteral(reflect.TypeOf(LitFunc{}), reflect.ValueOf(LitBase{7}).Method(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Interface().(LitFunc).F(); got != 7 {
+		t.Fatalf("F() = %d, want 7", got)
+	}
+
+	for _, tc := range []struct {
+		name string
+		typ  reflect.Type
+		vals []reflect.Value
+		want string
+	}{
+		{"count", reflect.TypeOf(LitPoint{}), []reflect.Value{reflect.ValueOf(1)}, "with 1 values for 3 fields"},
+		{"conversion", reflect.TypeOf(LitPoint{}), []reflect.Value{reflect.ValueOf("x"), reflect.ValueOf(2), reflect.Value{}}, "not assignable to field X"},
+		{"unexported", reflect.TypeOf(LitMixed{}), []reflect.Value{reflect.ValueOf(1), reflect.ValueOf(2), reflect.Value{}}, "unexported field b"},
+		{"non-struct", reflect.TypeOf(1), nil, "non-struct type int"},
+		{"nil", nil, nil, "of nil Type"},
+	} {
+		if _, err := reflect.StructLiteral(tc.typ, tc.vals...); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got error %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestStructLiteralKeyed(t *testing.T) {
+	v, err := reflect.StructLiteralKeyed(reflect.TypeOf(LitOuter{}), map[string]reflect.Value{
+		"Name": reflect.ValueOf("n"),
+		"ID":   reflect.ValueOf(7),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := v.Interface().(LitOuter)
+	if got.Name != "n" || got.LitBase == nil || got.ID != 7 {
+		t.Fatalf("unexpected result %+v", got)
+	}
+
+	v, err = reflect.StructLiteralKeyed(reflect.TypeOf(LitOuter{}), map[string]reflect.Value{"Name": reflect.ValueOf("n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Interface().(LitOuter); got.LitBase != nil {
+		t.Fatalf("embedded pointer allocated without a promoted key: %+v", got)
+	}
+
+	v, err = reflect.StructLiteralKeyed(reflect.TypeOf(LitMixed{}), map[string]reflect.Value{"A": reflect.ValueOf(3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Interface().(LitMixed); got.A != 3 {
+		t.Fatalf("unexpected result %+v", got)
+	}
+
+	for _, tc := range []struct {
+		name string
+		typ  reflect.Type
+		kv   map[string]reflect.Value
+		want string
+	}{
+		{"missing", reflect.TypeOf(LitOuter{}), map[string]reflect.Value{"Nope": reflect.ValueOf(1)}, "no exported field Nope"},
+		{"unexported", reflect.TypeOf(LitMixed{}), map[string]reflect.Value{"b": reflect.ValueOf(1)}, "no exported field b"},
+		{"promoted unexported", reflect.TypeOf(LitMixed{}), map[string]reflect.Value{"Z": reflect.ValueOf(1)}, "unexported embedded struct"},
+		{"conversion", reflect.TypeOf(LitOuter{}), map[string]reflect.Value{"ID": reflect.ValueOf("x")}, "not assignable to field ID"},
+		{"non-struct", reflect.TypeOf(""), nil, "non-struct type string"},
+	} {
+		if _, err := reflect.StructLiteralKeyed(tc.typ, tc.kv); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got error %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package reflect
+
+import "fmt"
+
+// A LookupError is returned by Value.FieldByNameErr and
+// Value.MethodByNameErr when there is no field or method of the name.
+type LookupError struct {
+	Lookup string // lookup that failed, "FieldByName" or "MethodByName"
+	Name   string // name that was looked up
+	Type   Type   // type that was searched
+}
+
+func (e *LookupError) Error() string {
+	what := "method"
+	if e.Lookup == "FieldByName" {
+		what = "field"
+	}
+	return fmt.Sprintf("reflect: %s(%q) on %s: no such %s", e.Lookup, e.Name, e.Type, what)
+}
+
+// A NilEmbeddedError is returned by Value.FieldByIndexErr when the index
+// sequence runs through a nil pointer to an embedded struct.
+type NilEmbeddedError struct {
+	Type  Type   // struct type holding the nil embedded pointer
+	Field string // name of the embedded pointer field
+	Depth int    // position in the index sequence of the field
+}
+
+func (e *NilEmbeddedError) Error() string {
+	return fmt.Sprintf("reflect: indirection through nil pointer to embedded struct field %s of %s at depth %d",
+		e.Field, e.Type, e.Depth)
+}
+
+// mustBeValid panics with a ValueError of Kind Invalid if v is the zero
+// Value. Value methods call it before anything else, so that they all
+// report the zero Value the same way, naming themselves as method.
+func (v Value) mustBeValid(method string) {
+	if v.flag == 0 {
+		panicZeroValue(method)
+	}
+}
+
+func panicZeroValue(method string) {
+	panic(zeroValueError(method))
+}
+
+// zeroValueError returns the error mustBeValid panics with.
+func zeroValueError(method string) error {
+	return &ValueError{Method: method, Kind: Invalid}
+}
+
+// mustBeExported panics as reflect does if v was obtained using an
+// unexported field, for methods that would otherwise fail later or in a
+// less obvious way.
+func (v Value) mustBeExported(method string) {
+	if v.flag&flagRO != 0 {
+		panic("reflect: " + method + " using value obtained using unexported field")
+	}
+}
@@ -0,0 +1,73 @@
+package reflect
+
+import (
+	"strconv"
+)
+
+// SetMapEntries sets the element associated with keys[i] in the map v to
+// vals[i] for each i, as SetMapIndex does, but checks the whole batch
+// before inserting anything: it panics without modifying the map if the
+// slices differ in length or if any key or element is not assignable to
+// the map's key or element type. As with SetMapIndex, a zero Value in vals
+// deletes its key.
+//
+// If v holds a nil map and is settable, SetMapEntries first sets it to a
+// new map sized for len(keys) entries. Otherwise it panics for a nil map,
+// as SetMapIndex does.
+func (v Value) SetMapEntries(keys, vals []Value) {
+	v.mustBeValid("reflect.Value.SetMapEntries")
+	if k := v.flag.kind(); k != Map {
+		panic(&ValueError{Method: "reflect.Value.SetMapEntries", Kind: k})
+	}
+	v.mustBeExported("reflect.Value.SetMapEntries")
+	if len(keys) != len(vals) {
+		panic("reflect: SetMapEntries with " + strconv.Itoa(len(keys)) + " keys and " + strconv.Itoa(len(vals)) + " elements")
+	}
+	kt, et := v.typ.Key(), v.typ.Elem()
+	for i := range keys {
+		mustBeMapEntry("key", i, keys[i], kt)
+		if vals[i].flag != 0 {
+			mustBeMapEntry("element", i, vals[i], et)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if value_IsNil(v) && v.CanSet() {
+		value_Set(v, value_MakeMapWithSize(v.typ, len(keys)))
+	}
+	for i := range keys {
+		value_SetMapIndex(v, keys[i], vals[i])
+	}
+	v.traceWrite(WriteSetMapIndex)
+}
+
+// mustBeMapEntry panics if x cannot be stored as the i'th key or element
+// of a map whose key or element type is typ.
+func mustBeMapEntry(what string, i int, x Value, typ Type) {
+	if x.flag == 0 {
+		panic("reflect: SetMapEntries: " + what + " " + strconv.Itoa(i) + " is the zero Value")
+	}
+	x.mustBeExported("reflect.Value.SetMapEntries")
+	if xt := x.typeOf(); xt != typ && !xt.AssignableTo(typ) {
+		panic("reflect: SetMapEntries: " + what + " " + strconv.Itoa(i) + ": " + explainNotAssignable(xt, typ))
+	}
+}
+
+// SetMapIndexAny is like SetMapIndex but takes the key and element as
+// interface values. A nil key or val stands for the zero value of the
+// map's key or element type, so unlike SetMapIndex it never deletes.
+func (v Value) SetMapIndexAny(key, val any) {
+	v.mustBeValid("reflect.Value.SetMapIndexAny")
+	if k := v.flag.kind(); k != Map {
+		panic(&ValueError{Method: "reflect.Value.SetMapIndexAny", Kind: k})
+	}
+	kv, ev := ValueOf(key), ValueOf(val)
+	if key == nil {
+		kv = value_Zero(v.typ.Key())
+	}
+	if val == nil {
+		ev = value_Zero(v.typ.Elem())
+	}
+	v.SetMapIndex(kv, ev)
+}
@@ -0,0 +1,62 @@
+package reflect
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// MapIter is an alias of reflect.MapIter, so the helpers below are functions
+// rather than methods. They read the map Value the iterator was created for,
+// which is the first field of reflect.MapIter.
+
+func mapIterMap(it *MapIter, op string) Value {
+	m := *(*Value)(unsafe.Pointer(it))
+	if m.flag == 0 {
+		panic("reflect: " + op + " called on an iterator that does not have an associated map Value")
+	}
+	return m
+}
+
+// MapIterMap returns the map Value it iterates over.
+// It is available as soon as the iterator is created by MapRange or Reset,
+// and it panics if it is the zero MapIter.
+func MapIterMap(it *MapIter) Value {
+	return mapIterMap(it, "MapIterMap")
+}
+
+// MapIterKeyType returns the key type of the map it iterates over,
+// without requiring a call to Next.
+// It panics if it is the zero MapIter.
+func MapIterKeyType(it *MapIter) Type {
+	return mapIterMap(it, "MapIterKeyType").typ.Key()
+}
+
+// MapIterValueType returns the element type of the map it iterates over,
+// without requiring a call to Next.
+// It panics if it is the zero MapIter.
+func MapIterValueType(it *MapIter) Type {
+	return mapIterMap(it, "MapIterValueType").typ.Elem()
+}
+
+// ToMapIter convert *reflect.MapIter to *MapIter.
+// Because MapIter is an alias of reflect.MapIter, it returns it itself:
+// the two share their position, so iteration may be started with one
+// package and continued with the other. Like any MapIter, the result
+// references the map it was created for and keeps it alive; changes to
+// the map during iteration follow the rules of a for range loop.
+func ToMapIter(it *reflect.MapIter) *MapIter {
+	return it
+}
+
+// ToReflectMapIter convert *MapIter to *reflect.MapIter.
+// It returns it itself; see ToMapIter for the lifetime rules.
+func ToReflectMapIter(it *MapIter) *reflect.MapIter {
+	return it
+}
+
+// MapRangeFromReflect returns a range iterator for the map held by the
+// reflect.Value v. It is equivalent to ToValue(v).MapRange().
+// It panics if v's Kind is not Map.
+func MapRangeFromReflect(v reflect.Value) *MapIter {
+	return toV(v).MapRange()
+}
@@ -0,0 +1,112 @@
+package reflect
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"strconv"
+)
+
+// sortMapEntries sorts entries in place by their keys, as returned by key,
+// which are keys of a map with key type t.
+//
+// Keys of ordered kinds are compared by value. Other comparable keys,
+// such as arrays, structs and interfaces, are compared by a canonical
+// serialization computed once per key; the resulting order carries no
+// meaning but does not depend on the map's iteration order.
+func sortMapEntries[E any](t Type, entries []E, key func(E) Value) {
+	switch t.Kind() {
+	case Int, Int8, Int16, Int32, Int64:
+		slices.SortFunc(entries, func(a, b E) int { return cmp.Compare(value_Int(key(a)), value_Int(key(b))) })
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		slices.SortFunc(entries, func(a, b E) int { return cmp.Compare(value_Uint(key(a)), value_Uint(key(b))) })
+	case Float32, Float64:
+		slices.SortFunc(entries, func(a, b E) int { return cmp.Compare(value_Float(key(a)), value_Float(key(b))) })
+	case String:
+		slices.SortFunc(entries, func(a, b E) int { return cmp.Compare(value_String(key(a)), value_String(key(b))) })
+	case Bool:
+		slices.SortFunc(entries, func(a, b E) int { return cmpBool(value_Bool(key(a)), value_Bool(key(b))) })
+	default:
+		type encodedEntry struct {
+			entry E
+			enc   []byte
+		}
+		encoded := make([]encodedEntry, len(entries))
+		var buf []byte
+		for i, e := range entries {
+			start := len(buf)
+			buf = appendKeyEncoding(buf, key(e))
+			encoded[i] = encodedEntry{entry: e, enc: buf[start:len(buf):len(buf)]}
+		}
+		slices.SortStableFunc(encoded, func(a, b encodedEntry) int { return bytes.Compare(a.enc, b.enc) })
+		for i, e := range encoded {
+			entries[i] = e.entry
+		}
+	}
+}
+
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// appendKeyEncoding appends the canonical serialization of the map key k
+// used to order keys that have no natural order. Struct fields are read
+// without regard to whether they are exported.
+func appendKeyEncoding(b []byte, k Value) []byte {
+	switch k.Kind() {
+	case Bool:
+		return strconv.AppendBool(b, value_Bool(k))
+	case Int, Int8, Int16, Int32, Int64:
+		return strconv.AppendInt(b, value_Int(k), 10)
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		return strconv.AppendUint(b, value_Uint(k), 10)
+	case Float32, Float64:
+		return strconv.AppendFloat(b, value_Float(k), 'g', -1, 64)
+	case Complex64, Complex128:
+		c := value_Complex(k)
+		b = append(b, '(')
+		b = strconv.AppendFloat(b, real(c), 'g', -1, 64)
+		b = append(b, ',')
+		b = strconv.AppendFloat(b, imag(c), 'g', -1, 64)
+		return append(b, ')')
+	case String:
+		return strconv.AppendQuote(b, value_String(k))
+	case Ptr, Chan, UnsafePointer:
+		// Addresses are only stable within a process.
+		return strconv.AppendUint(append(b, "0x"...), uint64(value_Pointer(k)), 16)
+	case Array:
+		b = append(b, '[')
+		for i, n := 0, value_Len(k); i < n; i++ {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = appendKeyEncoding(b, value_Index(k, i))
+		}
+		return append(b, ']')
+	case Struct:
+		b = append(b, '{')
+		for i, n := 0, value_NumField(k); i < n; i++ {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = appendKeyEncoding(b, value_Field(k, i))
+		}
+		return append(b, '}')
+	case Interface:
+		if value_IsNil(k) {
+			return append(b, "nil"...)
+		}
+		e := value_Elem(k)
+		b = append(b, e.Type().String()...)
+		b = append(b, ':')
+		return appendKeyEncoding(b, e)
+	}
+	return b
+}
@@ -0,0 +1,117 @@
+package reflect_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+func dumpSorted(m any) []byte {
+	var buf bytes.Buffer
+	v := reflect.ValueOf(m)
+	for _, k := range v.MapKeysSorted() {
+		fmt.Fprintf(&buf, "%v=%v\n", k.Interface(), v.MapIndex(k).Interface())
+	}
+	return buf.Bytes()
+}
+
+func TestMapKeysSortedComposite(t *testing.T) {
+	arrays := map[[2]int]string{}
+	structs := map[struct{ A, B string }]int{}
+	ifaces := map[any]int{}
+	for i := 0; i < 50; i++ {
+		arrays[[2]int{i % 7, -i}] = fmt.Sprint(i)
+		structs[struct{ A, B string }{fmt.Sprint(i % 5), fmt.Sprint(i)}] = i
+		ifaces[i] = i
+		ifaces[fmt.Sprint(i)] = i
+	}
+	ifaces[nil] = -1
+	for _, m := range []any{arrays, structs, ifaces} {
+		first := dumpSorted(m)
+		for i := 0; i < 10; i++ {
+			if got := dumpSorted(m); !bytes.Equal(got, first) {
+				t.Fatalf("%T: output differs between dumps:\n%s\n%s", m, first, got)
+			}
+		}
+		if n := reflect.ValueOf(m).Len(); bytes.Count(first, []byte("\n")) != n {
+			t.Fatalf("%T: dumped %d keys, want %d", m, bytes.Count(first, []byte("\n")), n)
+		}
+	}
+}
+
+func TestMapKeysSortedOrdered(t *testing.T) {
+	keys := reflect.ValueOf(map[string]int{"b": 2, "a": 1, "c": 3}).MapKeysSorted()
+	if len(keys) != 3 || keys[0].String() != "a" || keys[1].String() != "b" || keys[2].String() != "c" {
+		t.Fatalf("unexpected order %v", keys)
+	}
+	ints := reflect.ValueOf(map[int8]bool{3: true, -1: true, 0: true}).MapKeysSorted()
+	if len(ints) != 3 || ints[0].Int() != -1 || ints[1].Int() != 0 || ints[2].Int() != 3 {
+		t.Fatalf("unexpected order %v", ints)
+	}
+	bools := reflect.ValueOf(map[bool]int{true: 1, false: 0}).MapKeysSorted()
+	if len(bools) != 2 || bools[0].Bool() || !bools[1].Bool() {
+		t.Fatalf("unexpected order %v", bools)
+	}
+}
+
+func TestMapRangeSorted(t *testing.T) {
+	dump := func(m any) string {
+		var buf bytes.Buffer
+		reflect.ValueOf(m).MapRangeSorted(func(k, v reflect.Value) bool {
+			fmt.Fprintf(&buf, "%v=%v;", k.Interface(), v.Interface())
+			return true
+		})
+		return buf.String()
+	}
+	strs := map[string]int{"b": 2, "a": 1, "c": 3, "": 0}
+	set := map[int]struct{}{}
+	for i := 10; i > -10; i -= 3 {
+		set[i] = struct{}{}
+	}
+	for _, tc := range []struct {
+		m    any
+		want string
+	}{
+		{strs, "=0;a=1;b=2;c=3;"},
+		{set, "-8={};-5={};-2={};1={};4={};7={};10={};"},
+		{map[string]int(nil), ""},
+	} {
+		for i := 0; i < 10; i++ {
+			if got := dump(tc.m); got != tc.want {
+				t.Fatalf("%T: run %d gave %q, want %q", tc.m, i, got, tc.want)
+			}
+		}
+	}
+
+	// Stopping early, and deleting entries not yet reached.
+	var seen []string
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	reflect.ValueOf(m).MapRangeSorted(func(k, v reflect.Value) bool {
+		seen = append(seen, k.String())
+		delete(m, "b")
+		return k.String() != "c"
+	})
+	if got := fmt.Sprint(seen); got != "[a c]" {
+		t.Errorf("visited %s, want [a c]", got)
+	}
+
+	// NaN keys cannot be looked up, but their entries are still visited.
+	nans := map[float64]int{math.NaN(): 1, math.NaN(): 2, 0: 4}
+	sum := 0
+	reflect.ValueOf(nans).MapRangeSorted(func(k, v reflect.Value) bool {
+		if v.Int() == 4 && k.Float() != 0 || v.Int() != 4 && !math.IsNaN(k.Float()) {
+			t.Errorf("visited %v=%v", k, v)
+		}
+		sum += int(v.Int())
+		return true
+	})
+	if sum != 7 {
+		t.Errorf("visited entries with values summing to %d, want 7", sum)
+	}
+	if s, want := reflect.Stringify(reflect.ValueOf(map[float64]int{math.NaN(): 1})), "map[float64]int{NaN: 1}"; s != want {
+		t.Errorf("Stringify(map with NaN key) = %q, want %q", s, want)
+	}
+}
@@ -0,0 +1,33 @@
+package reflect
+
+// A BoundMethod is an exported method of a value with the receiver bound,
+// as returned by BoundMethods.
+type BoundMethod struct {
+	Name  string
+	Func  Value // method value, as returned by Value.Method(Index)
+	Type  Type  // signature of Func, without the receiver
+	Index int   // index for Value.Method and Type.Method
+}
+
+// BoundMethods returns the exported methods of recv in the order of
+// Value.Method, each bound to recv. recv may have a value or pointer
+// receiver type, whose method set determines the methods, or be an
+// interface, whose methods are bound to its dynamic value.
+// It panics if recv is the zero Value or a nil interface.
+func BoundMethods(recv Value) []BoundMethod {
+	recv.mustBeValid("reflect.BoundMethods")
+	if recv.flag.kind() == Interface && value_IsNil(recv) {
+		panic("reflect: BoundMethods of nil interface value")
+	}
+	n := type_NumMethod(recv.typ)
+	methods := make([]BoundMethod, 0, n)
+	for i := 0; i < n; i++ {
+		m := type_Method(recv.typ, i)
+		if m.PkgPath != "" {
+			continue
+		}
+		f := value_Method(recv, i)
+		methods = append(methods, BoundMethod{Name: m.Name, Func: f, Type: value_Type(f), Index: i})
+	}
+	return methods
+}
@@ -0,0 +1,100 @@
+package reflect
+
+import (
+	"unsafe"
+)
+
+// IsNamed reports whether t is a named type, that is, a defined type or a
+// predeclared one such as int or error, as opposed to a type literal such
+// as []int or struct{}. An alias denotes the type it stands for, so an
+// alias for a type literal is not named.
+func (t *rtype) IsNamed() bool {
+	return t.Name() != ""
+}
+
+// IsPredeclared reports whether t is one of the types predeclared by the
+// language, such as int, string or error.
+func (t *rtype) IsPredeclared() bool {
+	return t.Name() != "" && t.PkgPath() == "" && t.Kind() != UnsafePointer
+}
+
+var underlyingCache typeCache[Type]
+
+// Underlying returns the underlying type of t, as defined by the language
+// and reported by go/types: the predeclared type of the same kind for a
+// named boolean, numeric or string type, the equivalent type literal for
+// other named types, and t itself for predeclared types and type literals.
+//
+// Underlying builds type literals with the functions of this package and
+// shares their limitations: it panics for a named struct type that
+// StructOf cannot construct, and for a named interface type with methods,
+// as no function can construct interface types.
+func Underlying(t Type) Type {
+	mustBeNonNilType(t, "Underlying")
+	if !t.IsNamed() || t.IsPredeclared() {
+		return t
+	}
+	return underlyingCache.get(t, buildUnderlying)
+}
+
+func buildUnderlying(t Type) Type {
+	switch k := t.Kind(); k {
+	case Array:
+		return ArrayOf(t.Len(), t.Elem())
+	case Chan:
+		return ChanOf(t.ChanDir(), t.Elem())
+	case Func:
+		in := make([]Type, t.NumIn())
+		for i := range in {
+			in[i] = t.In(i)
+		}
+		out := make([]Type, t.NumOut())
+		for i := range out {
+			out[i] = t.Out(i)
+		}
+		return FuncOf(in, out, t.IsVariadic())
+	case Interface:
+		if t.NumMethod() == 0 {
+			return TypeFor[any]()
+		}
+		panic("reflect.Underlying: cannot construct the underlying type of interface type " + t.String())
+	case Map:
+		return MapOf(t.Key(), t.Elem())
+	case Ptr:
+		return PtrTo(t.Elem())
+	case Slice:
+		return SliceOf(t.Elem())
+	case Struct:
+		fields := make([]StructField, t.NumField())
+		for i := range fields {
+			fields[i] = t.Field(i)
+		}
+		return StructOf(fields)
+	case UnsafePointer:
+		return TypeFor[unsafe.Pointer]()
+	default:
+		return predeclaredTypes[k]
+	}
+}
+
+// predeclaredTypes maps each boolean, numeric and string Kind to its
+// predeclared type.
+var predeclaredTypes = [...]Type{
+	Bool:       TypeFor[bool](),
+	Int:        TypeFor[int](),
+	Int8:       TypeFor[int8](),
+	Int16:      TypeFor[int16](),
+	Int32:      TypeFor[int32](),
+	Int64:      TypeFor[int64](),
+	Uint:       TypeFor[uint](),
+	Uint8:      TypeFor[uint8](),
+	Uint16:     TypeFor[uint16](),
+	Uint32:     TypeFor[uint32](),
+	Uint64:     TypeFor[uint64](),
+	Uintptr:    TypeFor[uintptr](),
+	Float32:    TypeFor[float32](),
+	Float64:    TypeFor[float64](),
+	Complex64:  TypeFor[complex64](),
+	Complex128: TypeFor[complex128](),
+	String:     TypeFor[string](),
+}
@@ -0,0 +1,71 @@
+package reflect_test
+
+import (
+	"io"
+	"testing"
+	"unsafe"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type (
+	namedInt     int
+	namedOfNamed namedInt
+	namedPtr     unsafe.Pointer
+	namedSlice   []namedInt
+	namedFunc    func(string, ...int) error
+	namedEmpty   interface{}
+	namedStruct  struct {
+		A      int `json:"a"`
+		B      namedSlice
+		hidden string
+	}
+	aliasedSlice = []int
+	aliasedInt   = namedInt
+)
+
+func TestNamedTypes(t *testing.T) {
+	for _, tc := range []struct {
+		typ                reflect.Type
+		named, predeclared bool
+		underlying         reflect.Type
+	}{
+		{reflect.TypeFor[int](), true, true, reflect.TypeFor[int]()},
+		{reflect.TypeFor[error](), true, true, reflect.TypeFor[error]()},
+		{reflect.TypeFor[any](), false, false, reflect.TypeFor[any]()},
+		{reflect.TypeFor[unsafe.Pointer](), true, false, reflect.TypeFor[unsafe.Pointer]()},
+		{reflect.TypeFor[MyString](), true, false, reflect.TypeFor[string]()},
+		{reflect.TypeFor[namedInt](), true, false, reflect.TypeFor[int]()},
+		{reflect.TypeFor[aliasedInt](), true, false, reflect.TypeFor[int]()},
+		{reflect.TypeFor[namedOfNamed](), true, false, reflect.TypeFor[int]()},
+		{reflect.TypeFor[namedPtr](), true, false, reflect.TypeFor[unsafe.Pointer]()},
+		{reflect.TypeFor[namedSlice](), true, false, reflect.TypeFor[[]namedInt]()},
+		{reflect.TypeFor[namedFunc](), true, false, reflect.TypeFor[func(string, ...int) error]()},
+		{reflect.TypeFor[namedEmpty](), true, false, reflect.TypeFor[any]()},
+		{reflect.TypeFor[namedStruct](), true, false, reflect.TypeFor[struct {
+			A      int `json:"a"`
+			B      namedSlice
+			hidden string
+		}]()},
+		{reflect.TypeFor[aliasedSlice](), false, false, reflect.TypeFor[[]int]()},
+		{reflect.TypeFor[map[string]namedInt](), false, false, reflect.TypeFor[map[string]namedInt]()},
+		{reflect.TypeFor[*namedStruct](), false, false, reflect.TypeFor[*namedStruct]()},
+	} {
+		if got := tc.typ.IsNamed(); got != tc.named {
+			t.Errorf("%v.IsNamed() = %v, want %v", tc.typ, got, tc.named)
+		}
+		if got := tc.typ.IsPredeclared(); got != tc.predeclared {
+			t.Errorf("%v.IsPredeclared() = %v, want %v", tc.typ, got, tc.predeclared)
+		}
+		if got := reflect.Underlying(tc.typ); got != tc.underlying {
+			t.Errorf("Underlying(%v) = %v, want %v", tc.typ, got, tc.underlying)
+		}
+	}
+
+	// Types declared in functions are named too.
+	type local struct{ X int }
+	if typ := reflect.TypeFor[local](); !typ.IsNamed() || typ.IsPredeclared() {
+		t.Errorf("local type: IsNamed %v, IsPredeclared %v", typ.IsNamed(), typ.IsPredeclared())
+	}
+	shouldPanic(func() { reflect.Underlying(reflect.TypeFor[io.Reader]()) })
+}
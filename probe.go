@@ -0,0 +1,111 @@
+package reflect
+
+// The probes in this file report whether an operation would panic by
+// inspecting flags and type metadata, so that walkers need not attempt the
+// operation under a deferred recover. None of them panics, not even for
+// the zero Value.
+
+// CanInterfaceDeep reports whether Interface can be called on v and on
+// every value a walker reaches from it through elements, map keys and
+// entries, pointers and struct fields, that is, whether v.CanInterface()
+// and no struct type reachable from v's type has unexported fields.
+// The dynamic values of interfaces within v are not looked into.
+func CanInterfaceDeep(v Value) bool {
+	if v.flag == 0 || v.flag&flagRO != 0 {
+		return false
+	}
+	return !unexportedFieldsCache.get(v.typ, buildHasUnexportedFields)
+}
+
+var unexportedFieldsCache typeCache[bool]
+
+func buildHasUnexportedFields(t Type) bool {
+	return hasUnexportedFields(t, map[Type]bool{})
+}
+
+func hasUnexportedFields(t Type, seen map[Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case Array, Chan, Ptr, Slice:
+		return hasUnexportedFields(t.Elem(), seen)
+	case Map:
+		return hasUnexportedFields(t.Key(), seen) || hasUnexportedFields(t.Elem(), seen)
+	case Struct:
+		for i, n := 0, t.NumField(); i < n; i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" || hasUnexportedFields(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanCompare reports whether comparing v's value with == would succeed
+// rather than panic. Unlike Type.Comparable, it takes the dynamic values of
+// interfaces within v into account: an interface holding a slice, or an
+// array or struct containing one, cannot be compared.
+func CanCompare(v Value) bool {
+	switch v.flag.kind() {
+	case Invalid, Func, Map, Slice:
+		return false
+	case Interface:
+		e := value_Elem(v)
+		return e.flag == 0 || CanCompare(e)
+	case Array:
+		if !v.typ.Comparable() {
+			return false
+		}
+		if !containsInterface(v.typ) {
+			return true
+		}
+		for i, n := 0, value_Len(v); i < n; i++ {
+			if !CanCompare(value_Index(v, i)) {
+				return false
+			}
+		}
+		return true
+	case Struct:
+		if !v.typ.Comparable() {
+			return false
+		}
+		if !containsInterface(v.typ) {
+			return true
+		}
+		for i, n := 0, v.typ.NumField(); i < n; i++ {
+			if !CanCompare(value_Field(v, i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// CanHash reports whether v's value can be used as a map key without
+// panicking. As every comparable Go value is hashable, this is the same
+// as CanCompare; it exists to state the intent at the call site.
+func CanHash(v Value) bool {
+	return CanCompare(v)
+}
+
+// containsInterface reports whether values of the array or struct type t
+// hold interfaces directly, that is, not behind a pointer.
+func containsInterface(t Type) bool {
+	switch t.Kind() {
+	case Interface:
+		return true
+	case Array:
+		return containsInterface(t.Elem())
+	case Struct:
+		for i, n := 0, t.NumField(); i < n; i++ {
+			if containsInterface(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package reflect_test
+
+import (
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type probeInner struct {
+	Name   string
+	hidden int
+}
+
+type probeOuter struct {
+	List  []probeInner
+	Inner *probeInner
+}
+
+type probeExported struct {
+	Next *probeExported
+	M    map[string][]int
+	Any  any
+}
+
+func TestCanCompare(t *testing.T) {
+	values := []any{
+		1, "x", 1.5, struct{}{}, [2]any{1, "x"}, [2]any{1, []int{}},
+		struct{ A any }{A: map[int]int{}}, struct{ A any }{A: 1},
+		[]int{}, map[int]int{}, func() {}, new(int), make(chan int),
+		[1]struct{ A [1]any }{{[1]any{func() {}}}},
+	}
+	for _, p := range valueTests {
+		values = append(values, reflect.ValueOf(p.i).Elem().Interface())
+	}
+	for _, x := range values {
+		v := reflect.ValueOf(x)
+		cmp := panicOf(func() { _ = v.Interface() == v.Interface() }) == nil
+		if got := reflect.CanCompare(v); got != cmp {
+			t.Errorf("CanCompare(%T) = %v, want %v", x, got, cmp)
+		}
+		hash := panicOf(func() { _ = map[any]bool{v.Interface(): true} }) == nil
+		if got := reflect.CanHash(v); got != hash {
+			t.Errorf("CanHash(%T) = %v, want %v", x, got, hash)
+		}
+	}
+	// Interface-typed Values are probed through their dynamic values.
+	s := []any{nil, []int{}, 1}
+	for i, want := range []bool{true, false, true} {
+		if got := reflect.CanCompare(reflect.ValueOf(s).Index(i)); got != want {
+			t.Errorf("CanCompare(%#v) = %v, want %v", s[i], got, want)
+		}
+	}
+	if reflect.CanCompare(reflect.Value{}) || reflect.CanHash(reflect.Value{}) {
+		t.Error("the zero Value can be compared")
+	}
+}
+
+func TestCanInterfaceDeep(t *testing.T) {
+	// walk calls Interface on every value reachable from v and reports
+	// whether any of the calls panicked.
+	var walk func(v reflect.Value, seen map[reflect.Type]bool) bool
+	walk = func(v reflect.Value, seen map[reflect.Type]bool) bool {
+		if panicOf(func() { v.Interface() }) != nil {
+			return true
+		}
+		if seen[v.Type()] {
+			return false
+		}
+		seen[v.Type()] = true
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+			return walk(reflect.New(v.Type().Elem()).Elem(), seen)
+		case reflect.Map:
+			return walk(reflect.New(v.Type().Key()).Elem(), seen) || walk(reflect.New(v.Type().Elem()).Elem(), seen)
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				if walk(v.Field(i), seen) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	values := []reflect.Value{
+		reflect.ValueOf(1),
+		reflect.ValueOf(probeInner{}),
+		reflect.ValueOf(probeOuter{}),
+		reflect.ValueOf(probeExported{}),
+		reflect.ValueOf(map[string]*probeOuter{}),
+		reflect.ValueOf(probeInner{}).Field(1),
+	}
+	for _, p := range valueTests {
+		values = append(values, reflect.ValueOf(p.i).Elem())
+	}
+	for _, v := range values {
+		want := !walk(v, map[reflect.Type]bool{})
+		if got := reflect.CanInterfaceDeep(v); got != want {
+			t.Errorf("CanInterfaceDeep(%v) = %v, want %v", v.Type(), got, want)
+		}
+	}
+	if reflect.CanInterfaceDeep(reflect.Value{}) {
+		t.Error("CanInterfaceDeep of the zero Value is true")
+	}
+}
@@ -0,0 +1,69 @@
+package reflect
+
+import "unsafe"
+
+// RawRangeSlice calls fn with the index and the address of each element of
+// the slice or addressable array v, in order, until fn returns false. No
+// Value is created: the element addresses are computed from the address of
+// the backing array and the element size, for loops where even reused
+// Values are too costly.
+//
+// The addresses point into the backing array, so writes through them are
+// visible through v and any other slice sharing it. They must only be
+// used as pointers to v's element type, and stay valid as long as the
+// backing array is reachable. Nothing prevents writing through the
+// addresses of elements of a value obtained using unexported fields.
+//
+// It panics if v's Kind is not Slice or Array, or if v is an array that is
+// not addressable, since its elements would be those of a copy.
+func RawRangeSlice(v Value, fn func(i int, elem unsafe.Pointer) bool) {
+	var base unsafe.Pointer
+	var n int
+	switch k := v.flag.kind(); k {
+	case Slice:
+		s := *(*[]byte)(v.data())
+		base, n = unsafe.Pointer(unsafe.SliceData(s)), len(s)
+	case Array:
+		if v.flag&flagAddr == 0 {
+			panic("reflect: RawRangeSlice of unaddressable array")
+		}
+		base, n = v.ptr, v.typ.Len()
+	default:
+		panic(&ValueError{Method: "reflect.RawRangeSlice", Kind: k})
+	}
+	size := v.typ.Elem().Size()
+	for i := 0; i < n; i++ {
+		if !fn(i, unsafe.Add(base, uintptr(i)*size)) {
+			return
+		}
+	}
+}
+
+// RawRangeMap calls fn with the addresses of the key and element of each
+// entry of the map v, in unspecified order, until fn returns false. No
+// Value is created and nothing is copied: the addresses point into the
+// map's storage.
+//
+// The addresses are only valid during the call to fn, and must only be
+// used as pointers to v's key and element types. fn may write through the
+// element address to update the entry, but must not write through the key
+// address and must not insert into or delete from v. Nothing prevents
+// writing through the addresses of entries of a value obtained using
+// unexported fields.
+//
+// It panics if v's Kind is not Map.
+func RawRangeMap(v Value, fn func(key, elem unsafe.Pointer) bool) {
+	if k := v.flag.kind(); k != Map {
+		panic(&ValueError{Method: "reflect.RawRangeMap", Kind: k})
+	}
+	m := v.pointer()
+	if m == nil {
+		return
+	}
+	var it hiter
+	for mapiterinit(v.typ, m, &it); it.key != nil; mapiternext(&it) {
+		if !fn(it.key, it.elem) {
+			return
+		}
+	}
+}
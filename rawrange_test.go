@@ -0,0 +1,159 @@
+package reflect_test
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type rawPoint struct {
+	X, Y int32
+}
+
+func TestRawRangeSlice(t *testing.T) {
+	s := []rawPoint{{1, 2}, {3, 4}, {5, 6}}
+	reflect.RawRangeSlice(reflect.ValueOf(s), func(i int, elem unsafe.Pointer) bool {
+		p := (*rawPoint)(elem)
+		if *p != s[i] {
+			t.Errorf("element %d = %v, want %v", i, *p, s[i])
+		}
+		p.X *= 10
+		return true
+	})
+	if s[0].X != 10 || s[1].X != 30 || s[2].X != 50 {
+		t.Errorf("writes through element pointers not visible: %v", s)
+	}
+
+	// A subslice starts at its own first element.
+	var seen []int32
+	reflect.RawRangeSlice(reflect.ValueOf(s[1:]), func(i int, elem unsafe.Pointer) bool {
+		seen = append(seen, (*rawPoint)(elem).Y)
+		return i < 0
+	})
+	if len(seen) != 1 || seen[0] != 4 {
+		t.Errorf("stopping after the first element of s[1:] saw %v, want [4]", seen)
+	}
+
+	a := [3]string{"a", "b", "c"}
+	reflect.RawRangeSlice(reflect.ValueOf(&a).Elem(), func(i int, elem unsafe.Pointer) bool {
+		*(*string)(elem) += "!"
+		return true
+	})
+	if a != [3]string{"a!", "b!", "c!"} {
+		t.Errorf("writes through array element pointers not visible: %v", a)
+	}
+
+	n := 0
+	reflect.RawRangeSlice(reflect.ValueOf([]struct{}{{}, {}}), func(int, unsafe.Pointer) bool { n++; return true })
+	reflect.RawRangeSlice(reflect.ValueOf([]int(nil)), func(int, unsafe.Pointer) bool { n++; return true })
+	if n != 2 {
+		t.Errorf("ranged over %d elements, want 2", n)
+	}
+
+	shouldPanic(func() { reflect.RawRangeSlice(reflect.ValueOf(a), nil) })
+	shouldPanic(func() { reflect.RawRangeSlice(reflect.ValueOf(map[int]int{}), nil) })
+}
+
+func TestRawRangeMap(t *testing.T) {
+	m := map[string]rawPoint{"a": {1, 2}, "b": {3, 4}}
+	for i := 0; i < 100; i++ {
+		m[string(rune('c'+i))] = rawPoint{int32(i), 0}
+	}
+	seen := make(map[string]bool)
+	reflect.RawRangeMap(reflect.ValueOf(m), func(key, elem unsafe.Pointer) bool {
+		k := *(*string)(key)
+		if seen[k] {
+			t.Errorf("key %q seen twice", k)
+		}
+		seen[k] = true
+		if p := (*rawPoint)(elem); *p != m[k] {
+			t.Errorf("element of %q = %v, want %v", k, *p, m[k])
+		}
+		(*rawPoint)(elem).Y = 7
+		return true
+	})
+	if len(seen) != len(m) {
+		t.Errorf("ranged over %d entries, want %d", len(seen), len(m))
+	}
+	for k, p := range m {
+		if p.Y != 7 {
+			t.Errorf("write through element pointer of %q not visible: %v", k, p)
+		}
+	}
+
+	// Keys and elements larger than 128 bytes are stored indirectly.
+	type big [200]byte
+	bm := map[big]big{{1}: {2}}
+	reflect.RawRangeMap(reflect.ValueOf(bm), func(key, elem unsafe.Pointer) bool {
+		if (*big)(key)[0] != 1 || (*big)(elem)[0] != 2 {
+			t.Errorf("indirect entry = %v: %v", (*big)(key)[0], (*big)(elem)[0])
+		}
+		return true
+	})
+
+	n := 0
+	reflect.RawRangeMap(reflect.ValueOf(m), func(key, elem unsafe.Pointer) bool { n++; return false })
+	reflect.RawRangeMap(reflect.ValueOf(map[int]int(nil)), func(key, elem unsafe.Pointer) bool { n++; return true })
+	if n != 1 {
+		t.Errorf("ranged over %d entries, want 1", n)
+	}
+
+	shouldPanic(func() { reflect.RawRangeMap(reflect.ValueOf([]int{}), nil) })
+}
+
+func BenchmarkRawRangeSlice(b *testing.B) {
+	s := make([]rawPoint, 1<<16)
+	v := reflect.ValueOf(s)
+	b.Run("RawRangeSlice", func(b *testing.B) {
+		var sum int32
+		for i := 0; i < b.N; i++ {
+			reflect.RawRangeSlice(v, func(_ int, elem unsafe.Pointer) bool {
+				sum += (*rawPoint)(elem).X
+				return true
+			})
+		}
+	})
+	b.Run("Index", func(b *testing.B) {
+		var sum int64
+		for i := 0; i < b.N; i++ {
+			for j, n := 0, v.Len(); j < n; j++ {
+				sum += v.Index(j).Field(0).Int()
+			}
+		}
+	})
+}
+
+func BenchmarkRawRangeMap(b *testing.B) {
+	m := make(map[int32]rawPoint, 1<<14)
+	for i := int32(0); i < 1<<14; i++ {
+		m[i] = rawPoint{i, i}
+	}
+	v := reflect.ValueOf(m)
+	b.Run("RawRangeMap", func(b *testing.B) {
+		var sum int32
+		for i := 0; i < b.N; i++ {
+			reflect.RawRangeMap(v, func(_, elem unsafe.Pointer) bool {
+				sum += (*rawPoint)(elem).X
+				return true
+			})
+		}
+	})
+	b.Run("RangeMap", func(b *testing.B) {
+		var sum int64
+		for i := 0; i < b.N; i++ {
+			v.RangeMap(func(_, val reflect.Value) bool {
+				sum += val.Field(0).Int()
+				return true
+			})
+		}
+	})
+	b.Run("MapRange", func(b *testing.B) {
+		var sum int64
+		for i := 0; i < b.N; i++ {
+			for it := v.MapRange(); it.Next(); {
+				sum += it.Value().Field(0).Int()
+			}
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package reflect
+
+// Reduce calls fn for each element of the slice or array container in
+// order, passing the result of the previous call, or seed for the first
+// element, as acc, and returns the result of the last call. If container is
+// empty or a nil slice, Reduce returns seed unchanged.
+// It panics if container's Kind is not Slice or Array.
+//
+// The elements are passed as obtained by Index, so iterating allocates
+// nothing beyond what fn allocates.
+func Reduce(container Value, seed Value, fn func(acc, elem Value) Value) Value {
+	if k := container.flag.kind(); k != Slice && k != Array {
+		panic(&ValueError{Method: "reflect.Reduce", Kind: k})
+	}
+	acc := seed
+	for i, n := 0, value_Len(container); i < n; i++ {
+		acc = fn(acc, value_Index(container, i))
+	}
+	return acc
+}
+
+// ReduceMap calls fn for each entry of the map m in unspecified order,
+// passing the result of the previous call, or seed for the first entry, as
+// acc, and returns the result of the last call. If m is empty or nil,
+// ReduceMap returns seed unchanged.
+// It panics if m's Kind is not Map.
+//
+// As with RangeMap, k and v are scratch Values reused for every entry:
+// they are only valid during the call and must not be retained or
+// returned as the accumulator.
+func ReduceMap(m Value, seed Value, fn func(acc, k, v Value) Value) Value {
+	if kind := m.flag.kind(); kind != Map {
+		panic(&ValueError{Method: "reflect.ReduceMap", Kind: kind})
+	}
+	acc := seed
+	if value_Len(m) == 0 {
+		return acc
+	}
+	value_RangeMap(m, func(k, v Value) bool {
+		acc = fn(acc, k, v)
+		return true
+	})
+	return acc
+}
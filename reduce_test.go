@@ -0,0 +1,110 @@
+package reflect_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+func sumInt64(acc, elem reflect.Value) reflect.Value {
+	acc.SetInt(acc.Int() + elem.Int())
+	return acc
+}
+
+func TestReduce(t *testing.T) {
+	s := []int64{1, 2, 3, 4}
+	sum := reflect.Reduce(reflect.ValueOf(s), reflect.New(reflect.TypeOf(int64(0))).Elem(), sumInt64)
+	if got := sum.Int(); got != 10 {
+		t.Errorf("Reduce sum = %d, want 10", got)
+	}
+	arr := [3]int64{5, 6, 7}
+	if got := reflect.Reduce(reflect.ValueOf(arr), reflect.New(reflect.TypeOf(int64(0))).Elem(), sumInt64).Int(); got != 18 {
+		t.Errorf("Reduce sum of array = %d, want 18", got)
+	}
+
+	seed := reflect.ValueOf("seed")
+	called := false
+	for _, c := range []any{[]int64(nil), []int64{}, [0]int64{}} {
+		got := reflect.Reduce(reflect.ValueOf(c), seed, func(acc, elem reflect.Value) reflect.Value {
+			called = true
+			return acc
+		})
+		if got != seed {
+			t.Errorf("Reduce(%#v) = %v, want seed", c, got)
+		}
+	}
+	if called {
+		t.Errorf("Reduce called fn for an empty container")
+	}
+
+	if !reflect.CrossCheckEnabled() {
+		v, acc := reflect.ValueOf(s), reflect.New(reflect.TypeOf(int64(0))).Elem()
+		if n := testing.AllocsPerRun(10, func() { reflect.Reduce(v, acc, sumInt64) }); n > 0 {
+			t.Errorf("Reduce allocates %v times, want 0", n)
+		}
+	}
+
+	shouldPanic(func() { reflect.Reduce(reflect.ValueOf(1), seed, sumInt64) })
+}
+
+func TestReduceMap(t *testing.T) {
+	m := map[int]string{1: "a", 2: "b", 3: "c"}
+	got := reflect.ReduceMap(reflect.ValueOf(m), reflect.ValueOf([]string(nil)), func(acc, k, v reflect.Value) reflect.Value {
+		return reflect.Append(acc, v)
+	}).Interface().([]string)
+	sort.Strings(got)
+	if s := strings.Join(got, ""); s != "abc" {
+		t.Errorf("ReduceMap concatenation = %q, want %q", s, "abc")
+	}
+
+	keys := reflect.ReduceMap(reflect.ValueOf(m), reflect.New(reflect.TypeOf(0)).Elem(), func(acc, k, v reflect.Value) reflect.Value {
+		if k.Int() > 1 {
+			acc.SetInt(acc.Int() + k.Int())
+		}
+		return acc
+	})
+	if keys.Int() != 5 {
+		t.Errorf("ReduceMap sum of keys > 1 = %d, want 5", keys.Int())
+	}
+
+	seed := reflect.ValueOf(0)
+	for _, c := range []any{map[int]string(nil), map[int]string{}} {
+		got := reflect.ReduceMap(reflect.ValueOf(c), seed, func(acc, k, v reflect.Value) reflect.Value {
+			t.Errorf("ReduceMap(%#v) called fn", c)
+			return acc
+		})
+		if got != seed {
+			t.Errorf("ReduceMap(%#v) = %v, want seed", c, got)
+		}
+	}
+
+	shouldPanic(func() { reflect.ReduceMap(reflect.ValueOf([]int{}), seed, nil) })
+}
+
+func BenchmarkReduce(b *testing.B) {
+	s := make([]int64, 1000)
+	for i := range s {
+		s[i] = int64(i)
+	}
+	v := reflect.ValueOf(s)
+	b.Run("Reduce", func(b *testing.B) {
+		acc := reflect.New(reflect.TypeOf(int64(0))).Elem()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			acc.SetInt(0)
+			reflect.Reduce(v, acc, sumInt64)
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var sum int64
+			for j := 0; j < v.Len(); j++ {
+				sum += v.Index(j).Int()
+			}
+			_ = sum
+		}
+	})
+}
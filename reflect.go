@@ -1,7 +1,11 @@
 package reflect
 
 import (
+	"errors"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"unsafe"
 )
 
@@ -208,15 +212,20 @@ func (f StructField) IsExported() bool {
 // If the resulting type would be larger than the available address space,
 // ArrayOf panics.
 func ArrayOf(count int, elem Type) Type {
+	mustBeNonNilType(elem, "ArrayOf")
 	return arrayOf(count, elem)
 }
 
 // ChanOf returns the channel type with the given direction and element type.
 // For example, if t represents int, ChanOf(RecvDir, t) represents <-chan int.
+// The result is the same type reflect.ChanOf returns for the corresponding
+// arguments, so it keeps its identity and direction when bridged with ToRT
+// and ToT, as do channel Values converted on either side.
 //
 // The gc runtime imposes a limit of 64 kB on channel element types.
 // If t's size is equal to or exceeds this limit, ChanOf panics.
 func ChanOf(dir ChanDir, t Type) Type {
+	mustBeNonNilType(t, "ChanOf")
 	return chanOf(dir, t)
 }
 
@@ -227,10 +236,54 @@ func ChanOf(dir ChanDir, t Type) Type {
 // The variadic argument controls whether the function is variadic. FuncOf
 // panics if the in[len(in)-1] does not represent a slice and variadic is
 // true.
+//
+// FuncOf returns the same Type for the same signature, which is also the
+// Type of statically declared functions of that signature. Repeated calls
+// find it in a cache without allocating.
 func FuncOf(in, out []Type, variadic bool) Type {
+	for _, t := range in {
+		mustBeNonNilType(t, "FuncOf")
+	}
+	for _, t := range out {
+		mustBeNonNilType(t, "FuncOf")
+	}
 	return funcOf(in, out, variadic)
 }
 
+// FuncOf1 returns the type func(I) O.
+func FuncOf1[I, O any]() Type {
+	return TypeFor[func(I) O]()
+}
+
+// HandlerFuncOf returns the function type with the given argument types
+// and no results, or a single error result if errOut is true.
+// For example, if k represents int, HandlerFuncOf([]Type{k}, true)
+// represents func(int) error.
+func HandlerFuncOf(in []Type, errOut bool) Type {
+	for _, t := range in {
+		mustBeNonNilType(t, "HandlerFuncOf")
+	}
+	if !errOut {
+		return FuncOf(in, nil, false)
+	}
+	return FuncOf(in, []Type{TypeFor[error]()}, false)
+}
+
+// VariadicFuncOf returns the variadic function type with the argument
+// types fixed followed by ...variadicElem, and the given result types.
+// For example, if s represents string and k represents int,
+// VariadicFuncOf([]Type{s}, k, nil) represents func(string, ...int).
+func VariadicFuncOf(fixed []Type, variadicElem Type, out []Type) Type {
+	for _, t := range fixed {
+		mustBeNonNilType(t, "VariadicFuncOf")
+	}
+	mustBeNonNilType(variadicElem, "VariadicFuncOf")
+	in := make([]Type, len(fixed)+1)
+	copy(in, fixed)
+	in[len(fixed)] = sliceOf(variadicElem)
+	return FuncOf(in, out, true)
+}
+
 // MapOf returns the map type with the given key and element types.
 // For example, if k represents int and e represents string,
 // MapOf(k, e) represents map[int]string.
@@ -238,18 +291,22 @@ func FuncOf(in, out []Type, variadic bool) Type {
 // If the key type is not a valid map key type (that is, if it does
 // not implement Go's == operator), MapOf panics.
 func MapOf(key, elem Type) Type {
+	mustBeNonNilType(key, "MapOf")
+	mustBeNonNilType(elem, "MapOf")
 	return mapOf(key, elem)
 }
 
 // PtrTo returns the pointer type with element t.
 // For example, if t represents type Foo, PtrTo(t) represents *Foo.
 func PtrTo(t Type) Type {
+	mustBeNonNilType(t, "PtrTo")
 	return ptrTo(t)
 }
 
 // SliceOf returns the slice type with element type t.
 // For example, if t represents int, SliceOf(t) represents []int.
 func SliceOf(t Type) Type {
+	mustBeNonNilType(t, "SliceOf")
 	return sliceOf(t)
 }
 
@@ -257,10 +314,30 @@ func SliceOf(t Type) Type {
 // The Offset and Index fields are ignored and computed as they would be
 // by the compiler.
 //
-// StructOf currently does not generate wrapper methods for embedded
-// fields and panics if passed unexported StructFields.
-// These limitations may be lifted in a future version.
+// To create an unexported field, set its PkgPath to the path of the package
+// it belongs to, as StructField reports for unexported fields of compiled
+// types; such fields behave like theirs, so CanSet and CanInterface report
+// false for them. StructOf panics if an exported field has PkgPath set, or if
+// a field whose name starts with an ASCII lower-case letter or underscore
+// does not.
+//
+// StructOf currently does not generate wrapper methods for embedded fields.
+// This limitation may be lifted in a future version.
+//
+// In particular, StructOf panics if an embedded field whose type has methods
+// is not the first field, since promoting its methods would require
+// generating machine code that adjusts the receiver by the field's offset,
+// which neither this package nor reflect can do at run time. If the type of
+// such a field is pointer-shaped, such as *T, it must also be the only
+// field. An embedded non-pointer struct with methods may be followed by
+// other fields.
 func StructOf(fields []StructField) Type {
+	for _, f := range fields {
+		mustBeNonNilType(f.Type, "StructOf")
+		if f.PkgPath != "" && isExportedName(f.Name) {
+			panic("reflect.StructOf: field \"" + f.Name + "\" is exported but has PkgPath set")
+		}
+	}
 	return structOf(fields)
 }
 
@@ -268,14 +345,70 @@ func StructOf(fields []StructField) Type {
 // If i is a nil interface value, TypeOf returns nil.
 func TypeOf(v any) Type {
 	value := (*Value)(unsafe.Pointer(&v))
+	if crossCheckEnabled.Load() {
+		crossCheckTypeOf(v, value.typ)
+	}
 	return value.typ
 }
 
+// TypeFor returns the Type that represents the type argument T.
+func TypeFor[T any]() Type {
+	return TypeOf((*T)(nil)).Elem()
+}
+
 // TypeID returns unique type identifier of v.
+//
+// Within a process, two values with identical dynamic types always have the
+// same TypeID, and values of distinct types never share one. TypeID(nil) is 0.
+// The identifier is derived from the address of the type descriptor, so it
+// differs between processes and runs; use TypeIDFingerprint for keys that
+// must be stable across processes. TypeIDLess orders TypeIDs, for tables
+// searched with SearchTypeID.
 func TypeID(v any) uintptr {
 	return uintptr(unsafe.Pointer(TypeOf(v)))
 }
 
+// TypeIDLess reports whether the TypeID a sorts before b. It is a total
+// order, and it is stable within a process, as type descriptors never
+// move, but it differs between processes and carries no meaning: it is
+// the numeric order of the identifiers.
+func TypeIDLess(a, b uintptr) bool {
+	return a < b
+}
+
+// SortTypeIDs sorts ids in the order of TypeIDLess.
+func SortTypeIDs(ids []uintptr) {
+	slices.Sort(ids)
+}
+
+// SearchTypeID searches for id in ids, which must be sorted by
+// SortTypeIDs, and returns the index of id and true if it is found, or
+// the index where it would be inserted and false otherwise. A sorted
+// table of TypeIDs needs no hashing and is smaller than a map; for a few
+// dozen entries, searching it takes about as long as a map lookup.
+func SearchTypeID(ids []uintptr, id uintptr) (int, bool) {
+	return slices.BinarySearch(ids, id)
+}
+
+// TypeIDUint64 returns TypeID(v) widened to 64 bits,
+// for use as a key in 64-bit hash tables or wire formats.
+func TypeIDUint64(v any) uint64 {
+	return uint64(TypeID(v))
+}
+
+// TypeIDFingerprint returns a hash of the string form, package path, kind and
+// size of the dynamic type of v. Unlike TypeID it is stable across processes
+// built from the same source, which makes it suitable as a persistent cache key.
+// Distinct types may in principle share a fingerprint.
+// TypeIDFingerprint(nil) is 0.
+func TypeIDFingerprint(v any) uint64 {
+	t := TypeOf(v)
+	if t == nil {
+		return 0
+	}
+	return typeFingerprint(t)
+}
+
 func valueOf(v any) Value {
 	if v == nil {
 		return Value{}
@@ -293,20 +426,92 @@ func valueOf(v any) Value {
 }
 
 // TypeAndPtrOf returns raw Type and ptr value in favor of performance.
+//
+// ptr is the data word of the interface v. For types that are stored
+// directly in interfaces (pointers, maps, chans, funcs, unsafe.Pointer and
+// structs or arrays holding a single such value), ptr is the value itself,
+// not a pointer to it; for all other types ptr points to the value.
+// Use IfaceIndir or TypeAndDataOf to tell the two cases apart.
 func TypeAndPtrOf(v any) (Type, unsafe.Pointer) {
 	value := (*Value)(unsafe.Pointer(&v))
 	return value.typ, value.ptr
 }
 
+// TypeAndDataOf is like TypeAndPtrOf but also reports whether data points to
+// the value (indirect is true) or is the value itself (indirect is false).
+// For a nil interface it returns nil, nil and false.
+func TypeAndDataOf(v any) (typ Type, data unsafe.Pointer, indirect bool) {
+	value := (*Value)(unsafe.Pointer(&v))
+	if value.typ == nil {
+		return nil, nil, false
+	}
+	return value.typ, value.ptr, ifaceIndir(value.typ)
+}
+
+// IfaceWordsOf is like TypeAndPtrOf for a value of any interface type I,
+// such as io.Reader, not just any: it returns the dynamic type of v, read
+// from the itab of a non-empty interface, and its data word, without
+// converting v to any. For a nil interface it returns nil, nil.
+// If I is not an interface type, it returns TypeAndPtrOf(v).
+func IfaceWordsOf[I any](v I) (Type, unsafe.Pointer) {
+	t := TypeFor[I]()
+	if t.Kind() != Interface {
+		return TypeAndPtrOf(v)
+	}
+	return ifaceWords(unsafe.Pointer(&v), t.NumMethod() == 0)
+}
+
+// ifaceWords returns the dynamic type and data word of the interface at p,
+// which is empty if empty is true.
+func ifaceWords(p unsafe.Pointer, empty bool) (Type, unsafe.Pointer) {
+	words := (*[2]unsafe.Pointer)(p)
+	if empty || words[0] == nil {
+		return (Type)(words[0]), words[1]
+	}
+	// The dynamic type follows the interface type in the itab.
+	return (*[2]Type)(words[0])[1], words[1]
+}
+
+// FieldPointer returns the address of the field f within the struct
+// pointed to by structPtr. f must be a field of that struct type, as
+// returned by Type.Field or Type.FieldByIndex.
+func FieldPointer(structPtr unsafe.Pointer, f StructField) unsafe.Pointer {
+	return unsafe.Add(structPtr, f.Offset)
+}
+
+// FieldOffsets returns the offsets of the top-level fields of the struct
+// type t, in field order. The table is computed once per type and cached,
+// so the returned slice is shared and must not be modified.
+// It panics if t's Kind is not Struct.
+func FieldOffsets(t Type) []uintptr {
+	mustBeNonNilType(t, "FieldOffsets")
+	structLayout(t, "FieldOffsets")
+	return fieldOffsetsCache.get(t, buildFieldOffsets)
+}
+
+// IfaceIndir reports whether values of type t are stored indirectly in an
+// interface, that is, whether the data word of an interface holding a t
+// points to the value rather than being the value. It is the inverse of
+// t.IsDirectIface.
+func IfaceIndir(t Type) bool {
+	return ifaceIndir(t)
+}
+
 // ValueOf returns a new Value initialized to the concrete value
 // stored in the interface i. ValueOf(nil) returns the zero Value.
 func ValueOf(v any) Value {
 	escape(v)
+	if crossCheckEnabled.Load() {
+		return crossCheckValue("ValueOf", func() Value { return valueOf(v) }, func() reflect.Value { return reflect.ValueOf(v) })
+	}
 	return valueOf(v)
 }
 
 // ValueNoEscapeOf no escape of ValueOf.
 func ValueNoEscapeOf(v any) Value {
+	if crossCheckEnabled.Load() {
+		return crossCheckValue("ValueNoEscapeOf", func() Value { return valueOf(v) }, func() reflect.Value { return reflect.ValueOf(v) })
+	}
 	return valueOf(v)
 }
 
@@ -320,6 +525,27 @@ func ToReflectValue(v Value) reflect.Value {
 	return toRV(v)
 }
 
+// ToReflectValues convert []Value to []reflect.Value.
+// The result shares its backing array with v and is obtained without allocation.
+func ToReflectValues(v []Value) []reflect.Value {
+	return toRVs(v)
+}
+
+// ToReflectMethod convert Method to reflect.Method
+func ToReflectMethod(m Method) reflect.Method {
+	return toRM(m)
+}
+
+// ToReflectSelectCase convert SelectCase to reflect.SelectCase
+func ToReflectSelectCase(c SelectCase) reflect.SelectCase {
+	return toRSC(c)
+}
+
+// ToReflectSelectCases convert []SelectCase to []reflect.SelectCase
+func ToReflectSelectCases(c []SelectCase) []reflect.SelectCase {
+	return toRSCs(c)
+}
+
 // ToType convert reflect.Type to Type
 func ToType(t reflect.Type) Type {
 	return toT(t)
@@ -330,6 +556,27 @@ func ToValue(v reflect.Value) Value {
 	return toV(v)
 }
 
+// ToValues convert []reflect.Value to []Value.
+// The result shares its backing array with v and is obtained without allocation.
+func ToValues(v []reflect.Value) []Value {
+	return toVs(v)
+}
+
+// ToSelectCase convert reflect.SelectCase to SelectCase
+func ToSelectCase(c reflect.SelectCase) SelectCase {
+	return toSC(c)
+}
+
+// ToSelectCases convert []reflect.SelectCase to []SelectCase
+func ToSelectCases(c []reflect.SelectCase) []SelectCase {
+	return toSCs(c)
+}
+
+// ToMethod convert reflect.Method to Method
+func ToMethod(m reflect.Method) Method {
+	return toM(m)
+}
+
 // Copy copies the contents of src into dst until either
 // dst has been filled or src has been exhausted.
 // It returns the number of elements copied.
@@ -337,8 +584,32 @@ func ToValue(v reflect.Value) Value {
 // dst and src must have the same element type.
 //
 // As a special case, src can have kind String if the element type of dst is kind Uint8.
+// Dst and src may also be pointers to arrays, which stand for the arrays
+// they point to, as in a copy to or from an array pointer converted to a
+// slice. Copy panics if such a pointer is nil, unless the array has length
+// zero, in which case it stands for an empty slice as (*[0]T)(nil)[:] does.
+//
+// The elements are copied with a single memmove that takes care of the
+// garbage collector's write barriers; see CopySliceFast for differing
+// element types.
 func Copy(dst, src Value) int {
-	return value_Copy(dst, src)
+	return value_Copy(derefArrayPtr(dst, "destination"), derefArrayPtr(src, "source"))
+}
+
+// derefArrayPtr returns the array v points to if v is a pointer to an array,
+// and v otherwise. A nil pointer to a zero-length array stands for an empty
+// slice of its element type; any other nil pointer panics.
+func derefArrayPtr(v Value, which string) Value {
+	if v.flag.kind() != Ptr || v.typ.Elem().Kind() != Array {
+		return v
+	}
+	if v.IsNil() {
+		if at := v.typ.Elem(); at.Len() == 0 {
+			return Zero(SliceOf(at.Elem()))
+		}
+		panic("reflect.Copy: " + which + " is a nil pointer to array " + v.typ.Elem().String())
+	}
+	return value_Elem(v)
 }
 
 // DeepEqual reports whether x and y are “deeply equal,” defined as follows.
@@ -421,6 +692,7 @@ func Indirect(v Value) Value {
 
 // MakeChan creates a new channel with the specified type and buffer size.
 func MakeChan(typ Type, buffer int) Value {
+	mustBeNonNilType(typ, "MakeChan")
 	return value_MakeChan(typ, buffer)
 }
 
@@ -445,36 +717,113 @@ func MakeChan(typ Type, buffer int) Value {
 //
 // The Examples section of the documentation includes an illustration
 // of how to use MakeFunc to build a swap function for different types.
+//
+// The runtime cannot create new method implementations, so there is no way
+// to build a value of a fresh type that satisfies an arbitrary interface.
+// A StructOf type embedding the interface reports that it implements it,
+// but calling any of the promoted methods panics. To adapt a MakeFunc
+// result to an interface, pass a named func type whose methods call the
+// function itself (in the style of http.HandlerFunc) as typ: the returned
+// Value then has that type and its method set.
+//
+// If fn returns results that do not match typ, the call of the new
+// function panics with a *CallError.
 func MakeFunc(typ Type, fn func(args []Value) (results []Value)) Value {
-	return value_MakeFunc(typ, fn)
+	mustBeNonNilType(typ, "MakeFunc")
+	if typ.Kind() != Func {
+		return value_MakeFunc(typ, fn)
+	}
+	return value_MakeFunc(typ, func(args []Value) []Value {
+		out := fn(args)
+		mustBeResultsOf(typ, out)
+		return out
+	})
+}
+
+// MakeTypedFunc is like MakeFunc for the function type F, but returns the
+// new function as an F rather than as a Value, so that it can be called
+// directly. It panics if F is not a function type.
+func MakeTypedFunc[F any](impl func(in []Value) []Value) F {
+	typ := TypeFor[F]()
+	if typ.Kind() != Func {
+		panic("reflect: MakeTypedFunc of non-func type " + typ.String())
+	}
+	return MakeFunc(typ, impl).Interface().(F)
 }
 
 // MakeMap creates a new map with the specified type.
 func MakeMap(typ Type) Value {
+	mustBeNonNilType(typ, "MakeMap")
 	return value_MakeMap(typ)
 }
 
 // MakeMapWithSize creates a new map with the specified type
 // and initial space for approximately n elements.
 func MakeMapWithSize(typ Type, n int) Value {
+	mustBeNonNilType(typ, "MakeMapWithSize")
 	return value_MakeMapWithSize(typ, n)
 }
 
+// MapClone returns a new map of the same type as the map v holding the same
+// entries. The keys and elements are copied as by assignment, so the
+// clone shares whatever they point to with v. If v represents a nil map,
+// MapClone returns a nil map of the same type.
+// It panics if v's Kind is not Map.
+func MapClone(v Value) Value {
+	if k := v.flag.kind(); k != Map {
+		panic(&ValueError{Method: "reflect.MapClone", Kind: k})
+	}
+	if value_IsNil(v) {
+		return value_Zero(v.typ)
+	}
+	m := value_MakeMapWithSize(v.typ, value_Len(v))
+	value_RangeMap(v, func(key, val Value) bool {
+		value_SetMapIndex(m, key, val)
+		return true
+	})
+	return m
+}
+
 // MakeSlice creates a new zero-initialized slice value
 // for the specified slice type, length, and capacity.
 func MakeSlice(typ Type, len, cap int) Value {
+	mustBeNonNilType(typ, "MakeSlice")
 	return value_MakeSlice(typ, len, cap)
 }
 
 // New returns a Value representing a pointer to a new zero value
 // for the specified type. That is, the returned Value's Type is PtrTo(typ).
 func New(typ Type) Value {
+	mustBeNonNilType(typ, "New")
 	return value_New(typ)
 }
 
+// TryNew is like New but returns an error instead of panicking
+// if typ is nil.
+func TryNew(typ Type) (Value, error) {
+	if typ == nil {
+		return Value{}, errNilType("New")
+	}
+	return value_New(typ), nil
+}
+
+// NewBoxed is like New, but also returns the new pointer as an interface
+// value, as ptr.Interface() would. Both share the one allocation of New:
+// a pointer is stored in an interface directly, so boxing it needs no
+// further allocation, and writes through ptr.Elem() are visible through
+// boxed.
+// It panics if typ is nil.
+func NewBoxed(typ Type) (ptr Value, boxed any) {
+	mustBeNonNilType(typ, "NewBoxed")
+	ptr = value_New(typ)
+	boxed, _ = value_InterfaceDirect(ptr)
+	return ptr, boxed
+}
+
 // NewAt returns a Value representing a pointer to a value of the
 // specified type, using p as that pointer.
 func NewAt(typ Type, p unsafe.Pointer) Value {
+	mustBeNonNilType(typ, "NewAt")
 	return value_NewAt(typ, p)
 }
 
@@ -485,7 +834,14 @@ func NewAt(typ Type, p unsafe.Pointer) Value {
 // and, if that case was a receive operation, the value received and a
 // boolean indicating whether the value corresponds to a send on the channel
 // (as opposed to a zero value received because the channel is closed).
+//
+// See EnableSelectValidation for checking the cases before they are used.
 func Select(cases []SelectCase) (int, Value, bool) {
+	if selectValidation.Load() {
+		if errs := ValidateSelectCases(cases); len(errs) != 0 {
+			panic(errs[0].Error())
+		}
+	}
 	return value_Select(cases)
 }
 
@@ -495,9 +851,19 @@ func Select(cases []SelectCase) (int, Value, bool) {
 // For example, Zero(TypeOf(42)) returns a Value with Kind Int and value 0.
 // The returned value is neither addressable nor settable.
 func Zero(typ Type) Value {
+	mustBeNonNilType(typ, "Zero")
 	return value_Zero(typ)
 }
 
+// TryZero is like Zero but returns an error instead of panicking
+// if typ is nil.
+func TryZero(typ Type) (Value, error) {
+	if typ == nil {
+		return Value{}, errNilType("Zero")
+	}
+	return value_Zero(typ), nil
+}
+
 // Align returns the alignment in bytes of a value of
 // this type when allocated in memory.
 func (t *rtype) Align() int {
@@ -534,10 +900,29 @@ func (t *rtype) Method(a0 int) Method {
 // For an interface type, the returned Method's Type field gives the
 // method signature, without a receiver, and the Func field is nil.
 func (t *rtype) MethodByName(a0 string) (Method, bool) {
+	if crossCheckEnabled.Load() {
+		return crossCheckMethod("Type.MethodByName", func() (Method, bool) {
+			mtd, ok := type_MethodByName(t, a0)
+			return toM(mtd), ok
+		}, func() (reflect.Method, bool) {
+			return toRT(t).MethodByName(a0)
+		})
+	}
 	mtd, ok := type_MethodByName(t, a0)
 	return toM(mtd), ok
 }
 
+// MethodIndexByName returns the index of the method with that name in the
+// type's method set, suitable for Method and Value.Method, and a boolean
+// indicating if the method was found.
+//
+// The name to index table is built once per type on first use and cached,
+// so repeated lookups are a map read and do not allocate.
+func (t *rtype) MethodIndexByName(name string) (int, bool) {
+	i, ok := methodIndexCache.get(t, buildMethodIndex)[name]
+	return i, ok
+}
+
 // NumMethod returns the number of exported methods in the type's method set.
 func (t *rtype) NumMethod() int {
 	return type_NumMethod(t)
@@ -549,6 +934,28 @@ func (t *rtype) Name() string {
 	return type_Name(t)
 }
 
+// IsInstantiated reports whether t is an instantiation of a generic type,
+// such as List[int]. Such a type is a defined type like any other, and
+// distinct instantiations are distinct types.
+func (t *rtype) IsInstantiated() bool {
+	return strings.HasSuffix(t.Name(), "]")
+}
+
+// TypeArgsString returns the type arguments of an instantiated generic
+// type t as they appear between the brackets of its name, for example
+// "string,int" for Pair[string, int], or "" if t is not instantiated.
+// Named type arguments are qualified by their full package path, but
+// types declared in different functions can still share a spelling, so
+// cache keys should rely on the Type itself or TypeID for identity.
+func (t *rtype) TypeArgsString() string {
+	name := t.Name()
+	if !strings.HasSuffix(name, "]") {
+		return ""
+	}
+	i := strings.IndexByte(name, '[')
+	return name[i+1 : len(name)-1]
+}
+
 // PkgPath returns a defined type's package path, that is, the import path
 // that uniquely identifies the package, such as "encoding/base64".
 // If the type was predeclared (string, error) or not defined (*T, struct{},
@@ -569,6 +976,11 @@ func (t *rtype) Size() uintptr {
 // (e.g., base64 instead of "encoding/base64") and is not
 // guaranteed to be unique among types. To test for type identity,
 // compare the Types directly.
+//
+// The string is stored with the type, by the compiler or by the
+// constructors such as StructOf and PtrTo, which build it from the
+// strings of already existing types. Printing a self-referential type
+// therefore never descends into it and always terminates.
 func (t *rtype) String() string {
 	return type_String(t)
 }
@@ -598,6 +1010,16 @@ func (t *rtype) Comparable() bool {
 	return type_Comparable(t)
 }
 
+// IsDirectIface reports whether values of this type are pointer-shaped,
+// that is, stored directly in the data word of an interface rather than
+// through a pointer to them. Pointers, maps, channels, funcs, unsafe
+// pointers, and arrays of one element and structs of one field of such a
+// type are. It tells how to interpret the pointer returned by
+// TypeAndPtrOf, and is the inverse of IfaceIndir.
+func (t *rtype) IsDirectIface() bool {
+	return !ifaceIndir(t)
+}
+
 // Methods applicable only to some types, depending on Kind.
 // The methods allowed for each kind are:
 //
@@ -662,9 +1084,59 @@ func (t *rtype) FieldByIndex(index []int) StructField {
 
 // FieldByName returns the struct field with the given name
 // and a boolean indicating if the field was found.
+//
+// For struct types the lookup uses the table of FieldIndexByName
+// instead of searching the embedded structs on every call.
 func (t *rtype) FieldByName(name string) (StructField, bool) {
-	field, ok := type_FieldByName(t, name)
-	return toSF(field), ok
+	if t.Kind() != Struct {
+		field, ok := type_FieldByName(t, name)
+		return toSF(field), ok
+	}
+	f, ok := fieldIndexCache.get(t, buildFieldIndex)[name]
+	if ok {
+		f.Index = append([]int(nil), f.Index...)
+	}
+	return f, ok
+}
+
+// FieldByNameFold is like FieldByName, but matches field names
+// case-insensitively, as encoding/json does: a field whose name matches
+// exactly is preferred, and otherwise the first field in field order whose
+// name is equal under Unicode case folding is returned.
+// Like FieldByName, promoted fields of embedded structs are found, and
+// fields hidden by or conflicting with a shallower one are not.
+//
+// The folded names are indexed once per type and cached.
+// It panics if the type's Kind is not Struct.
+func (t *rtype) FieldByNameFold(name string) (StructField, bool) {
+	if t.Kind() != Struct {
+		panic("reflect: FieldByNameFold of non-struct type " + t.String())
+	}
+	f, ok := fieldIndexCache.get(t, buildFieldIndex)[name]
+	if !ok {
+		f, ok = foldedFieldCache.get(t, buildFoldedFieldIndex)[foldName(name)]
+	}
+	if ok {
+		f.Index = append([]int(nil), f.Index...)
+	}
+	return f, ok
+}
+
+// FieldIndexByName returns the index sequence of the struct field with the
+// given name, suitable for FieldByIndex and Value.FieldByIndex, and a boolean
+// indicating if the field was found. Names are resolved like FieldByName does,
+// so ambiguous promoted fields are not found.
+//
+// The name to field table is built once per type on first use and cached,
+// so repeated lookups are a map read and do not allocate. The returned slice
+// is shared and must not be modified.
+// It panics if the type's Kind is not Struct.
+func (t *rtype) FieldIndexByName(name string) ([]int, bool) {
+	if t.Kind() != Struct {
+		panic("reflect: FieldIndexByName of non-struct type " + t.String())
+	}
+	f, ok := fieldIndexCache.get(t, buildFieldIndex)[name]
+	return f.Index, ok
 }
 
 // FieldByNameFunc returns the struct field with a name
@@ -684,6 +1156,74 @@ func (t *rtype) FieldByNameFunc(match func(string) bool) (StructField, bool) {
 	return toSF(field), ok
 }
 
+// RangeFields calls fn for each field of the struct type t in order,
+// passing the field index, name, type, offset and tag, until fn returns false.
+// Unlike Field, it does not copy a StructField or allocate per field.
+// It panics if the type's Kind is not Struct.
+func (t *rtype) RangeFields(fn func(i int, name string, ft Type, offset uintptr, tag StructTag) bool) {
+	for i, f := range structLayout(t, "RangeFields").fields {
+		if !fn(i, f.name.name(), f.typ, f.offset, StructTag(f.name.tag())) {
+			return
+		}
+	}
+}
+
+// WalkFields calls fn for every field reachable from the struct type t,
+// descending into embedded structs and the struct types embedded pointers
+// point to, until fn returns false. path is the full index sequence of the
+// field, suitable for FieldByIndex, and is also stored in f.Index; fn may
+// retain it.
+//
+// Fields are visited in order of depth, and in field order within a depth,
+// so a field is always visited before the deeper fields it shadows, and the
+// first visited field with a given name is the one FieldByName would find
+// unless that name is ambiguous.
+// An embedded struct that is already being walked further up the chain of
+// embeddings, as in a struct embedding a pointer to itself, is reported as
+// a field but not walked again, so WalkFields terminates for any type.
+// It panics if t's Kind is not Struct.
+func WalkFields(t Type, fn func(path []int, f StructField) bool) {
+	mustBeNonNilType(t, "WalkFields")
+	if t.Kind() != Struct {
+		panic("reflect: WalkFields of non-struct type " + t.String())
+	}
+	type walkNode struct {
+		typ   Type
+		path  []int
+		chain []Type
+	}
+	level := []walkNode{{typ: t, chain: []Type{t}}}
+	for len(level) > 0 {
+		var next []walkNode
+		for _, node := range level {
+			for i, n := 0, node.typ.NumField(); i < n; i++ {
+				f := node.typ.Field(i)
+				path := append(node.path[:len(node.path):len(node.path)], i)
+				f.Index = path
+				if !fn(path, f) {
+					return
+				}
+				if !f.Anonymous {
+					continue
+				}
+				ft := f.Type
+				if ft.Kind() == Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() != Struct || slices.Contains(node.chain, ft) {
+					continue
+				}
+				next = append(next, walkNode{
+					typ:   ft,
+					path:  path,
+					chain: append(node.chain[:len(node.chain):len(node.chain)], ft),
+				})
+			}
+		}
+		level = next
+	}
+}
+
 // In returns the type of a function type's i'th input parameter.
 // It panics if the type's Kind is not Func.
 // It panics if i is not in the range [0, NumIn()).
@@ -734,18 +1274,21 @@ func (t *rtype) Out(i int) Type {
 // or slice element in order to call a method that requires a
 // pointer receiver.
 func (v Value) Addr() Value {
+	v.mustBeValid("reflect.Value.Addr")
 	return value_Addr(v)
 }
 
 // Bool returns v's underlying value.
 // It panics if v's kind is not Bool.
 func (v Value) Bool() bool {
+	v.mustBeValid("reflect.Value.Bool")
 	return value_Bool(v)
 }
 
 // Bytes returns v's underlying value.
 // It panics if v's underlying value is not a slice of bytes.
 func (v Value) Bytes() []byte {
+	v.mustBeValid("reflect.Value.Bytes")
 	return value_Bytes(v)
 }
 
@@ -757,7 +1300,16 @@ func (v Value) Bytes() []byte {
 // type of the function's corresponding input parameter.
 // If v is a variadic function, Call creates the variadic slice parameter
 // itself, copying in the corresponding values.
+// If the arguments do not match the function's parameters, Call panics
+// with a *CallError describing the first mismatch.
 func (v Value) Call(in []Value) []Value {
+	v.mustBeValid("reflect.Value.Call")
+	mustBeCallable("Call", v, in)
+	if crossCheckEnabled.Load() {
+		out := value_Call(v, in)
+		crossCheckCall("Value.Call", v, in, out)
+		return out
+	}
 	return value_Call(v, in)
 }
 
@@ -768,7 +1320,10 @@ func (v Value) Call(in []Value) []Value {
 // It returns the output results as Values.
 // As in Go, each input argument must be assignable to the
 // type of the function's corresponding input parameter.
+// If they do not, CallSlice panics with a *CallError.
 func (v Value) CallSlice(in []Value) []Value {
+	v.mustBeValid("reflect.Value.CallSlice")
+	mustBeCallable("CallSlice", v, in)
 	return value_CallSlice(v, in)
 }
 
@@ -783,6 +1338,7 @@ func (v Value) CanAddr() bool {
 
 // CanInterface reports whether Interface can be used without panicking.
 func (v Value) CanInterface() bool {
+	v.mustBeValid("reflect.Value.CanInterface")
 	return value_CanInterface(v)
 }
 
@@ -798,18 +1354,22 @@ func (v Value) CanSet() bool {
 // Cap returns v's capacity.
 // It panics if v's Kind is not Array, Chan, or Slice.
 func (v Value) Cap() int {
+	v.mustBeValid("reflect.Value.Cap")
 	return value_Cap(v)
 }
 
 // Close closes the channel v.
 // It panics if v's Kind is not Chan.
 func (v Value) Close() {
+	v.mustBeValid("reflect.Value.Close")
 	value_Close(v)
+	v.traceWrite(WriteClose)
 }
 
 // Complex returns v's underlying value, as a complex128.
 // It panics if v's Kind is not Complex64 or Complex128.
 func (v Value) Complex() complex128 {
+	v.mustBeValid("reflect.Value.Complex")
 	return value_Complex(v)
 }
 
@@ -817,34 +1377,206 @@ func (v Value) Complex() complex128 {
 // If the usual Go conversion rules do not allow conversion
 // of the value v to type t, Convert panics.
 func (v Value) Convert(t Type) Value {
+	v.mustBeValid("reflect.Value.Convert")
+	if crossCheckEnabled.Load() {
+		return crossCheckValue("Value.Convert", func() Value { return value_Convert(v, t) }, func() reflect.Value { return toRV(v).Convert(toRT(t)) })
+	}
 	return value_Convert(v, t)
 }
 
+// CanConvert reports whether the value v can be converted to type t.
+// If v.CanConvert(t) returns true then v.Convert(t) will not panic.
+// Unlike Type.ConvertibleTo, it takes the length of a slice into account
+// when converting it to an array or a pointer to an array.
+func (v Value) CanConvert(t Type) bool {
+	v.mustBeValid("reflect.Value.CanConvert")
+	mustBeNonNilType(t, "Value.CanConvert")
+	return toRV(v).CanConvert(toRT(t))
+}
+
+// SliceToArray returns the first n elements of the slice v as an array
+// of type [n]E, where E is v's element type, copying them as the Go
+// conversion [n]E(v) does. It returns an error if v is shorter than n.
+// It panics if v's Kind is not Slice or if n is negative.
+func SliceToArray(v Value, n int) (Value, error) {
+	if k := v.flag.kind(); k != Slice {
+		panic(&ValueError{Method: "reflect.SliceToArray", Kind: k})
+	}
+	if l := value_Len(v); l < n {
+		return Value{}, errors.New("reflect: SliceToArray: slice of length " + strconv.Itoa(l) +
+			" is too short for array of length " + strconv.Itoa(n))
+	}
+	return value_Convert(v, ArrayOf(n, v.typ.Elem())), nil
+}
+
+// DynKind returns the Kind of the value held by the interface v, and
+// false if v is a nil interface. Unlike v.Elem().Kind(), it tells a nil
+// interface apart from one holding a value: an interface holding a nil
+// pointer reports Ptr and true.
+// If v's Kind is not Interface, DynKind returns v.Kind() and true,
+// so that it can be called on any Value; for the zero Value it returns
+// Invalid and false.
+func (v Value) DynKind() (Kind, bool) {
+	if v.flag.kind() != Interface {
+		return v.flag.kind(), v.flag != 0
+	}
+	e := value_Elem(v)
+	return e.flag.kind(), e.flag != 0
+}
+
+// DynType is like DynKind but returns the dynamic type of v, or v's own
+// type if v's Kind is not Interface. It returns nil and false for a nil
+// interface and for the zero Value.
+func (v Value) DynType() (Type, bool) {
+	if v.flag == 0 {
+		return nil, false
+	}
+	if v.flag.kind() != Interface {
+		return value_Type(v), true
+	}
+	e := value_Elem(v)
+	if e.flag == 0 {
+		return nil, false
+	}
+	return e.typ, true
+}
+
 // Elem returns the value that the interface v contains
 // or that the pointer v points to.
 // It panics if v's Kind is not Interface or Ptr.
 // It returns the zero Value if v is nil.
 func (v Value) Elem() Value {
+	v.mustBeValid("reflect.Value.Elem")
 	return value_Elem(v)
 }
 
 // Field returns the i'th field of the struct v.
 // It panics if v's Kind is not Struct or i is out of range.
 func (v Value) Field(i int) Value {
+	v.mustBeValid("reflect.Value.Field")
+	if crossCheckEnabled.Load() {
+		return crossCheckValue("Value.Field", func() Value { return value_Field(v, i) }, func() reflect.Value { return toRV(v).Field(i) })
+	}
 	return value_Field(v, i)
 }
 
+// FieldPointer returns the address of the i'th field of the struct v.
+// It panics if v's Kind is not Struct, if i is out of range,
+// or if v is not addressable.
+func (v Value) FieldPointer(i int) unsafe.Pointer {
+	v.mustBeValid("reflect.Value.FieldPointer")
+	if k := v.flag.kind(); k != Struct {
+		panic(&ValueError{Method: "reflect.Value.FieldPointer", Kind: k})
+	}
+	fields := structLayout(v.typ, "FieldPointer").fields
+	if uint(i) >= uint(len(fields)) {
+		panic("reflect: FieldPointer index out of range")
+	}
+	if v.flag&flagAddr == 0 {
+		panic("reflect: FieldPointer of unaddressable value")
+	}
+	return unsafe.Add(v.ptr, fields[i].offset)
+}
+
 // FieldByIndex returns the nested field corresponding to index.
 // It panics if v's Kind is not struct.
 func (v Value) FieldByIndex(index []int) Value {
+	v.mustBeValid("reflect.Value.FieldByIndex")
 	return value_FieldByIndex(v, index)
 }
 
+// FieldByIndexErr returns the nested field corresponding to index.
+// Unlike FieldByIndex, it returns a *NilEmbeddedError instead of panicking
+// if evaluation requires stepping through a nil pointer to an embedded
+// struct, so callers can treat such a field as absent.
+// It panics if v's Kind is not struct.
+func (v Value) FieldByIndexErr(index []int) (Value, error) {
+	v.mustBeValid("reflect.Value.FieldByIndexErr")
+	if len(index) == 1 {
+		return v.Field(index[0]), nil
+	}
+	if k := v.flag.kind(); k != Struct {
+		panic(&ValueError{Method: "reflect.Value.FieldByIndexErr", Kind: k})
+	}
+	var owner Type
+	for i, x := range index {
+		if i > 0 && v.flag.kind() == Ptr && v.typ.Elem().Kind() == Struct {
+			if v.IsNil() {
+				return Value{}, &NilEmbeddedError{Type: owner, Field: owner.Field(index[i-1]).Name, Depth: i - 1}
+			}
+			v = value_Elem(v)
+		}
+		owner = v.typ
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// CanSetPath reports whether the field of root reached through the
+// index sequence could be set, and if not, why: because a pointer on the
+// path is nil, because a field on the path is unexported, or because root
+// is not addressable. Like FieldByIndex, it follows pointers to structs,
+// including root itself, but it does not panic on nil ones.
+// It panics if index does not describe a field of root.
+func CanSetPath(root Value, index []int) (canSet bool, reason string) {
+	root.mustBeValid("reflect.CanSetPath")
+	if root.flag&flagRO != 0 {
+		return false, "root value was obtained using an unexported field"
+	}
+	v := root
+	var owner Type
+	for i, x := range index {
+		if v.flag.kind() == Ptr {
+			if value_IsNil(v) {
+				if i == 0 {
+					return false, "root value is a nil " + v.typ.String()
+				}
+				return false, "embedded field " + owner.String() + "." + owner.Field(index[i-1]).Name + " is a nil pointer"
+			}
+			v = value_Elem(v)
+		}
+		owner = v.typ
+		v = value_Field(v, x)
+		if v.flag&flagRO == 0 {
+			continue
+		}
+		f := owner.Field(x)
+		if v.flag&flagStickyRO != 0 {
+			return false, "field " + owner.String() + "." + f.Name + " is unexported"
+		}
+		if i == len(index)-1 {
+			return false, "embedded field " + owner.String() + "." + f.Name + " is unexported"
+		}
+	}
+	if v.flag&flagAddr == 0 {
+		return false, "root value of type " + root.typ.String() + " is not addressable"
+	}
+	return true, ""
+}
+
 // FieldByName returns the struct field with the given name.
-// It returns the zero Value if no field was found.
+// It returns the zero Value if no field was found; see FieldByNameErr
+// for an error describing the failed lookup.
 // It panics if v's Kind is not struct.
 func (v Value) FieldByName(name string) Value {
-	return value_FieldByName(v, name)
+	v.mustBeValid("reflect.Value.FieldByName")
+	if k := v.flag.kind(); k != Struct {
+		panic(&ValueError{Method: "reflect.Value.FieldByName", Kind: k})
+	}
+	if index, ok := v.typ.FieldIndexByName(name); ok {
+		return value_FieldByIndex(v, index)
+	}
+	return Value{}
+}
+
+// FieldByNameErr is like FieldByName, but returns a *LookupError naming
+// the field and the struct type if no field was found.
+func (v Value) FieldByNameErr(name string) (Value, error) {
+	v.mustBeValid("reflect.Value.FieldByNameErr")
+	if f := v.FieldByName(name); f.flag != 0 {
+		return f, nil
+	}
+	return Value{}, &LookupError{Lookup: "FieldByName", Name: name, Type: v.typ}
 }
 
 // FieldByNameFunc returns the struct field with a name
@@ -852,24 +1584,28 @@ func (v Value) FieldByName(name string) Value {
 // It panics if v's Kind is not struct.
 // It returns the zero Value if no field was found.
 func (v Value) FieldByNameFunc(match func(string) bool) Value {
+	v.mustBeValid("reflect.Value.FieldByNameFunc")
 	return value_FieldByNameFunc(v, match)
 }
 
 // Float returns v's underlying value, as a float64.
 // It panics if v's Kind is not Float32 or Float64.
 func (v Value) Float() float64 {
+	v.mustBeValid("reflect.Value.Float")
 	return value_Float(v)
 }
 
 // Index returns v's i'th element.
 // It panics if v's Kind is not Array, Slice, or String or i is out of range.
 func (v Value) Index(i int) Value {
+	v.mustBeValid("reflect.Value.Index")
 	return value_Index(v, i)
 }
 
 // Int returns v's underlying value, as an int64.
 // It panics if v's Kind is not Int, Int8, Int16, Int32, or Int64.
 func (v Value) Int() int64 {
+	v.mustBeValid("reflect.Value.Int")
 	return value_Int(v)
 }
 
@@ -881,12 +1617,32 @@ func (v Value) Int() int64 {
 // It panics if the Value was obtained by accessing
 // unexported struct fields.
 func (v Value) Interface() any {
+	v.mustBeValid("reflect.Value.Interface")
+	if i, ok := value_InterfaceDirect(v); ok {
+		return i
+	}
 	return value_Interface(v)
 }
 
+// InterfaceNoAlloc returns v's current value as an interface{} only if
+// that can be done without allocating, that is, if v's type is pointer-shaped
+// (pointer, map, chan, func, unsafe.Pointer and single-pointer structs or arrays)
+// and v is not a method value. Otherwise it returns nil and false.
+//
+// Like Interface, it panics if the Value was obtained by accessing
+// unexported struct fields.
+func (v Value) InterfaceNoAlloc() (any, bool) {
+	if v.flag&flagRO != 0 {
+		// Let Interface report the unexported field access.
+		value_Interface(v)
+	}
+	return value_InterfaceDirect(v)
+}
+
 // InterfaceData returns the interface v's value as a uintptr pair.
 // It panics if v's Kind is not Interface.
 func (v Value) InterfaceData() [2]uintptr {
+	v.mustBeValid("reflect.Value.InterfaceData")
 	return value_InterfaceData(v)
 }
 
@@ -898,21 +1654,34 @@ func (v Value) InterfaceData() [2]uintptr {
 // i==nil will be true but v.IsNil will panic as v will be the zero
 // Value.
 func (v Value) IsNil() bool {
+	v.mustBeValid("reflect.Value.IsNil")
 	return value_IsNil(v)
 }
 
 // IsValid reports whether v represents a value.
 // It returns false if v is the zero Value.
-// If IsValid returns false, all other methods except String panic.
+// If IsValid returns false, all other methods except String, Kind and
+// OrElse panic, unless their documentation states otherwise, with a
+// *ValueError of Kind Invalid naming the method.
 // Most functions and methods never return an invalid Value.
 // If one does, its documentation states the conditions explicitly.
 func (v Value) IsValid() bool {
 	return value_IsValid(v)
 }
 
+// OrElse returns v, or def if v is the zero Value. It supplies defaults
+// for lookups that may fail, as in m.MapIndex(k).OrElse(ValueOf(0)).Int().
+func (v Value) OrElse(def Value) Value {
+	if v.flag == 0 {
+		return def
+	}
+	return v
+}
+
 // IsZero reports whether v is the zero value for its type.
 // It panics if the argument is invalid.
 func (v Value) IsZero() bool {
+	v.mustBeValid("reflect.Value.IsZero")
 	return value_IsZero(v)
 }
 
@@ -925,6 +1694,7 @@ func (v Value) Kind() Kind {
 // Len returns v's length.
 // It panics if v's Kind is not Array, Chan, Map, Slice, or String.
 func (v Value) Len() int {
+	v.mustBeValid("reflect.Value.Len")
 	return value_Len(v)
 }
 
@@ -933,17 +1703,93 @@ func (v Value) Len() int {
 // It returns the zero Value if key is not found in the map or if v represents a nil map.
 // As in Go, the key's value must be assignable to the map's key type.
 func (v Value) MapIndex(key Value) Value {
+	v.mustBeValid("reflect.Value.MapIndex")
 	return value_MapIndex(v, key)
 }
 
+// MapHasKey reports whether key is present in the map v, like the ok
+// result of a two-value index expression. Unlike MapIndex, it does not
+// copy the element, so it does not allocate for keys of the map's key
+// type.
+// It panics if v's Kind is not Map, and returns false if v represents
+// a nil map. As in Go, the key's value must be assignable to the map's
+// key type.
+func (v Value) MapHasKey(key Value) bool {
+	v.mustBeValid("reflect.Value.MapHasKey")
+	if k := v.flag.kind(); k != Map {
+		panic(&ValueError{Method: "reflect.Value.MapHasKey", Kind: k})
+	}
+	if key.flag == 0 || key.flag&flagRO != 0 {
+		// Leave the panic to reflect.
+		return value_MapIndex(v, key).flag != 0
+	}
+	kt := v.typ.Key()
+	if key.typ != kt {
+		if !key.typ.AssignableTo(kt) {
+			return value_MapIndex(v, key).flag != 0
+		}
+		k := value_Elem(value_New(kt))
+		value_Set(k, key)
+		key = k
+	}
+	return mapaccess(v.typ, v.pointer(), key.data()) != nil
+}
+
 // MapKeys returns a slice containing all the keys present in the map,
 // in unspecified order.
 // It panics if v's Kind is not Map.
 // It returns an empty slice if v represents a nil map.
 func (v Value) MapKeys() []Value {
+	v.mustBeValid("reflect.Value.MapKeys")
 	return value_MapKeys(v)
 }
 
+// MapKeysSorted is like MapKeys, but returns the keys in an order that does
+// not depend on the map's iteration order, for reproducible output.
+// Numbers, strings and bools are sorted by value. Other comparable keys,
+// such as arrays, structs and interfaces, are ordered by a canonical
+// serialization of their contents, which is stable across runs but carries
+// no meaning; keys holding pointers or channels are ordered by address and
+// are only stable within a process.
+// It panics if v's Kind is not Map.
+func (v Value) MapKeysSorted() []Value {
+	v.mustBeValid("reflect.Value.MapKeysSorted")
+	keys := value_MapKeys(v)
+	sortMapEntries(v.typ.Key(), keys, func(k Value) Value { return k })
+	return keys
+}
+
+// MapRangeSorted calls fn for each entry of the map v, in the order of
+// MapKeysSorted, until fn returns false. The entries are collected in a
+// single pass over the map before fn is first called, so every entry is
+// visited, including those whose keys are not equal to themselves, such
+// as NaNs, and fn is passed the values the entries had at that time.
+// Entries that fn deletes before they are reached are skipped, as in a
+// range statement; entries it adds are not visited.
+// It panics if v's Kind is not Map.
+func (v Value) MapRangeSorted(fn func(key, val Value) bool) {
+	v.mustBeValid("reflect.Value.MapRangeSorted")
+	if k := v.flag.kind(); k != Map {
+		panic(&ValueError{Method: "reflect.Value.MapRangeSorted", Kind: k})
+	}
+	type entry struct{ key, val Value }
+	entries := make([]entry, 0, value_Len(v))
+	for it := value_MapRange(v); it.Next(); {
+		entries = append(entries, entry{toV(it.Key()), toV(it.Value())})
+	}
+	sortMapEntries(v.typ.Key(), entries, func(e entry) Value { return e.key })
+	for _, e := range entries {
+		// A key that is not equal to itself cannot be looked up, nor
+		// deleted, so its entry is still there.
+		if !v.MapHasKey(e.key) && toRV(e.key).Equal(toRV(e.key)) {
+			continue
+		}
+		if !fn(e.key, e.val) {
+			return
+		}
+	}
+}
+
 // MapRange returns a range iterator for a map.
 // It panics if v's Kind is not Map.
 //
@@ -960,14 +1806,28 @@ func (v Value) MapKeys() []Value {
 //		...
 //	}
 func (v Value) MapRange() *MapIter {
+	v.mustBeValid("reflect.Value.MapRange")
 	return value_MapRange(v)
 }
 
+// RangeMap calls fn for each entry of the map v until fn returns false.
+// It panics if v's Kind is not Map.
+//
+// The key and val passed to fn are two scratch Values that are reused for
+// every entry, so iterating does not allocate per entry. They hold copies of
+// the entry, are only valid during the callback and must not be retained;
+// mutating them does not affect the map.
+func (v Value) RangeMap(fn func(key, val Value) bool) {
+	v.mustBeValid("reflect.Value.RangeMap")
+	value_RangeMap(v, fn)
+}
+
 // Method returns a function value corresponding to v's i'th method.
 // The arguments to a Call on the returned function should not include
 // a receiver; the returned function will always use v as the receiver.
 // Method panics if i is out of range or if v is a nil interface value.
 func (v Value) Method(i int) Value {
+	v.mustBeValid("reflect.Value.Method")
 	return value_Method(v, i)
 }
 
@@ -975,43 +1835,107 @@ func (v Value) Method(i int) Value {
 // of v with the given name.
 // The arguments to a Call on the returned function should not include
 // a receiver; the returned function will always use v as the receiver.
-// It returns the zero Value if no method was found.
+// It returns the zero Value if no method was found; see MethodByNameErr
+// for an error describing the failed lookup.
 func (v Value) MethodByName(name string) Value {
+	v.mustBeValid("reflect.Value.MethodByName")
+	if crossCheckEnabled.Load() {
+		return crossCheckValue("Value.MethodByName", func() Value { return value_MethodByName(v, name) }, func() reflect.Value { return toRV(v).MethodByName(name) })
+	}
 	return value_MethodByName(v, name)
 }
 
+// MethodByNameErr is like MethodByName, but returns a *LookupError naming
+// the method and the type that was searched if no method was found.
+func (v Value) MethodByNameErr(name string) (Value, error) {
+	v.mustBeValid("reflect.Value.MethodByNameErr")
+	if m := v.MethodByName(name); m.flag != 0 {
+		return m, nil
+	}
+	return Value{}, &LookupError{Lookup: "MethodByName", Name: name, Type: v.typ}
+}
+
+// MethodIfNotNil returns the method of v with the given name, as
+// MethodByName does, and true. It returns the zero Value and false if v is
+// a nil interface or nil pointer, where calling the method would panic or
+// run with a nil receiver, or if there is no such method.
+// It does not allocate when it returns false.
+func (v Value) MethodIfNotNil(name string) (Value, bool) {
+	switch v.Kind() {
+	case Invalid:
+		return Value{}, false
+	case Interface, Ptr:
+		if v.IsNil() {
+			return Value{}, false
+		}
+	}
+	i, ok := v.Type().MethodIndexByName(name)
+	if !ok {
+		return Value{}, false
+	}
+	return value_Method(v, i), true
+}
+
+// CallMethodByName calls the method of v with the given name with the
+// input arguments in, as v.MethodByName(name).Call(in) does, and panics
+// in the same way, except that a zero v is reported against
+// CallMethodByName itself. The method is found through the cached method index
+// of v's type rather than by a search of its methods, which allocates.
+func (v Value) CallMethodByName(name string, in []Value) []Value {
+	v.mustBeValid("reflect.Value.CallMethodByName")
+	if crossCheckEnabled.Load() {
+		return v.MethodByName(name).Call(in)
+	}
+	i, ok := 0, false
+	if v.flag&flagMethod == 0 {
+		i, ok = v.typ.MethodIndexByName(name)
+	}
+	if !ok {
+		panicZeroValue("reflect.Value.Call")
+	}
+	m := value_Method(v, i)
+	mustBeCallable("Call", m, in)
+	return value_Call(m, in)
+}
+
 // NumField returns the number of fields in the struct v.
 // It panics if v's Kind is not Struct.
 func (v Value) NumField() int {
+	v.mustBeValid("reflect.Value.NumField")
 	return value_NumField(v)
 }
 
 // NumMethod returns the number of exported methods in the value's method set.
 func (v Value) NumMethod() int {
+	v.mustBeValid("reflect.Value.NumMethod")
 	return value_NumMethod(v)
 }
 
 // OverflowComplex reports whether the complex128 x cannot be represented by v's type.
 // It panics if v's Kind is not Complex64 or Complex128.
 func (v Value) OverflowComplex(x complex128) bool {
+	v.mustBeValid("reflect.Value.OverflowComplex")
 	return value_OverflowComplex(v, x)
 }
 
 // OverflowFloat reports whether the float64 x cannot be represented by v's type.
 // It panics if v's Kind is not Float32 or Float64.
 func (v Value) OverflowFloat(x float64) bool {
+	v.mustBeValid("reflect.Value.OverflowFloat")
 	return value_OverflowFloat(v, x)
 }
 
 // OverflowInt reports whether the int64 x cannot be represented by v's type.
 // It panics if v's Kind is not Int, Int8, Int16, Int32, or Int64.
 func (v Value) OverflowInt(x int64) bool {
+	v.mustBeValid("reflect.Value.OverflowInt")
 	return value_OverflowInt(v, x)
 }
 
 // OverflowUint reports whether the uint64 x cannot be represented by v's type.
 // It panics if v's Kind is not Uint, Uintptr, Uint8, Uint16, Uint32, or Uint64.
 func (v Value) OverflowUint(x uint64) bool {
+	v.mustBeValid("reflect.Value.OverflowUint")
 	return value_OverflowUint(v, x)
 }
 
@@ -1030,11 +1954,14 @@ func (v Value) OverflowUint(x uint64) bool {
 // code pointer, but not necessarily enough to identify a
 // single function uniquely. The only guarantee is that the
 // result is zero if and only if v is a nil func Value.
+// In particular, all functions created by MakeFunc share one code
+// pointer; use FuncID to tell functions apart.
 //
 // If v's Kind is Slice, the returned pointer is to the first
 // element of the slice. If the slice is nil the returned value
 // is 0.  If the slice is empty but non-nil the return value is non-zero.
 func (v Value) Pointer() uintptr {
+	v.mustBeValid("reflect.Value.Pointer")
 	return value_Pointer(v)
 }
 
@@ -1044,6 +1971,7 @@ func (v Value) Pointer() uintptr {
 // The boolean value ok is true if the value x corresponds to a send
 // on the channel, false if it is a zero value received because the channel is closed.
 func (v Value) Recv() (Value, bool) {
+	v.mustBeValid("reflect.Value.Recv")
 	return value_Recv(v)
 }
 
@@ -1051,58 +1979,80 @@ func (v Value) Recv() (Value, bool) {
 // It panics if v's kind is not Chan or if x's type is not the same type as v's element type.
 // As in Go, x's value must be assignable to the channel's element type.
 func (v Value) Send(x Value) {
+	v.mustBeValid("reflect.Value.Send")
 	value_Send(v, x)
+	v.traceWrite(WriteSend)
 }
 
 // Set assigns x to the value v.
 // It panics if CanSet returns false.
-// As in Go, x's value must be assignable to v's type.
+// As in Go, x's value must be assignable to v's type; see ExplainAssign
+// for the panic when x's type is distinct from but prints the same as v's.
 func (v Value) Set(x Value) {
+	v.mustBeValid("reflect.Value.Set")
+	if v.typ != nil {
+		mustBeAssignableLookalike("Set", x, v.typ)
+	}
 	value_Set(v, x)
+	v.traceWrite(WriteSet)
 }
 
 // SetBool sets v's underlying value.
 // It panics if v's Kind is not Bool or if CanSet() is false.
 func (v Value) SetBool(x bool) {
+	v.mustBeValid("reflect.Value.SetBool")
 	value_SetBool(v, x)
+	v.traceWrite(WriteSetBool)
 }
 
 // SetBytes sets v's underlying value.
 // It panics if v's underlying value is not a slice of bytes.
 func (v Value) SetBytes(x []byte) {
+	v.mustBeValid("reflect.Value.SetBytes")
 	value_SetBytes(v, x)
+	v.traceWrite(WriteSetBytes)
 }
 
 // SetCap sets v's capacity to n.
 // It panics if v's Kind is not Slice or if n is smaller than the length or
 // greater than the capacity of the slice.
 func (v Value) SetCap(n int) {
+	v.mustBeValid("reflect.Value.SetCap")
 	value_SetCap(v, n)
+	v.traceWrite(WriteSetCap)
 }
 
 // SetComplex sets v's underlying value to x.
 // It panics if v's Kind is not Complex64 or Complex128, or if CanSet() is false.
 func (v Value) SetComplex(x complex128) {
+	v.mustBeValid("reflect.Value.SetComplex")
 	value_SetComplex(v, x)
+	v.traceWrite(WriteSetComplex)
 }
 
 // SetFloat sets v's underlying value to x.
 // It panics if v's Kind is not Float32 or Float64, or if CanSet() is false.
 func (v Value) SetFloat(x float64) {
+	v.mustBeValid("reflect.Value.SetFloat")
 	value_SetFloat(v, x)
+	v.traceWrite(WriteSetFloat)
 }
 
 // SetInt sets v's underlying value to x.
 // It panics if v's Kind is not Int, Int8, Int16, Int32, or Int64, or if CanSet() is false.
 func (v Value) SetInt(x int64) {
+	v.mustBeValid("reflect.Value.SetInt")
 	value_SetInt(v, x)
+	v.traceWrite(WriteSetInt)
 }
 
 // SetLen sets v's length to n.
 // It panics if v's Kind is not Slice or if n is negative or
 // greater than the capacity of the slice.
 func (v Value) SetLen(n int) {
+	v.mustBeValid("reflect.Value.SetLen")
 	value_SetLen(v, n)
+	v.traceWrite(WriteSetLen)
 }
 
 // SetMapIndex sets the element associated with key in the map v to elem.
@@ -1112,37 +2062,48 @@ func (v Value) SetLen(n int) {
 // As in Go, key's elem must be assignable to the map's key type,
 // and elem's value must be assignable to the map's elem type.
 func (v Value) SetMapIndex(key, elem Value) {
+	v.mustBeValid("reflect.Value.SetMapIndex")
 	value_SetMapIndex(v, key, elem)
+	v.traceWrite(WriteSetMapIndex)
 }
 
 // SetPointer sets the unsafe.Pointer value v to x.
 // It panics if v's Kind is not UnsafePointer.
 func (v Value) SetPointer(x unsafe.Pointer) {
+	v.mustBeValid("reflect.Value.SetPointer")
 	value_SetPointer(v, x)
+	v.traceWrite(WriteSetPointer)
 }
 
 // SetString sets v's underlying value to x.
 // It panics if v's Kind is not String or if CanSet() is false.
 func (v Value) SetString(x string) {
+	v.mustBeValid("reflect.Value.SetString")
 	value_SetString(v, x)
+	v.traceWrite(WriteSetString)
 }
 
 // SetUint sets v's underlying value to x.
 // It panics if v's Kind is not Uint, Uintptr, Uint8, Uint16, Uint32, or Uint64, or if CanSet() is false.
 func (v Value) SetUint(x uint64) {
+	v.mustBeValid("reflect.Value.SetUint")
 	value_SetUint(v, x)
+	v.traceWrite(WriteSetUint)
 }
 
 // SetZero sets v to be the zero value of v's type.
 // It panics if CanSet returns false.
 func (v Value) SetZero() {
+	v.mustBeValid("reflect.Value.SetZero")
 	value_SetZero(v)
+	v.traceWrite(WriteSetZero)
 }
 
 // Slice returns v[i:j].
 // It panics if v's Kind is not Array, Slice or String, or if v is an unaddressable array,
 // or if the indexes are out of bounds.
 func (v Value) Slice(i, j int) Value {
+	v.mustBeValid("reflect.Value.Slice")
 	return value_Slice(v, i, j)
 }
 
@@ -1150,6 +2111,7 @@ func (v Value) Slice(i, j int) Value {
 // It panics if v's Kind is not Array or Slice, or if v is an unaddressable array,
 // or if the indexes are out of bounds.
 func (v Value) Slice3(i, j, k int) Value {
+	v.mustBeValid("reflect.Value.Slice3")
 	return value_Slice3(v, i, j, k)
 }
 
@@ -1169,6 +2131,7 @@ func (v Value) String() string {
 // If the receive cannot finish without blocking, x is the zero Value and ok is false.
 // If the channel is closed, x is the zero value for the channel's element type and ok is false.
 func (v Value) TryRecv() (Value, bool) {
+	v.mustBeValid("reflect.Value.TryRecv")
 	return value_TryRecv(v)
 }
 
@@ -1177,17 +2140,24 @@ func (v Value) TryRecv() (Value, bool) {
 // It reports whether the value was sent.
 // As in Go, x's value must be assignable to the channel's element type.
 func (v Value) TrySend(x Value) bool {
-	return value_TrySend(v, x)
+	v.mustBeValid("reflect.Value.TrySend")
+	if !value_TrySend(v, x) {
+		return false
+	}
+	v.traceWrite(WriteTrySend)
+	return true
 }
 
 // Type returns v's type.
 func (v Value) Type() Type {
+	v.mustBeValid("reflect.Value.Type")
 	return value_Type(v)
 }
 
 // Uint returns v's underlying value, as a uint64.
 // It panics if v's Kind is not Uint, Uintptr, Uint8, Uint16, Uint32, or Uint64.
 func (v Value) Uint() uint64 {
+	v.mustBeValid("reflect.Value.Uint")
 	return value_Uint(v)
 }
 
@@ -1200,5 +2170,6 @@ func (v Value) Uint() uint64 {
 // It is for advanced clients that also import the "unsafe" package.
 // It panics if v is not addressable.
 func (v Value) UnsafeAddr() uintptr {
+	v.mustBeValid("reflect.Value.UnsafeAddr")
 	return value_UnsafeAddr(v)
 }
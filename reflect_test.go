@@ -1,8 +1,14 @@
 package reflect_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	corereflect "reflect"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 	"unsafe"
 
@@ -128,3 +134,935 @@ func TestValueFieldByNameFunc(t *testing.T) {
 		t.Fatal("failed to FieldByNameFunc")
 	}
 }
+
+func TestInterfaceNoAlloc(t *testing.T) {
+	x := 10
+	i, ok := reflect.ValueOf(&struct{ P *int }{&x}).Elem().Field(0).InterfaceNoAlloc()
+	if !ok || i.(*int) != &x {
+		t.Fatal("failed to get pointer-shaped interface without copy")
+	}
+	m := map[string]int{"a": 1}
+	if i, ok := reflect.ValueOf(m).InterfaceNoAlloc(); !ok || i.(map[string]int)["a"] != 1 {
+		t.Fatal("failed to get map interface without copy")
+	}
+	if _, ok := reflect.ValueOf(x).InterfaceNoAlloc(); ok {
+		t.Fatal("int must require a copy")
+	}
+	if _, ok := reflect.ValueOf(&x).MethodByName("Missing").InterfaceNoAlloc(); ok {
+		t.Fatal("zero Value must not be packed")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("did not panic on unexported field")
+		}
+	}()
+	reflect.ValueOf(struct{ p *int }{&x}).Field(0).InterfaceNoAlloc()
+}
+
+func TestCheckCompat(t *testing.T) {
+	err := reflect.CheckCompat()
+	if !reflect.SimulateCompatMismatch {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatal("expected error for simulated layout mismatch")
+	}
+	for _, want := range []string{"go-reflect is incompatible with this Go version", "simulated layout mismatch"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestCompatProbes(t *testing.T) {
+	results := map[string]bool{}
+	for _, name := range reflect.CompatProbeNames() {
+		results[name] = true
+	}
+	if err := reflect.RunCompatProbes(results); err != nil {
+		t.Fatal(err)
+	}
+	results["Value size and alignment"] = false
+	results["TypeAndPtrOf sentinel"] = false
+	err := reflect.RunCompatProbes(results)
+	if err == nil {
+		t.Fatal("expected error for failed probes")
+	}
+	msg := err.Error()
+	for _, want := range []string{"incompatible with this Go version", runtime.Version(), "Value size and alignment", "TypeAndPtrOf sentinel"} {
+		if !strings.Contains(msg, want) {
+			t.Fatalf("error %q does not contain %q", msg, want)
+		}
+	}
+	if strings.Contains(msg, "Value flag round-trip") {
+		t.Fatalf("error %q lists a passing probe", msg)
+	}
+}
+
+type rangeFieldsT struct {
+	F0, F1, F2, F3, F4, F5, F6, F7, F8, F9           int    `r:"f"`
+	F10, F11, F12, F13, F14, F15, F16, F17, F18, F19 string `r:"s"`
+}
+
+func TestRangeFields(t *testing.T) {
+	typ := reflect.TypeOf(rangeFieldsT{})
+	n := 0
+	typ.RangeFields(func(i int, name string, ft reflect.Type, offset uintptr, tag reflect.StructTag) bool {
+		f := typ.Field(i)
+		if name != f.Name || ft != f.Type || offset != f.Offset || tag != f.Tag {
+			t.Fatalf("field %d: got %s %s %d %q, want %s %s %d %q", i, name, ft, offset, tag, f.Name, f.Type, f.Offset, f.Tag)
+		}
+		n++
+		return true
+	})
+	if n != typ.NumField() {
+		t.Fatalf("visited %d fields, want %d", n, typ.NumField())
+	}
+	n = 0
+	typ.RangeFields(func(int, string, reflect.Type, uintptr, reflect.StructTag) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Fatal("failed to stop iteration")
+	}
+	if allocs := testing.AllocsPerRun(100, func() {
+		typ.RangeFields(func(int, string, reflect.Type, uintptr, reflect.StructTag) bool { return true })
+	}); allocs > 0 {
+		t.Fatal("allocs:", allocs)
+	}
+}
+
+func BenchmarkRangeFields(b *testing.B) {
+	typ := reflect.TypeOf(rangeFieldsT{})
+	b.Run("Field", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for i := 0; i < typ.NumField(); i++ {
+				_ = typ.Field(i)
+			}
+		}
+	})
+	b.Run("RangeFields", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			typ.RangeFields(func(int, string, reflect.Type, uintptr, reflect.StructTag) bool { return true })
+		}
+	})
+}
+
+func TestMapIterTypes(t *testing.T) {
+	m := map[string]float64{"a": 1}
+	check := func(it *reflect.MapIter) {
+		t.Helper()
+		if reflect.MapIterKeyType(it) != reflect.TypeOf("") {
+			t.Fatal("failed to get key type")
+		}
+		if reflect.MapIterValueType(it) != reflect.TypeOf(float64(0)) {
+			t.Fatal("failed to get value type")
+		}
+		if reflect.MapIterMap(it).Len() != 1 {
+			t.Fatal("failed to get map value")
+		}
+	}
+	it := reflect.ValueOf(m).MapRange()
+	check(it)
+	for it.Next() {
+	}
+	check(it)
+	it.Reset(corereflect.ValueOf(map[string]float64{"b": 2}))
+	check(it)
+
+	for _, f := range []func(*reflect.MapIter){
+		func(it *reflect.MapIter) { reflect.MapIterKeyType(it) },
+		func(it *reflect.MapIter) { reflect.MapIterValueType(it) },
+		func(it *reflect.MapIter) { reflect.MapIterMap(it) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("did not panic on zero MapIter")
+				}
+			}()
+			f(new(reflect.MapIter))
+		}()
+	}
+}
+
+func TestMapIterBridge(t *testing.T) {
+	m := map[int]string{1: "a", 2: "b", 3: "c", 4: "d"}
+	seen := map[int]int{}
+
+	rit := corereflect.ValueOf(m).MapRange()
+	if !rit.Next() {
+		t.Fatal("failed to start iteration")
+	}
+	seen[int(rit.Key().Int())]++
+	it := reflect.ToMapIter(rit)
+	for it.Next() {
+		seen[int(it.Key().Int())]++
+		if reflect.ToReflectMapIter(it) != rit {
+			t.Fatal("bridged iterators must share their position")
+		}
+	}
+	if len(seen) != len(m) {
+		t.Fatalf("saw %v, want all keys of %v", seen, m)
+	}
+	for k, n := range seen {
+		if n != 1 {
+			t.Fatalf("key %d seen %d times", k, n)
+		}
+	}
+
+	clear(seen)
+	it = reflect.MapRangeFromReflect(corereflect.ValueOf(m))
+	it.Next()
+	seen[int(it.Key().Int())]++
+	rit = reflect.ToReflectMapIter(it)
+	for rit.Next() {
+		seen[int(rit.Key().Int())]++
+	}
+	if len(seen) != len(m) {
+		t.Fatalf("saw %v, want all keys of %v", seen, m)
+	}
+	for k, n := range seen {
+		if n != 1 {
+			t.Fatalf("key %d seen %d times", k, n)
+		}
+	}
+}
+
+type greeter interface {
+	Greet(name string) string
+}
+
+type greeterFunc func(name string) string
+
+func (f greeterFunc) Greet(name string) string { return f(name) }
+
+func TestMakeFuncNamedFuncType(t *testing.T) {
+	fv := reflect.MakeFunc(reflect.TypeOf(greeterFunc(nil)), func(in []reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.ValueOf("hello " + in[0].String())}
+	})
+	if !fv.Type().Implements(reflect.TypeOf((*greeter)(nil)).Elem()) {
+		t.Fatal("MakeFunc result does not implement greeter")
+	}
+	if got := fv.Interface().(greeter).Greet("gopher"); got != "hello gopher" {
+		t.Fatalf("got %q", got)
+	}
+	out := fv.MethodByName("Greet").Call([]reflect.Value{reflect.ValueOf("reflect")})
+	if out[0].String() != "hello reflect" {
+		t.Fatalf("got %q", out[0].String())
+	}
+}
+
+func TestRangeMap(t *testing.T) {
+	m := make(map[string]int, 1000)
+	for i := 0; i < 1000; i++ {
+		m[fmt.Sprint(i)] = i
+	}
+	v := reflect.ValueOf(m)
+	seen := 0
+	v.RangeMap(func(key, val reflect.Value) bool {
+		if m[key.String()] != int(val.Int()) {
+			t.Fatalf("%s: got %d", key.String(), val.Int())
+		}
+		key.SetString("mutated")
+		val.SetInt(-1)
+		seen++
+		return true
+	})
+	if seen != len(m) {
+		t.Fatalf("visited %d entries, want %d", seen, len(m))
+	}
+	if _, ok := m["mutated"]; ok || m["1"] != 1 {
+		t.Fatal("mutating the yielded Values changed the map")
+	}
+	seen = 0
+	v.RangeMap(func(key, val reflect.Value) bool {
+		seen++
+		return false
+	})
+	if seen != 1 {
+		t.Fatal("failed to stop iteration")
+	}
+	reflect.ValueOf(map[int]int(nil)).RangeMap(func(key, val reflect.Value) bool {
+		t.Fatal("iterated over nil map")
+		return true
+	})
+	if allocs := testing.AllocsPerRun(10, func() {
+		v.RangeMap(func(key, val reflect.Value) bool { return true })
+	}); allocs > 5 {
+		t.Fatal("allocs:", allocs)
+	}
+}
+
+type lookupT struct{ A int }
+
+func (lookupT) Foo() {}
+
+func TestLookupError(t *testing.T) {
+	v := reflect.ValueOf(lookupT{})
+	if m := v.MethodByName("Bar"); m != (reflect.Value{}) {
+		t.Fatalf("failed MethodByName = %v, want the zero Value", m)
+	}
+	if f := v.FieldByName("B"); f != (reflect.Value{}) {
+		t.Fatalf("failed FieldByName = %v, want the zero Value", f)
+	}
+	for _, tc := range []struct {
+		lookup func() (reflect.Value, error)
+		want   string
+	}{
+		{func() (reflect.Value, error) { return v.MethodByNameErr("Bar") },
+			`reflect: MethodByName("Bar") on reflect_test.lookupT: no such method`},
+		{func() (reflect.Value, error) { return v.FieldByNameErr("B") },
+			`reflect: FieldByName("B") on reflect_test.lookupT: no such field`},
+	} {
+		got, err := tc.lookup()
+		var lerr *reflect.LookupError
+		if got.IsValid() || !errors.As(err, &lerr) || lerr.Type != v.Type() {
+			t.Fatalf("lookup = %v, %v, want the zero Value and a *LookupError", got, err)
+		}
+		if err.Error() != tc.want {
+			t.Errorf("got %q, want %q", err.Error(), tc.want)
+		}
+	}
+	if m, err := v.MethodByNameErr("Foo"); err != nil || !m.IsValid() {
+		t.Fatalf("MethodByNameErr(Foo) = %v, %v", m, err)
+	}
+	if f, err := v.FieldByNameErr("A"); err != nil || !f.IsValid() {
+		t.Fatalf("FieldByNameErr(A) = %v, %v", f, err)
+	}
+	if !v.MethodByName("Foo").IsValid() || !v.FieldByName("A").IsValid() {
+		t.Fatal("failed to look up existing members")
+	}
+	if !reflect.CrossCheckEnabled() {
+		if n := testing.AllocsPerRun(100, func() { v.FieldByName("B") }); n != 0 {
+			t.Errorf("failed FieldByName: %v allocs, want 0", n)
+		}
+	}
+}
+
+func TestZeroValuePanics(t *testing.T) {
+	var v reflect.Value
+	typ := reflect.TypeOf(0)
+	for name, f := range map[string]func(){
+		"Addr":            func() { v.Addr() },
+		"Int":             func() { v.Int() },
+		"Convert":         func() { v.Convert(typ) },
+		"CanConvert":      func() { v.CanConvert(typ) },
+		"FieldByName":     func() { v.FieldByName("A") },
+		"FieldByNameFunc": func() { v.FieldByNameFunc(func(string) bool { return true }) },
+		"MapKeysSorted":   func() { v.MapKeysSorted() },
+		"RangeMap":        func() { v.RangeMap(nil) },
+		"SetInt":          func() { v.SetInt(1) },
+		"Strings":         func() { v.Strings() },
+		"Type":            func() { v.Type() },
+	} {
+		func() {
+			defer func() {
+				err, ok := recover().(*reflect.ValueError)
+				if !ok || err.Kind != reflect.Invalid {
+					t.Errorf("%s: panicked with %v, want *ValueError of Kind Invalid", name, err)
+					return
+				}
+				if want := "reflect: call of reflect.Value." + name + " on zero Value"; err.Error() != want {
+					t.Errorf("%s: panicked with %q, want %q", name, err.Error(), want)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	m := reflect.ValueOf(map[string]int{"a": 1})
+	def := reflect.ValueOf(-1)
+	if got := m.MapIndex(reflect.ValueOf("a")).OrElse(def).Int(); got != 1 {
+		t.Errorf("OrElse of present key = %d, want 1", got)
+	}
+	if got := m.MapIndex(reflect.ValueOf("b")).OrElse(def).Int(); got != -1 {
+		t.Errorf("OrElse of missing key = %d, want -1", got)
+	}
+	if got := reflect.ValueOf(lookupT{}).FieldByName("B").OrElse(def).Int(); got != -1 {
+		t.Errorf("OrElse of failed lookup = %d, want -1", got)
+	}
+	if got := (reflect.Value{}).OrElse(reflect.Value{}); got.IsValid() {
+		t.Errorf("OrElse of two zero Values = %v, want zero Value", got)
+	}
+	var p *int
+	if got := reflect.ValueOf(p).OrElse(def); got.Kind() != reflect.Ptr || !got.IsNil() {
+		t.Errorf("OrElse of nil pointer = %v, want the nil pointer", got)
+	}
+}
+
+type methods30 struct{}
+
+func (methods30) M00() {}
+func (methods30) M01() {}
+func (methods30) M02() {}
+func (methods30) M03() {}
+func (methods30) M04() {}
+func (methods30) M05() {}
+func (methods30) M06() {}
+func (methods30) M07() {}
+func (methods30) M08() {}
+func (methods30) M09() {}
+func (methods30) M10() {}
+func (methods30) M11() {}
+func (methods30) M12() {}
+func (methods30) M13() {}
+func (methods30) M14() {}
+func (methods30) M15() {}
+func (methods30) M16() {}
+func (methods30) M17() {}
+func (methods30) M18() {}
+func (methods30) M19() {}
+func (methods30) M20() {}
+func (methods30) M21() {}
+func (methods30) M22() {}
+func (methods30) M23() {}
+func (methods30) M24() {}
+func (methods30) M25() {}
+func (methods30) M26() {}
+func (methods30) M27() {}
+func (methods30) M28() {}
+func (methods30) M29() {}
+
+func TestMethodIndexByName(t *testing.T) {
+	for _, typ := range []reflect.Type{
+		reflect.TypeOf(methods30{}),
+		reflect.TypeOf(&methods30{}),
+		reflect.TypeOf((*greeter)(nil)).Elem(),
+	} {
+		for i := 0; i < typ.NumMethod(); i++ {
+			m := typ.Method(i)
+			idx, ok := typ.MethodIndexByName(m.Name)
+			if !ok || idx != i {
+				t.Fatalf("%s.%s: got %d, %v, want %d", typ, m.Name, idx, ok, i)
+			}
+		}
+		if _, ok := typ.MethodIndexByName("Missing"); ok {
+			t.Fatalf("%s: found missing method", typ)
+		}
+	}
+	v := reflect.ValueOf(greeterFunc(func(name string) string { return name }))
+	i, _ := v.Type().MethodIndexByName("Greet")
+	if out := v.Method(i).Call([]reflect.Value{reflect.ValueOf("x")}); out[0].String() != "x" {
+		t.Fatal("failed to call method by cached index")
+	}
+	typ := reflect.TypeOf(methods30{})
+	if allocs := testing.AllocsPerRun(100, func() { typ.MethodIndexByName("M29") }); allocs > 0 {
+		t.Fatal("allocs:", allocs)
+	}
+}
+
+func BenchmarkMethodIndexByName(b *testing.B) {
+	typ := reflect.TypeOf(methods30{})
+	b.Run("MethodByName", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			typ.MethodByName("M29")
+		}
+	})
+	b.Run("MethodIndexByName", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			typ.MethodIndexByName("M29")
+		}
+	})
+}
+
+func anonStructA() any { return struct{ X, Y int }{} }
+
+func anonStructB() any { return struct{ X, Y int }{} }
+
+func TestTypeIDStability(t *testing.T) {
+	a, b := anonStructA(), anonStructB()
+	if reflect.TypeID(a) != reflect.TypeID(b) {
+		t.Fatal("identical anonymous struct types must share a TypeID")
+	}
+	if reflect.TypeIDUint64(a) != uint64(reflect.TypeID(b)) {
+		t.Fatal("failed to get 64-bit TypeID")
+	}
+	if reflect.TypeIDFingerprint(a) != reflect.TypeIDFingerprint(b) {
+		t.Fatal("identical anonymous struct types must share a fingerprint")
+	}
+	distinct := []any{0, int8(0), uint(0), "", []int{}, [1]int{}, struct{ X, Z int }{}, struct{ X, Y int8 }{}, &struct{ X, Y int }{}}
+	ids := map[uintptr]bool{reflect.TypeID(a): true}
+	fps := map[uint64]bool{reflect.TypeIDFingerprint(a): true}
+	for _, v := range distinct {
+		if ids[reflect.TypeID(v)] {
+			t.Fatalf("%T shares a TypeID", v)
+		}
+		ids[reflect.TypeID(v)] = true
+		if fps[reflect.TypeIDFingerprint(v)] {
+			t.Fatalf("%T shares a fingerprint", v)
+		}
+		fps[reflect.TypeIDFingerprint(v)] = true
+	}
+	if reflect.TypeID(nil) != 0 || reflect.TypeIDFingerprint(nil) != 0 {
+		t.Fatal("nil must have zero identifiers")
+	}
+}
+
+// typeIDTable returns the TypeIDs of n distinct types, in no particular
+// order, and the types of n more that are not among them.
+func typeIDTable(n int) (ids, absent []uintptr) {
+	for i := 0; i < 2*n; i++ {
+		id := reflect.TypeID(reflect.Zero(reflect.ArrayOf(i, reflect.TypeOf(""))).Interface())
+		if i%2 == 0 {
+			ids = append(ids, id)
+		} else {
+			absent = append(absent, id)
+		}
+	}
+	return ids, absent
+}
+
+func TestSearchTypeID(t *testing.T) {
+	ids, absent := typeIDTable(50)
+	oracle := make(map[uintptr]int)
+	for i, id := range ids {
+		oracle[id] = i
+	}
+	table := slices.Clone(ids)
+	reflect.SortTypeIDs(table)
+	for i := 1; i < len(table); i++ {
+		if !reflect.TypeIDLess(table[i-1], table[i]) || reflect.TypeIDLess(table[i], table[i-1]) {
+			t.Fatalf("SortTypeIDs: %#x and %#x out of order", table[i-1], table[i])
+		}
+	}
+	// A dispatch table indexed like the sorted IDs.
+	handlers := make([]int, len(table))
+	for i, id := range table {
+		handlers[i] = oracle[id]
+	}
+	for _, id := range append(ids, absent...) {
+		i, ok := reflect.SearchTypeID(table, id)
+		want, wantOK := oracle[id]
+		if ok != wantOK || ok && handlers[i] != want {
+			t.Errorf("SearchTypeID(%#x) = %d, %v; want handler %d, %v", id, i, ok, want, wantOK)
+		}
+		if !ok && (i < len(table) && !reflect.TypeIDLess(id, table[i]) || i > 0 && !reflect.TypeIDLess(table[i-1], id)) {
+			t.Errorf("SearchTypeID(%#x) = %d, not the insertion point", id, i)
+		}
+	}
+	if reflect.TypeIDLess(ids[0], ids[0]) {
+		t.Error("TypeIDLess is not irreflexive")
+	}
+}
+
+func BenchmarkSearchTypeID(b *testing.B) {
+	for _, n := range []int{8, 32, 128} {
+		ids, _ := typeIDTable(n)
+		m := make(map[uintptr]int, n)
+		for i, id := range ids {
+			m[id] = i
+		}
+		table := slices.Clone(ids)
+		reflect.SortTypeIDs(table)
+		b.Run(fmt.Sprintf("SearchTypeID/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, ok := reflect.SearchTypeID(table, ids[i%n]); !ok {
+					b.Fatal("not found")
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("map/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, ok := m[ids[i%n]]; !ok {
+					b.Fatal("not found")
+				}
+			}
+		})
+	}
+}
+
+func TestNilTypeConstructors(t *testing.T) {
+	nilType := reflect.TypeOf(nil)
+	intType := reflect.TypeOf(0)
+	for name, f := range map[string]func(){
+		"ArrayOf":         func() { reflect.ArrayOf(1, nilType) },
+		"ChanOf":          func() { reflect.ChanOf(reflect.BothDir, nilType) },
+		"FuncOf":          func() { reflect.FuncOf([]reflect.Type{intType, nilType}, nil, false) },
+		"MapOf":           func() { reflect.MapOf(intType, nilType) },
+		"PtrTo":           func() { reflect.PtrTo(nilType) },
+		"SliceOf":         func() { reflect.SliceOf(nilType) },
+		"StructOf":        func() { reflect.StructOf([]reflect.StructField{{Name: "A", Type: nilType}}) },
+		"MakeChan":        func() { reflect.MakeChan(nilType, 0) },
+		"MakeFunc":        func() { reflect.MakeFunc(nilType, nil) },
+		"MakeMap":         func() { reflect.MakeMap(nilType) },
+		"MakeMapWithSize": func() { reflect.MakeMapWithSize(nilType, 1) },
+		"MakeSlice":       func() { reflect.MakeSlice(nilType, 0, 0) },
+		"New":             func() { reflect.New(nilType) },
+		"NewAt":           func() { reflect.NewAt(nilType, nil) },
+		"Zero":            func() { reflect.Zero(nilType) },
+	} {
+		func() {
+			defer func() {
+				want := "reflect: " + name + " of nil Type"
+				r := recover()
+				if e, ok := r.(*reflect.NilTypeError); !ok || e.Func != name || e.Error() != want {
+					t.Errorf("%s: got panic %v, want *NilTypeError %q", name, r, want)
+				}
+			}()
+			f()
+		}()
+	}
+
+	if _, err := reflect.TryZero(nilType); err == nil || err.Error() != "reflect: Zero of nil Type" {
+		t.Fatalf("TryZero: unexpected error %v", err)
+	}
+	if _, err := reflect.TryNew(nilType); err == nil || err.Error() != "reflect: New of nil Type" {
+		t.Fatalf("TryNew: unexpected error %v", err)
+	}
+	if _, err := reflect.TryNew(nilType); !errors.As(err, new(*reflect.NilTypeError)) {
+		t.Fatalf("TryNew: error %v is not a *NilTypeError", err)
+	}
+	if v, err := reflect.TryZero(intType); err != nil || v.Int() != 0 {
+		t.Fatal("failed to TryZero")
+	}
+	if v, err := reflect.TryNew(intType); err != nil || v.Elem().Int() != 0 {
+		t.Fatal("failed to TryNew")
+	}
+}
+
+func TestTypeAndDataOf(t *testing.T) {
+	x := 1
+	m := map[string]int{"a": 1}
+	ch := make(chan int)
+	fn := func() int { return 2 }
+	type large struct{ A, B, C int }
+
+	typ, data, indirect := reflect.TypeAndDataOf(&x)
+	if typ != reflect.TypeOf(&x) || indirect || (*int)(data) != &x {
+		t.Fatal("failed to get direct pointer data")
+	}
+	if _, data, indirect := reflect.TypeAndDataOf(m); indirect || *(*map[string]int)(unsafe.Pointer(&data)) == nil ||
+		(*(*map[string]int)(unsafe.Pointer(&data)))["a"] != 1 {
+		t.Fatal("failed to get direct map data")
+	}
+	if _, data, indirect := reflect.TypeAndDataOf(ch); indirect || *(*chan int)(unsafe.Pointer(&data)) != ch {
+		t.Fatal("failed to get direct chan data")
+	}
+	if _, data, indirect := reflect.TypeAndDataOf(fn); indirect || (*(*func() int)(unsafe.Pointer(&data)))() != 2 {
+		t.Fatal("failed to get direct func data")
+	}
+	if _, data, indirect := reflect.TypeAndDataOf(large{1, 2, 3}); !indirect || (*large)(data).C != 3 {
+		t.Fatal("failed to get indirect struct data")
+	}
+	if typ, data, indirect := reflect.TypeAndDataOf(nil); typ != nil || data != nil || indirect {
+		t.Fatal("failed to handle nil")
+	}
+	for _, c := range []struct {
+		v        any
+		indirect bool
+	}{
+		{&x, false}, {m, false}, {ch, false}, {fn, false}, {large{}, true},
+		{struct{ M map[string]int }{m}, false}, {struct{ M, N map[string]int }{}, true}, {0, true},
+	} {
+		if got := reflect.IfaceIndir(reflect.TypeOf(c.v)); got != c.indirect {
+			t.Errorf("IfaceIndir(%T) = %v, want %v", c.v, got, c.indirect)
+		}
+	}
+}
+
+type countWriter struct{ n *int }
+
+func (w countWriter) Write(p []byte) (int, error) {
+	*w.n += len(p)
+	return len(p), nil
+}
+
+type nopWriter struct{ a, b int }
+
+func (nopWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func TestIfaceWordsOf(t *testing.T) {
+	var buf bytes.Buffer
+	var sb strings.Builder
+	n := 0
+	for _, w := range []io.Writer{&buf, &sb, io.Discard, countWriter{&n}, nopWriter{1, 2}} {
+		typ, ptr := reflect.IfaceWordsOf(w)
+		wantTyp, wantPtr := reflect.TypeAndPtrOf(w)
+		if typ != reflect.TypeOf(w) || typ != wantTyp || ptr != wantPtr {
+			t.Errorf("IfaceWordsOf(%T) = %v, %p; want %v, %p", w, typ, ptr, wantTyp, wantPtr)
+		}
+	}
+	w := io.Writer(&buf)
+	if _, ptr := reflect.IfaceWordsOf(w); (*bytes.Buffer)(ptr) != &buf {
+		t.Error("IfaceWordsOf(*bytes.Buffer) does not return the pointer")
+	}
+	if _, ptr := reflect.IfaceWordsOf(io.Writer(nopWriter{1, 2})); *(*nopWriter)(ptr) != (nopWriter{1, 2}) {
+		t.Error("IfaceWordsOf(nopWriter) does not point to the value")
+	}
+	var rw io.ReadWriter = &buf
+	if typ, _ := reflect.IfaceWordsOf(rw); typ != reflect.TypeOf(&buf) {
+		t.Errorf("IfaceWordsOf(io.ReadWriter) = %v, want *bytes.Buffer", typ)
+	}
+	if typ, ptr := reflect.IfaceWordsOf[io.Writer](nil); typ != nil || ptr != nil {
+		t.Errorf("IfaceWordsOf(nil io.Writer) = %v, %p; want nil, nil", typ, ptr)
+	}
+	if typ, ptr := reflect.IfaceWordsOf[any](nil); typ != nil || ptr != nil {
+		t.Errorf("IfaceWordsOf(nil any) = %v, %p; want nil, nil", typ, ptr)
+	}
+	if typ, _ := reflect.IfaceWordsOf[any](&n); typ != reflect.TypeOf(&n) {
+		t.Errorf("IfaceWordsOf(any(*int)) = %v, want *int", typ)
+	}
+	if typ, ptr := reflect.IfaceWordsOf(&n); typ != reflect.TypeOf(&n) || (*int)(ptr) != &n {
+		t.Errorf("IfaceWordsOf(*int) = %v, %p; want *int, %p", typ, ptr, &n)
+	}
+	if !reflect.CrossCheckEnabled() {
+		if allocs := testing.AllocsPerRun(100, func() { reflect.IfaceWordsOf(w) }); allocs != 0 {
+			t.Errorf("IfaceWordsOf(io.Writer) allocates %v times", allocs)
+		}
+	}
+}
+
+func TestFieldPointer(t *testing.T) {
+	s := struct {
+		A int8
+		B string
+		c int64
+	}{}
+	v := reflect.ValueOf(&s).Elem()
+	*(*string)(v.FieldPointer(1)) = "b"
+	*(*int64)(v.FieldPointer(2)) = 3
+	if v.Field(1).String() != "b" || v.Field(2).Int() != 3 {
+		t.Fatal("failed to write through FieldPointer")
+	}
+	*(*int8)(reflect.FieldPointer(unsafe.Pointer(&s), v.Type().Field(0))) = 1
+	if s.A != 1 {
+		t.Fatal("failed to write through package-level FieldPointer")
+	}
+	for _, f := range []func(){
+		func() { reflect.ValueOf(&s).FieldPointer(0) },
+		func() { v.FieldPointer(3) },
+		func() { v.FieldPointer(-1) },
+		func() { reflect.ValueOf(s).FieldPointer(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("did not panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+type fields50 struct {
+	F00 int
+	F01 int
+	F02 int
+	F03 int
+	F04 int
+	F05 int
+	F06 int
+	F07 int
+	F08 int
+	F09 int
+	F10 int
+	F11 int
+	F12 int
+	F13 int
+	F14 int
+	F15 int
+	F16 int
+	F17 int
+	F18 int
+	F19 int
+	F20 int
+	F21 int
+	F22 int
+	F23 int
+	F24 int
+	F25 int
+	F26 int
+	F27 int
+	F28 int
+	F29 int
+	F30 int
+	F31 int
+	F32 int
+	F33 int
+	F34 int
+	F35 int
+	F36 int
+	F37 int
+	F38 int
+	F39 int
+	F40 int
+	F41 int
+	F42 int
+	F43 int
+	F44 int
+	F45 int
+	F46 int
+	F47 int
+	F48 int
+	F49 int
+}
+
+func TestFieldOffsets(t *testing.T) {
+	typ := reflect.TypeOf(fields50{})
+	offsets := reflect.FieldOffsets(typ)
+	if len(offsets) != typ.NumField() {
+		t.Fatalf("got %d offsets, want %d", len(offsets), typ.NumField())
+	}
+	for i, off := range offsets {
+		if off != typ.Field(i).Offset {
+			t.Fatalf("field %d: got offset %d, want %d", i, off, typ.Field(i).Offset)
+		}
+	}
+	if &reflect.FieldOffsets(typ)[0] != &offsets[0] {
+		t.Fatal("offsets must be cached")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("did not panic on non-struct type")
+		}
+	}()
+	reflect.FieldOffsets(reflect.TypeOf(0))
+}
+
+func BenchmarkFieldOffsets(b *testing.B) {
+	typ := reflect.TypeOf(fields50{})
+	b.Run("Field", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			var sum uintptr
+			for i := 0; i < typ.NumField(); i++ {
+				sum += typ.Field(i).Offset
+			}
+		}
+	})
+	b.Run("FieldOffsets", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			var sum uintptr
+			for _, off := range reflect.FieldOffsets(typ) {
+				sum += off
+			}
+		}
+	})
+}
+
+func TestMethodBridge(t *testing.T) {
+	typ := reflect.TypeOf(Point{})
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		rm := reflect.ToReflectMethod(m)
+		if rm.Name != m.Name || rm.Index != m.Index || rm.Type != reflect.ToReflectType(m.Type) ||
+			rm.Func.Pointer() != m.Func.Pointer() {
+			t.Fatalf("%s: failed to convert to reflect.Method", m.Name)
+		}
+		back := reflect.ToMethod(rm)
+		if back.Name != m.Name || back.PkgPath != m.PkgPath || back.Index != m.Index || back.Type != m.Type ||
+			back.Func.Pointer() != m.Func.Pointer() {
+			t.Fatalf("%s: failed to round-trip Method", m.Name)
+		}
+	}
+	dist, _ := corereflect.TypeOf(Point{}).MethodByName("Dist")
+	m := reflect.ToMethod(dist)
+	if out := m.Func.Call([]reflect.Value{reflect.ValueOf(Point{1, 2}), reflect.ValueOf(3)}); out[0].Int() != 15 {
+		t.Fatalf("got %d", out[0].Int())
+	}
+}
+
+func TestValuesBridge(t *testing.T) {
+	vs := []reflect.Value{reflect.ValueOf(1), reflect.ValueOf("a")}
+	rvs := reflect.ToReflectValues(vs)
+	if len(rvs) != 2 || cap(rvs) != cap(vs) || rvs[0].Int() != 1 || rvs[1].String() != "a" {
+		t.Fatal("failed to convert to []reflect.Value")
+	}
+	rvs[0] = corereflect.ValueOf(2)
+	if vs[0].Int() != 2 {
+		t.Fatal("converted slice must share its backing array")
+	}
+	back := reflect.ToValues(rvs)
+	if len(back) != 2 || &back[0] != &vs[0] {
+		t.Fatal("failed to round-trip []Value")
+	}
+	if reflect.ToValues(nil) != nil || reflect.ToReflectValues(nil) != nil {
+		t.Fatal("nil slices must stay nil")
+	}
+	if n := testing.AllocsPerRun(100, func() { rvs = reflect.ToReflectValues(vs) }); n != 0 {
+		t.Fatalf("ToReflectValues allocated %v times", n)
+	}
+}
+
+type nilSafeT struct{}
+
+func (*nilSafeT) Ptr() string { return "ptr" }
+
+func TestMethodIfNotNil(t *testing.T) {
+	holder := struct {
+		G greeter
+		P *nilSafeT
+	}{}
+	v := reflect.ValueOf(&holder).Elem()
+	if _, ok := v.Field(0).MethodIfNotNil("Greet"); ok {
+		t.Fatal("nil interface must not yield a method")
+	}
+	if _, ok := v.Field(1).MethodIfNotNil("Ptr"); ok {
+		t.Fatal("nil pointer must not yield a method")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { v.Field(0).MethodIfNotNil("Greet") }); allocs > 0 && !reflect.CrossCheckEnabled() {
+		t.Fatal("allocs:", allocs)
+	}
+
+	holder.G = greeterFunc(func(name string) string { return "hi " + name })
+	holder.P = &nilSafeT{}
+	m, ok := v.Field(0).MethodIfNotNil("Greet")
+	if !ok || m.Call([]reflect.Value{reflect.ValueOf("x")})[0].String() != "hi x" {
+		t.Fatal("failed to call interface method")
+	}
+	m, ok = v.Field(1).MethodIfNotNil("Ptr")
+	if !ok || m.Call(nil)[0].String() != "ptr" {
+		t.Fatal("failed to call pointer method")
+	}
+	if _, ok := v.Field(1).MethodIfNotNil("Missing"); ok {
+		t.Fatal("found missing method")
+	}
+	if _, ok := reflect.ValueOf(Point{}).MethodIfNotNil("Dist"); !ok {
+		t.Fatal("failed to get value method")
+	}
+}
+
+func TestSelectCaseBridge(t *testing.T) {
+	recv := make(chan int, 1)
+	send := make(chan string, 1)
+	recv <- 42
+	cases := reflect.ToSelectCases([]corereflect.SelectCase{
+		{Dir: corereflect.SelectRecv, Chan: corereflect.ValueOf(recv)},
+		{Dir: corereflect.SelectSend}, // zero Chan: ignored
+		{Dir: corereflect.SelectRecv}, // zero Chan: ignored
+	})
+	chosen, v, ok := reflect.Select(cases)
+	if chosen != 0 || !ok || v.Int() != 42 {
+		t.Fatalf("got %d, %v, %v", chosen, v, ok)
+	}
+	cases = []reflect.SelectCase{
+		reflect.ToSelectCase(corereflect.SelectCase{Dir: corereflect.SelectSend, Chan: corereflect.ValueOf(send), Send: corereflect.ValueOf("x")}),
+	}
+	if chosen, _, _ := reflect.Select(cases); chosen != 0 || <-send != "x" {
+		t.Fatal("failed to send through converted case")
+	}
+	rc := reflect.ToReflectSelectCase(cases[0])
+	if rc.Dir != corereflect.SelectSend || rc.Chan.Interface().(chan string) != send || rc.Send.String() != "x" {
+		t.Fatal("failed to convert to reflect.SelectCase")
+	}
+	rcs := reflect.ToReflectSelectCases([]reflect.SelectCase{{Dir: reflect.SelectDefault}})
+	if len(rcs) != 1 || rcs[0].Chan.IsValid() || rcs[0].Send.IsValid() {
+		t.Fatal("failed to convert default case")
+	}
+	if chosen, _, _ := corereflect.Select(rcs); chosen != 0 {
+		t.Fatal("failed to select default case")
+	}
+}
@@ -0,0 +1,142 @@
+package reflect
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+)
+
+var selectValidation atomic.Bool
+
+// EnableSelectValidation turns the validation of the cases passed to Select
+// on or off. While enabled, Select runs ValidateSelectCases first and panics
+// with the error of the first malformed case, naming its index. It is meant
+// for debugging and is off by default.
+func EnableSelectValidation(enable bool) {
+	selectValidation.Store(enable)
+}
+
+// A SelectCaseError describes a malformed case found by ValidateSelectCases.
+type SelectCaseError struct {
+	Index  int // index of the case in the slice passed to ValidateSelectCases
+	Reason string
+}
+
+func (e *SelectCaseError) Error() string {
+	return fmt.Sprintf("reflect.Select: case %d: %s", e.Index, e.Reason)
+}
+
+// ValidateSelectCases checks every case the way Select would use it and
+// returns a *SelectCaseError for each malformed one, in order, or nil if all
+// of them are well-formed. It checks that Dir is a valid SelectDir, that
+// there is at most one SelectDefault case, that Chan is a channel whose
+// direction allows the operation, and that Send is set exactly for send
+// cases and is assignable to the channel's element type.
+// As in Select, a send or receive case with a zero Chan is valid and ignored.
+func ValidateSelectCases(cases []SelectCase) []error {
+	var errs []error
+	fail := func(i int, format string, args ...any) {
+		errs = append(errs, &SelectCaseError{Index: i, Reason: fmt.Sprintf(format, args...)})
+	}
+	haveDefault := false
+	for i, c := range cases {
+		switch c.Dir {
+		case SelectDefault:
+			if haveDefault {
+				fail(i, "multiple default cases")
+			}
+			haveDefault = true
+			if c.Chan.IsValid() {
+				fail(i, "default case has Chan value")
+			}
+			if c.Send.IsValid() {
+				fail(i, "default case has Send value")
+			}
+		case SelectSend, SelectRecv:
+			op, dir := "send", SendDir
+			if c.Dir == SelectRecv {
+				op, dir = "receive", RecvDir
+				if c.Send.IsValid() {
+					fail(i, "receive case has Send value")
+				}
+			}
+			if !c.Chan.IsValid() {
+				break
+			}
+			if k := c.Chan.Kind(); k != Chan {
+				fail(i, "%s case Chan is a %s, not a channel", op, k)
+				break
+			}
+			ct := c.Chan.Type()
+			if ct.ChanDir()&dir == 0 {
+				fail(i, "%s case on %s", op, ct)
+			}
+			if c.Dir == SelectRecv {
+				break
+			}
+			switch {
+			case !c.Send.IsValid():
+				fail(i, "send case missing Send value")
+			case c.Send.flag&flagRO != 0:
+				fail(i, "send case Send value obtained using unexported field")
+			case !c.Send.Type().AssignableTo(ct.Elem()):
+				fail(i, "send case Send value of type %s is not assignable to %s", c.Send.Type(), ct.Elem())
+			}
+		default:
+			fail(i, "invalid Dir %d", c.Dir)
+		}
+	}
+	return errs
+}
+
+// A SelectSet is a set of select cases that can be run repeatedly, for
+// event loops that select over a mostly unchanging set of channels.
+// Unlike Select, which converts its cases on every call, a SelectSet
+// converts a case once when it is added. The zero SelectSet is empty and
+// ready to use. A SelectSet must not be used concurrently.
+type SelectSet struct {
+	cases   []reflect.SelectCase
+	removed []bool
+	free    []int // indices of removed cases, for reuse by Add
+}
+
+// Add adds c to the set and returns its index, which Run reports when c
+// is chosen. Add reuses the indices of removed cases.
+// If select validation is enabled, Add panics if c is malformed.
+func (s *SelectSet) Add(c SelectCase) int {
+	if selectValidation.Load() {
+		if errs := ValidateSelectCases([]SelectCase{c}); len(errs) != 0 {
+			panic(fmt.Sprintf("reflect.SelectSet.Add: %s", errs[0].(*SelectCaseError).Reason))
+		}
+	}
+	if n := len(s.free); n > 0 {
+		i := s.free[n-1]
+		s.free = s.free[:n-1]
+		s.cases[i] = toRSC(c)
+		s.removed[i] = false
+		return i
+	}
+	s.cases = append(s.cases, toRSC(c))
+	s.removed = append(s.removed, false)
+	return len(s.cases) - 1
+}
+
+// Remove removes the case with index i from the set. The indices of the
+// other cases do not change.
+// It panics if there is no case with index i.
+func (s *SelectSet) Remove(i int) {
+	if i < 0 || i >= len(s.cases) || s.removed[i] {
+		panic("reflect.SelectSet.Remove: no case with index " + strconv.Itoa(i))
+	}
+	// A receive case with a zero Chan is ignored by Select.
+	s.cases[i] = reflect.SelectCase{Dir: SelectRecv}
+	s.removed[i] = true
+	s.free = append(s.free, i)
+}
+
+// Run executes a select operation over the cases in the set, as Select
+// does, and returns the index of the chosen case as returned by Add.
+func (s *SelectSet) Run() (chosen int, recv Value, recvOK bool) {
+	return value_SelectRSCs(s.cases)
+}
@@ -0,0 +1,144 @@
+package reflect_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+func TestValidateSelectCases(t *testing.T) {
+	ch := make(chan int, 1)
+	var recvOnly <-chan int = ch
+	var sendOnly chan<- int = ch
+	private := reflect.ValueOf(&struct{ n int }{}).Elem().Field(0)
+
+	valid := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: reflect.ValueOf(sendOnly), Send: reflect.ValueOf(1)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(recvOnly)},
+		{Dir: reflect.SelectRecv},
+		{Dir: reflect.SelectSend},
+	}
+	if errs := reflect.ValidateSelectCases(valid); errs != nil {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if errs := reflect.ValidateSelectCases(append(valid[:len(valid):len(valid)], reflect.SelectCase{Dir: reflect.SelectDefault})); errs != nil {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+
+	tests := []struct {
+		c      reflect.SelectCase
+		reason string
+	}{
+		{reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch), Send: reflect.ValueOf(1)}, "receive case has Send value"},
+		{reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(recvOnly), Send: reflect.ValueOf(1)}, "send case on <-chan int"},
+		{reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sendOnly)}, "receive case on chan<- int"},
+		{reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch)}, "missing Send value"},
+		{reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch), Send: reflect.ValueOf("x")}, "not assignable to int"},
+		{reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch), Send: private}, "unexported field"},
+		{reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(1)}, "not a channel"},
+		{reflect.SelectCase{Dir: reflect.SelectDefault, Chan: reflect.ValueOf(ch)}, "default case has Chan value"},
+		{reflect.SelectCase{Dir: reflect.SelectDefault, Send: reflect.ValueOf(1)}, "default case has Send value"},
+		{reflect.SelectCase{Dir: 42}, "invalid Dir 42"},
+	}
+	for _, test := range tests {
+		cases := append(valid[:len(valid):len(valid)], test.c)
+		errs := reflect.ValidateSelectCases(cases)
+		if len(errs) != 1 {
+			t.Fatalf("%s: got errors %v, want one", test.reason, errs)
+		}
+		var serr *reflect.SelectCaseError
+		if !errors.As(errs[0], &serr) || serr.Index != len(valid) || !strings.Contains(serr.Reason, test.reason) {
+			t.Fatalf("got %v, want case %d: %s", errs[0], len(valid), test.reason)
+		}
+	}
+
+	errs := reflect.ValidateSelectCases([]reflect.SelectCase{{Dir: reflect.SelectDefault}, {Dir: reflect.SelectDefault}})
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "case 1: multiple default cases") {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+}
+
+func TestSelectValidation(t *testing.T) {
+	reflect.EnableSelectValidation(true)
+	defer reflect.EnableSelectValidation(false)
+
+	ch := make(chan int, 1)
+	chosen, _, _ := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch), Send: reflect.ValueOf(1)},
+	})
+	if chosen != 0 {
+		t.Fatalf("chose %d", chosen)
+	}
+	defer func() {
+		msg, _ := recover().(string)
+		if msg != "reflect.Select: case 1: send case missing Send value" {
+			t.Fatalf("unexpected panic %q", msg)
+		}
+	}()
+	reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)},
+		{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch)},
+	})
+}
+
+func TestSelectSetRemove(t *testing.T) {
+	a, b, c := make(chan int, 1), make(chan int, 1), make(chan int, 1)
+	var s reflect.SelectSet
+	ia := s.Add(reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(a)})
+	ib := s.Add(reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(b)})
+	a <- 1
+	s.Remove(ia)
+	b <- 2
+	if chosen, recv, ok := s.Run(); chosen != ib || recv.Int() != 2 || !ok {
+		t.Fatalf("Run = %d, %v, %v, want %d, 2, true", chosen, recv, ok, ib)
+	}
+	// The removed index is reused.
+	if ic := s.Add(reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c)}); ic != ia {
+		t.Fatalf("Add after Remove returned %d, want %d", ic, ia)
+	}
+	c <- 3
+	if chosen, recv, _ := s.Run(); chosen != ia || recv.Int() != 3 {
+		t.Fatalf("Run = %d, %v, want %d, 3", chosen, recv, ia)
+	}
+	if len(a) != 1 {
+		t.Errorf("removed case was received from")
+	}
+	s.Remove(ia)
+	for _, i := range []int{ia, -1, 5} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.Contains(r.(string), "no case with index") {
+					t.Errorf("Remove(%d) panicked with %v", i, r)
+				}
+			}()
+			s.Remove(i)
+		}()
+	}
+}
+
+func BenchmarkSelectSet(b *testing.B) {
+	chans := make([]chan int, 8)
+	cases := make([]reflect.SelectCase, len(chans))
+	var s reflect.SelectSet
+	for i := range chans {
+		chans[i] = make(chan int, 1)
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(chans[i])}
+		s.Add(cases[i])
+	}
+	b.Run("Select", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			chans[i%len(chans)] <- i
+			reflect.Select(append([]reflect.SelectCase(nil), cases...))
+		}
+	})
+	b.Run("SelectSet", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			chans[i%len(chans)] <- i
+			s.Run()
+		}
+	})
+}
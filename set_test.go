@@ -6,10 +6,13 @@ package reflect_test
 
 import (
 	"bytes"
+	"fmt"
 	"go/ast"
 	"go/token"
 	"io"
+	"strings"
 	"testing"
+	"time"
 	"unsafe"
 
 	. "github.com/3JoB/go-reflect"
@@ -117,6 +120,93 @@ func TestImplicitMapConversion(t *testing.T) {
 	}
 }
 
+func TestSetMapEntries(t *testing.T) {
+	// Implicit conversions, as for SetMapIndex.
+	m := make(map[io.Reader]any)
+	b1, b2 := new(bytes.Buffer), new(bytes.Buffer)
+	ValueOf(m).SetMapEntries([]Value{ValueOf(b1), ValueOf(b2)}, []Value{ValueOf(1), ValueOf("two")})
+	if len(m) != 2 || m[b1] != 1 || m[b2] != "two" {
+		t.Errorf("after SetMapEntries: %v", m)
+	}
+
+	// A zero element deletes.
+	ValueOf(m).SetMapEntries([]Value{ValueOf(b1)}, []Value{{}})
+	if _, ok := m[b1]; ok || len(m) != 1 {
+		t.Errorf("after deleting SetMapEntries: %v", m)
+	}
+
+	// A settable nil map is allocated.
+	var s struct{ M map[<-chan int]int }
+	c := make(chan int)
+	ValueOf(&s).Elem().Field(0).SetMapEntries([]Value{ValueOf(c)}, []Value{ValueOf(7)})
+	if s.M[c] != 7 {
+		t.Errorf("after SetMapEntries on nil map: %v", s.M)
+	}
+
+	// Method values are stored as their func type.
+	fm := make(map[string]func() string)
+	ValueOf(fm).SetMapEntries([]Value{ValueOf("m")}, []Value{ValueOf(time.March).MethodByName("String")})
+	if f := fm["m"]; f == nil || f() != "March" {
+		t.Errorf("after SetMapEntries with a method value: %v", fm)
+	}
+
+	mustPanic := func(want string, f func()) {
+		t.Helper()
+		if msg := fmt.Sprint(panicOf(f)); !strings.Contains(msg, want) {
+			t.Errorf("panic %q, want %q", msg, want)
+		}
+	}
+
+	// A bad entry panics before anything is inserted.
+	m2 := make(map[string]int)
+	mustPanic("element 1: value of type string is not assignable to type int", func() {
+		ValueOf(m2).SetMapEntries([]Value{ValueOf("a"), ValueOf("b")}, []Value{ValueOf(1), ValueOf("x")})
+	})
+	if len(m2) != 0 {
+		t.Errorf("failed SetMapEntries modified the map: %v", m2)
+	}
+	mustPanic("2 keys and 1 elements", func() {
+		ValueOf(m2).SetMapEntries([]Value{ValueOf("a"), ValueOf("b")}, []Value{ValueOf(1)})
+	})
+	mustPanic("nil map", func() {
+		var nilMap map[string]int
+		ValueOf(nilMap).SetMapEntries([]Value{ValueOf("a")}, []Value{ValueOf(1)})
+	})
+
+	// SetMapIndexAny stores nil as the zero element instead of deleting.
+	ValueOf(m).SetMapIndexAny(b1, nil)
+	if v, ok := m[b1]; !ok || v != nil {
+		t.Errorf("SetMapIndexAny(b1, nil) stored %v, %v", v, ok)
+	}
+	ValueOf(m2).SetMapIndexAny("k", 3)
+	if m2["k"] != 3 {
+		t.Errorf("SetMapIndexAny(k, 3) gave %v", m2)
+	}
+}
+
+func BenchmarkSetMapEntries(b *testing.B) {
+	const n = 10000
+	keys, vals := make([]Value, n), make([]Value, n)
+	for i := range keys {
+		keys[i], vals[i] = ValueOf(i), ValueOf(i)
+	}
+	typ := TypeOf(map[int]any(nil))
+	b.Run("SetMapIndex", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := MakeMap(typ)
+			for j := range keys {
+				m.SetMapIndex(keys[j], vals[j])
+			}
+		}
+	})
+	b.Run("SetMapEntries", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := New(typ).Elem()
+			m.SetMapEntries(keys, vals)
+		}
+	})
+}
+
 func TestImplicitSetConversion(t *testing.T) {
 	// Assume TestImplicitMapConversion covered the basics.
 	// Just make sure conversions are being applied at all.
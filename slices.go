@@ -0,0 +1,115 @@
+package reflect
+
+import (
+	"unsafe"
+)
+
+// sliceElemOf returns the element type of the slice or array v.
+// It panics if v's Kind is not Slice or Array.
+func sliceElemOf(v Value, method string) Type {
+	if k := v.Kind(); k != Slice && k != Array {
+		panic(&ValueError{Method: method, Kind: k})
+	}
+	return v.typ.Elem()
+}
+
+func panicElem(method string, v Value, want string) {
+	panic(method + ": element type " + v.typ.Elem().String() + " of " + v.typ.String() + " is not " + want)
+}
+
+// Strings returns a copy of v's elements as a []string.
+// It panics if v's Kind is not Slice or Array, or if the element Kind is not String.
+// Named string element types are converted.
+func (v Value) Strings() []string {
+	v.mustBeValid("reflect.Value.Strings")
+	if sliceElemOf(v, "reflect.Value.Strings").Kind() != String {
+		panicElem("reflect.Value.Strings", v, "a string type")
+	}
+	rv := toRV(v)
+	out := make([]string, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).String()
+	}
+	return out
+}
+
+// Ints returns a copy of v's elements widened to a []int64.
+// It panics if v's Kind is not Slice or Array, or if the element Kind is not
+// one of Int, Int8, Int16, Int32 or Int64.
+func (v Value) Ints() []int64 {
+	v.mustBeValid("reflect.Value.Ints")
+	switch sliceElemOf(v, "reflect.Value.Ints").Kind() {
+	case Int, Int8, Int16, Int32, Int64:
+	default:
+		panicElem("reflect.Value.Ints", v, "a signed integer type")
+	}
+	rv := toRV(v)
+	out := make([]int64, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Int()
+	}
+	return out
+}
+
+// Floats returns a copy of v's elements widened to a []float64.
+// It panics if v's Kind is not Slice or Array, or if the element Kind is not
+// Float32 or Float64.
+func (v Value) Floats() []float64 {
+	v.mustBeValid("reflect.Value.Floats")
+	switch sliceElemOf(v, "reflect.Value.Floats").Kind() {
+	case Float32, Float64:
+	default:
+		panicElem("reflect.Value.Floats", v, "a floating-point type")
+	}
+	rv := toRV(v)
+	out := make([]float64, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Float()
+	}
+	return out
+}
+
+// StringsAlias returns v's underlying []string without copying, and true,
+// if v is a slice whose element type is exactly string.
+// Otherwise it returns nil and false; use Strings to copy and convert.
+// Writes to the returned slice are visible through v.
+func (v Value) StringsAlias() ([]string, bool) {
+	if v.Kind() != Slice || v.typ.Elem() != TypeOf("") {
+		return nil, false
+	}
+	return *(*[]string)(v.ptr), true
+}
+
+// IntsAlias returns v's underlying []int64 without copying, and true,
+// if v is a slice whose element type is exactly int64.
+// Otherwise it returns nil and false; use Ints to copy and widen.
+// Writes to the returned slice are visible through v.
+func (v Value) IntsAlias() ([]int64, bool) {
+	if v.Kind() != Slice || v.typ.Elem() != TypeOf(int64(0)) {
+		return nil, false
+	}
+	return *(*[]int64)(v.ptr), true
+}
+
+// StringBytes returns the bytes of the string v without copying them.
+// The result aliases the string's memory and must not be modified; it is
+// nil for an empty string. Use []byte(v.String()) for a copy.
+// It panics if v's Kind is not String.
+func StringBytes(v Value) []byte {
+	if k := v.flag.kind(); k != String {
+		panic(&ValueError{Method: "reflect.StringBytes", Kind: k})
+	}
+	s := *(*string)(v.data())
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// UnsafeStringValue returns a Value holding a string that aliases the
+// bytes of b instead of copying them, as ValueOf(string(b)) would.
+// The caller must not modify b for as long as the string is in use,
+// since strings are assumed to be immutable.
+func UnsafeStringValue(b []byte) Value {
+	return ValueOf(unsafe.String(unsafe.SliceData(b), len(b)))
+}
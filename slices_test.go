@@ -0,0 +1,109 @@
+package reflect_test
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type sliceString string
+
+type sliceInt8 int8
+
+func TestSliceAccessors(t *testing.T) {
+	if got := reflect.ValueOf([]string{"a", "b"}).Strings(); len(got) != 2 || got[1] != "b" {
+		t.Fatalf("Strings: got %v", got)
+	}
+	if got := reflect.ValueOf([2]sliceString{"x", "y"}).Strings(); got[0] != "x" || got[1] != "y" {
+		t.Fatalf("Strings of named elements: got %v", got)
+	}
+	if got := reflect.ValueOf([]sliceInt8{-1, 127}).Ints(); got[0] != -1 || got[1] != 127 {
+		t.Fatalf("Ints: got %v", got)
+	}
+	if got := reflect.ValueOf([]int32{1 << 30}).Ints(); got[0] != 1<<30 {
+		t.Fatalf("Ints: got %v", got)
+	}
+	if got := reflect.ValueOf([]float32{1.5}).Floats(); got[0] != 1.5 {
+		t.Fatalf("Floats: got %v", got)
+	}
+
+	s := []string{"a"}
+	alias, ok := reflect.ValueOf(s).StringsAlias()
+	if !ok || &alias[0] != &s[0] {
+		t.Fatal("StringsAlias must alias []string")
+	}
+	if cp := reflect.ValueOf(s).Strings(); &cp[0] == &s[0] {
+		t.Fatal("Strings must copy")
+	}
+	if _, ok := reflect.ValueOf([]sliceString{"a"}).StringsAlias(); ok {
+		t.Fatal("StringsAlias must not alias named element types")
+	}
+	i := []int64{1}
+	ialias, ok := reflect.ValueOf(i).IntsAlias()
+	if !ok || &ialias[0] != &i[0] {
+		t.Fatal("IntsAlias must alias []int64")
+	}
+	if _, ok := reflect.ValueOf([]int{1}).IntsAlias(); ok {
+		t.Fatal("IntsAlias must not alias []int")
+	}
+
+	func() {
+		defer func() {
+			if r, _ := recover().(string); !strings.Contains(r, "element type string of []string") {
+				t.Fatalf("unexpected panic %q", r)
+			}
+		}()
+		reflect.ValueOf([]string{}).Ints()
+	}()
+	func() {
+		defer func() {
+			if _, ok := recover().(*reflect.ValueError); !ok {
+				t.Fatal("did not panic with ValueError")
+			}
+		}()
+		reflect.ValueOf(1).Floats()
+	}()
+}
+
+func TestStringBytes(t *testing.T) {
+	s := strings.Repeat("gopher", 4)
+	b := reflect.StringBytes(reflect.ValueOf(s))
+	if string(b) != s {
+		t.Fatalf("StringBytes = %q, want %q", b, s)
+	}
+	if unsafe.SliceData(b) != unsafe.StringData(s) {
+		t.Errorf("StringBytes copied the string")
+	}
+	// Named string types, and strings reached through fields.
+	v := reflect.ValueOf(&struct{ S sliceString }{"named"}).Elem().Field(0)
+	if got := reflect.StringBytes(v); string(got) != "named" {
+		t.Errorf("StringBytes of named string = %q", got)
+	}
+	if got := reflect.StringBytes(reflect.ValueOf("")); got != nil {
+		t.Errorf("StringBytes of empty string = %v, want nil", got)
+	}
+	if n := testing.AllocsPerRun(100, func() { reflect.StringBytes(v) }); n != 0 {
+		t.Errorf("StringBytes allocates %v times", n)
+	}
+
+	buf := []byte("aliased")
+	sv := reflect.UnsafeStringValue(buf)
+	if sv.String() != "aliased" || sv.Type() != reflect.TypeOf("") {
+		t.Fatalf("UnsafeStringValue = %v of type %v", sv, sv.Type())
+	}
+	if unsafe.StringData(sv.String()) != unsafe.SliceData(buf) {
+		t.Errorf("UnsafeStringValue copied the bytes")
+	}
+
+	// The ordinary conversions still copy.
+	if unsafe.StringData(reflect.ValueOf(buf).Convert(reflect.TypeOf("")).String()) == unsafe.SliceData(buf) {
+		t.Errorf("Convert of []byte to string aliases the bytes")
+	}
+	if bs := reflect.ValueOf(s).Convert(reflect.TypeOf([]byte(nil))).Bytes(); unsafe.SliceData(bs) == unsafe.StringData(s) {
+		t.Errorf("Convert of string to []byte aliases the string")
+	}
+
+	shouldPanic(func() { reflect.StringBytes(reflect.ValueOf(1)) })
+}
@@ -0,0 +1,102 @@
+package reflect
+
+import (
+	"errors"
+	"io"
+	"unsafe"
+)
+
+// isByteStream reports whether v is a string or a byte slice, which
+// WriteValueTo and ReadValueFrom support.
+func isByteStream(v Value) bool {
+	switch v.flag.kind() {
+	case String:
+		return true
+	case Slice:
+		return v.typ.Elem().Kind() == Uint8
+	}
+	return false
+}
+
+// WriteValueTo writes the contents of v, which must be a string or a byte
+// slice, possibly of a named type, to w without copying them, and returns
+// the number of bytes written and any error encountered. Values obtained
+// using unexported fields can be written too. The bytes are passed to w
+// as they are in memory, so w must not modify them, as io.Writer requires.
+//
+// It returns a *ValueError if v's Kind is not String or a Slice of bytes.
+func WriteValueTo(w io.Writer, v Value) (int64, error) {
+	if !isByteStream(v) {
+		return 0, &ValueError{Method: "reflect.WriteValueTo", Kind: v.Kind()}
+	}
+	var b []byte
+	if v.flag.kind() == String {
+		b = StringBytes(v)
+	} else {
+		b = *(*[]byte)(v.data())
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadValueFrom reads from r until EOF, or until limit bytes have been read
+// if limit is not negative, and sets v, which must be a settable string or
+// byte slice, possibly of a named type, to the bytes read. It returns the
+// number of bytes read and any error encountered other than EOF, in which
+// case v is left unchanged.
+//
+// The bytes are read into a new buffer, which a string set to v then
+// shares. If r reports the number of unread bytes with a Len method, as
+// *bytes.Reader, *bytes.Buffer and *strings.Reader do, the buffer is
+// allocated once with the final size.
+//
+// It returns a *ValueError if v's Kind is not String or a Slice of bytes,
+// and an error if v is not settable.
+func ReadValueFrom(r io.Reader, v Value, limit int64) (int64, error) {
+	if !isByteStream(v) {
+		return 0, &ValueError{Method: "reflect.ReadValueFrom", Kind: v.Kind()}
+	}
+	if !v.CanSet() {
+		return 0, errors.New("reflect: ReadValueFrom into unaddressable value or value obtained using unexported field")
+	}
+	if limit >= 0 {
+		r = io.LimitReader(r, limit)
+	}
+	// One more byte than expected lets the final read report EOF without
+	// growing the buffer.
+	var buf []byte
+	if l, ok := r.(interface{ Len() int }); ok {
+		buf = make([]byte, 0, l.Len()+1)
+	} else if lr, ok := r.(*io.LimitedReader); ok {
+		if l, ok := lr.R.(interface{ Len() int }); ok {
+			buf = make([]byte, 0, min(int64(l.Len()), lr.N)+1)
+		}
+	}
+	buf, err := readAll(r, buf)
+	if err != nil {
+		return int64(len(buf)), err
+	}
+	if v.flag.kind() == String {
+		v.SetString(unsafe.String(unsafe.SliceData(buf), len(buf)))
+	} else {
+		v.SetBytes(buf)
+	}
+	return int64(len(buf)), nil
+}
+
+// readAll is io.ReadAll appending to b.
+func readAll(r io.Reader, b []byte) ([]byte, error) {
+	for {
+		if len(b) == cap(b) {
+			b = append(b, 0)[:len(b)]
+		}
+		n, err := r.Read(b[len(b):cap(b)])
+		b = b[:len(b)+n]
+		if err == io.EOF {
+			return b, nil
+		}
+		if err != nil {
+			return b, err
+		}
+	}
+}
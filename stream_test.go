@@ -0,0 +1,104 @@
+package reflect_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type streamBytes []byte
+
+type streamName string
+
+// countingWriter counts the bytes written to it without copying them.
+type countingWriter struct{ n int64 }
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+func TestWriteValueTo(t *testing.T) {
+	type record struct {
+		Blob streamBytes
+		Name streamName
+		blob []byte
+		ID   int
+	}
+	rec := record{Blob: make(streamBytes, 16<<20), Name: "name", blob: []byte("hidden")}
+	v := reflect.ValueOf(rec)
+
+	var w countingWriter
+	blob := v.Field(0)
+	if n, err := reflect.WriteValueTo(&w, blob); err != nil || n != 16<<20 || w.n != n {
+		t.Errorf("WriteValueTo(Blob) = %d, %v; wrote %d", n, err, w.n)
+	}
+	if !reflect.CrossCheckEnabled() {
+		if allocs := testing.AllocsPerRun(10, func() { reflect.WriteValueTo(&w, blob) }); allocs > 0 {
+			t.Errorf("WriteValueTo allocates %v times, want 0", allocs)
+		}
+	}
+
+	var buf bytes.Buffer
+	for i, want := range map[int]string{1: "name", 2: "hidden"} {
+		buf.Reset()
+		if n, err := reflect.WriteValueTo(&buf, v.Field(i)); err != nil || n != int64(len(want)) || buf.String() != want {
+			t.Errorf("WriteValueTo(field %d) = %d, %v; wrote %q, want %q", i, n, err, buf.String(), want)
+		}
+	}
+
+	_, err := reflect.WriteValueTo(&buf, v.Field(3))
+	var ve *reflect.ValueError
+	if !errors.As(err, &ve) || ve.Kind != reflect.Int {
+		t.Errorf("WriteValueTo(int) error = %v, want a *ValueError", err)
+	}
+}
+
+func TestReadValueFrom(t *testing.T) {
+	type record struct {
+		Blob streamBytes
+		Name streamName
+		ID   int
+	}
+	var rec record
+	v := reflect.ValueOf(&rec).Elem()
+
+	data := bytes.Repeat([]byte("x"), 16<<20)
+	if n, err := reflect.ReadValueFrom(bytes.NewReader(data), v.Field(0), -1); err != nil || n != int64(len(data)) || !bytes.Equal(rec.Blob, data) {
+		t.Errorf("ReadValueFrom(Blob) = %d, %v; got %d bytes", n, err, len(rec.Blob))
+	}
+	if !reflect.CrossCheckEnabled() {
+		if allocs := testing.AllocsPerRun(5, func() {
+			reflect.ReadValueFrom(bytes.NewReader(data), v.Field(0), -1)
+		}); allocs > 2 {
+			t.Errorf("ReadValueFrom allocates %v times, want at most 2", allocs)
+		}
+	}
+
+	if n, err := reflect.ReadValueFrom(strings.NewReader("hello, world"), v.Field(1), 5); err != nil || n != 5 || rec.Name != "hello" {
+		t.Errorf("ReadValueFrom(Name, 5) = %d, %v; got %q", n, err, rec.Name)
+	}
+	// A reader without Len.
+	if n, err := reflect.ReadValueFrom(io.MultiReader(strings.NewReader("ab"), strings.NewReader("cd")), v.Field(1), -1); err != nil || n != 4 || rec.Name != "abcd" {
+		t.Errorf("ReadValueFrom(Name) = %d, %v; got %q", n, err, rec.Name)
+	}
+
+	errRead := errors.New("read failed")
+	if _, err := reflect.ReadValueFrom(io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(errRead)), v.Field(1), -1); err != errRead || rec.Name != "abcd" {
+		t.Errorf("ReadValueFrom with failing reader = %v, left %q", err, rec.Name)
+	}
+
+	_, err := reflect.ReadValueFrom(strings.NewReader("1"), v.Field(2), -1)
+	var ve *reflect.ValueError
+	if !errors.As(err, &ve) || ve.Kind != reflect.Int {
+		t.Errorf("ReadValueFrom(int) error = %v, want a *ValueError", err)
+	}
+	if _, err := reflect.ReadValueFrom(strings.NewReader("x"), reflect.ValueOf(rec).Field(1), -1); err == nil {
+		t.Errorf("ReadValueFrom into unaddressable value succeeded")
+	}
+}
@@ -0,0 +1,163 @@
+package reflect
+
+import (
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Stringify returns a textual representation of v for debugging, such as
+// *int(&5) or map[string]int{a: 1, b: 2}. Unlike the fmt package, it does
+// not call String or Error methods and prints unexported fields.
+//
+// Numbers, strings and bools print as their value, with strings unquoted,
+// except that values of enum types registered with RegisterEnum print as
+// their name, if they have one.
+// Composite values print as their type followed by their elements in
+// braces, with map entries sorted by key as by MapKeysSorted. Pointers
+// print as their type followed by &elem, or 0 if nil, in parentheses, and
+// interfaces as their type followed by their dynamic value in parentheses.
+// Funcs and unsafe pointers print as their type followed by their address
+// in decimal in parentheses, and channels as their type only. The zero
+// Value prints as <zero Value>.
+//
+// A pointer, map or slice that refers to a value being printed, forming a
+// cycle, prints as its type followed by (<cycle>).
+func Stringify(v Value) string {
+	s := stringifier{active: make(map[deepRef]bool)}
+	s.write(v)
+	return s.b.String()
+}
+
+type stringifier struct {
+	b      strings.Builder
+	active map[deepRef]bool // values being printed
+}
+
+// enter reports whether the value ref refers to is not being printed
+// already, and marks it as being printed until leave is called.
+func (s *stringifier) enter(ref deepRef) bool {
+	if s.active[ref] {
+		return false
+	}
+	s.active[ref] = true
+	return true
+}
+
+func (s *stringifier) leave(ref deepRef) {
+	delete(s.active, ref)
+}
+
+func (s *stringifier) write(v Value) {
+	if v.flag == 0 {
+		s.b.WriteString("<zero Value>")
+		return
+	}
+	v = v.readable()
+	typ := v.Type()
+	switch v.flag.kind() {
+	case Int, Int8, Int16, Int32, Int64:
+		if name, ok := EnumString(v); ok {
+			s.b.WriteString(name)
+		} else {
+			s.b.WriteString(strconv.FormatInt(value_Int(v), 10))
+		}
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		if name, ok := EnumString(v); ok {
+			s.b.WriteString(name)
+		} else {
+			s.b.WriteString(strconv.FormatUint(value_Uint(v), 10))
+		}
+	case Float32, Float64:
+		s.b.WriteString(strconv.FormatFloat(value_Float(v), 'g', -1, 64))
+	case Complex64, Complex128:
+		c := value_Complex(v)
+		s.b.WriteString(strconv.FormatFloat(real(c), 'g', -1, 64) + "+" + strconv.FormatFloat(imag(c), 'g', -1, 64) + "i")
+	case String:
+		s.b.WriteString(value_String(v))
+	case Bool:
+		s.b.WriteString(strconv.FormatBool(value_Bool(v)))
+	case Ptr:
+		s.b.WriteString(typ.String())
+		if value_IsNil(v) {
+			s.b.WriteString("(0)")
+			return
+		}
+		ref := deepRef{v.pointer(), v.typ, 0}
+		if !s.enter(ref) {
+			s.b.WriteString("(<cycle>)")
+			return
+		}
+		s.b.WriteString("(&")
+		s.write(value_Elem(v))
+		s.b.WriteString(")")
+		s.leave(ref)
+	case Array:
+		s.b.WriteString(typ.String())
+		s.writeElems(v)
+	case Slice:
+		s.b.WriteString(typ.String())
+		if value_IsNil(v) {
+			s.b.WriteString("{}")
+			return
+		}
+		ref := deepRef{*(*unsafe.Pointer)(v.data()), v.typ, value_Len(v)}
+		if !s.enter(ref) {
+			s.b.WriteString("(<cycle>)")
+			return
+		}
+		s.writeElems(v)
+		s.leave(ref)
+	case Map:
+		s.b.WriteString(typ.String())
+		ref := deepRef{v.pointer(), v.typ, 0}
+		if !s.enter(ref) {
+			s.b.WriteString("(<cycle>)")
+			return
+		}
+		s.b.WriteString("{")
+		first := true
+		v.MapRangeSorted(func(k, e Value) bool {
+			if !first {
+				s.b.WriteString(", ")
+			}
+			first = false
+			s.write(k)
+			s.b.WriteString(": ")
+			s.write(e)
+			return true
+		})
+		s.b.WriteString("}")
+		s.leave(ref)
+	case Chan:
+		s.b.WriteString(typ.String())
+	case Struct:
+		s.b.WriteString(typ.String())
+		s.b.WriteString("{")
+		for i, n := 0, v.typ.NumField(); i < n; i++ {
+			if i > 0 {
+				s.b.WriteString(", ")
+			}
+			s.write(value_Field(v, i))
+		}
+		s.b.WriteString("}")
+	case Interface:
+		s.b.WriteString(typ.String())
+		s.b.WriteString("(")
+		s.write(value_Elem(v))
+		s.b.WriteString(")")
+	case Func, UnsafePointer:
+		s.b.WriteString(typ.String() + "(" + strconv.FormatUint(uint64(v.Pointer()), 10) + ")")
+	}
+}
+
+func (s *stringifier) writeElems(v Value) {
+	s.b.WriteString("{")
+	for i, n := 0, value_Len(v); i < n; i++ {
+		if i > 0 {
+			s.b.WriteString(", ")
+		}
+		s.write(value_Index(v, i))
+	}
+	s.b.WriteString("}")
+}
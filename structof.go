@@ -0,0 +1,99 @@
+package reflect
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// TryStructOf is like StructOf, but returns an error instead of panicking
+// if fields do not describe a valid struct type. Every field is checked,
+// and the error lists the problems of all invalid fields, each prefixed
+// with the index of the field.
+func TryStructOf(fields []StructField) (Type, error) {
+	var errs []error
+	seen := make(map[string]int, len(fields))
+	for i, f := range fields {
+		if reason := invalidStructField(fields, i); reason != "" {
+			errs = append(errs, fmt.Errorf("reflect: StructOf: field %d: %s", i, reason))
+			continue
+		}
+		if j, ok := seen[f.Name]; ok {
+			errs = append(errs, fmt.Errorf("reflect: StructOf: field %d: duplicate field %q, first declared by field %d", i, f.Name, j))
+			continue
+		}
+		seen[f.Name] = i
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	var t Type
+	if r, panicked := catchPanic(func() { t = structOf(fields) }); panicked {
+		// A limitation of reflect not covered above.
+		return nil, fmt.Errorf("reflect: StructOf: %v", r)
+	}
+	return t, nil
+}
+
+// invalidStructField describes why fields[i] cannot be passed to StructOf,
+// or returns "" if it can.
+func invalidStructField(fields []StructField, i int) string {
+	f := fields[i]
+	switch {
+	case f.Name == "":
+		return "no name"
+	case !isValidFieldName(f.Name):
+		return fmt.Sprintf("invalid name %q", f.Name)
+	case f.Type == nil:
+		return fmt.Sprintf("%q has no type", f.Name)
+	case f.PkgPath != "" && isExportedName(f.Name):
+		return fmt.Sprintf("%q is exported but has PkgPath set", f.Name)
+	case f.PkgPath == "" && ('a' <= f.Name[0] && f.Name[0] <= 'z' || f.Name[0] == '_'):
+		return fmt.Sprintf("%q is unexported but has no PkgPath", f.Name)
+	case f.PkgPath != "" && f.Anonymous:
+		return fmt.Sprintf("%q is embedded but has PkgPath set", f.Name)
+	}
+	if !f.Anonymous {
+		return ""
+	}
+	t := f.Type
+	if t.Kind() == Ptr {
+		if k := t.Elem().Kind(); k == Ptr || k == Interface {
+			return fmt.Sprintf("embedded field %q has illegal type %s", f.Name, t)
+		}
+	}
+	if t.Kind() == Interface {
+		for j := 0; j < t.NumMethod(); j++ {
+			if t.Method(j).PkgPath != "" {
+				return fmt.Sprintf("embedded interface %s has unexported methods, which StructOf cannot promote", t)
+			}
+		}
+		return ""
+	}
+	switch {
+	case t.NumMethod() == 0:
+		return ""
+	case i > 0:
+		return fmt.Sprintf("embedded field %q of type %s has methods, which StructOf can only promote from the first field", f.Name, t)
+	case len(fields) > 1 && !ifaceIndir(t):
+		return fmt.Sprintf("embedded field %q of pointer-shaped type %s has methods, which StructOf can only promote if it is the only field", f.Name, t)
+	}
+	return ""
+}
+
+// isValidFieldName reports whether name is a valid Go identifier.
+func isValidFieldName(name string) bool {
+	for i, c := range name {
+		if i == 0 && !isLetter(c) {
+			return false
+		}
+		if !(isLetter(c) || unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return len(name) > 0
+}
+
+func isLetter(ch rune) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || ch >= 0x80 && unicode.IsLetter(ch)
+}
@@ -0,0 +1,70 @@
+package reflect_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type structOfEmbedded struct{ A int }
+
+func (structOfEmbedded) M() {}
+
+func TestTryStructOf(t *testing.T) {
+	str := reflect.TypeOf("")
+	_, err := reflect.TryStructOf([]reflect.StructField{
+		{Name: "Valid", Type: str},
+		{Name: "1nvalid", Type: str},
+		{Name: "", Type: str},
+		{Name: "Valid", Type: str},
+		{Name: "NoType"},
+		{Name: "Late", Type: reflect.TypeOf(structOfEmbedded{}), Anonymous: true},
+	})
+	if err == nil {
+		t.Fatal("TryStructOf with invalid fields succeeded")
+	}
+	for _, want := range []string{
+		`field 1: invalid name "1nvalid"`,
+		`field 2: no name`,
+		`field 3: duplicate field "Valid", first declared by field 0`,
+		`field 4: "NoType" has no type`,
+		`field 5: embedded field "Late" of type reflect_test.structOfEmbedded has methods`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("TryStructOf error %q does not contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "field 0") && !strings.Contains(err.Error(), "by field 0") {
+		t.Errorf("TryStructOf error %q reports the valid field 0", err)
+	}
+
+	for _, fields := range [][]reflect.StructField{
+		{{Name: "x", Type: str}},
+		{{Name: "X", Type: str, PkgPath: "p"}},
+		{{Name: "S", Type: reflect.TypeOf(&structOfEmbedded{}), Anonymous: true}, {Name: "X", Type: str}},
+	} {
+		if _, err := reflect.TryStructOf(fields); err == nil {
+			t.Errorf("TryStructOf(%v) succeeded", fields)
+		} else if panicOf(func() { reflect.StructOf(fields) }) == nil {
+			t.Errorf("TryStructOf(%v) failed, but StructOf did not panic", fields)
+		}
+	}
+
+	for _, fields := range [][]reflect.StructField{
+		{{Name: "φ", Type: str}, {Name: "Val1d_", Type: str}, {Name: "x", Type: str, PkgPath: "p"}},
+		{{Name: "S", Type: reflect.TypeOf(structOfEmbedded{}), Anonymous: true}, {Name: "X", Type: str}},
+		{{Name: "Duration", Type: reflect.TypeOf(time.Duration(0)), Anonymous: true}, {Name: "X", Type: str}},
+		{{Name: "S", Type: reflect.TypeOf(&structOfEmbedded{}), Anonymous: true}},
+	} {
+		typ, err := reflect.TryStructOf(fields)
+		if err != nil {
+			t.Errorf("TryStructOf(%v): %v", fields, err)
+			continue
+		}
+		if want := reflect.StructOf(fields); typ != want {
+			t.Errorf("TryStructOf(%v) = %v, want %v", fields, typ, want)
+		}
+	}
+}
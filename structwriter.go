@@ -0,0 +1,105 @@
+package reflect
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// A StructWriter builds a struct value by writing its fields positionally.
+// It allocates the struct once and writes each field by offset, so filling
+// every field of a row does not re-derive and re-validate a field Value
+// per call the way Field(i).Set does.
+type StructWriter struct {
+	// SkipUnexported makes Next skip unexported fields instead of
+	// returning an error when it reaches one.
+	SkipUnexported bool
+
+	typ    Type
+	ptr    Value
+	base   unsafe.Pointer
+	fields []structFieldLayout
+	next   int
+}
+
+// NewStructWriter returns a StructWriter for a new zero value of the struct type t.
+// It panics if t's Kind is not Struct.
+func NewStructWriter(t Type) *StructWriter {
+	mustBeNonNilType(t, "NewStructWriter")
+	fields := structLayout(t, "NewStructWriter").fields
+	ptr := value_New(t)
+	return &StructWriter{
+		typ:    t,
+		ptr:    ptr,
+		base:   ptr.pointer(),
+		fields: fields,
+	}
+}
+
+// Next writes v to the field after the one written by the previous call to
+// Next, starting with the first field. Values that are not assignable but
+// convertible to the field type are converted.
+// It returns an error if all fields have been written, if the field is
+// unexported and SkipUnexported is false, or if v cannot be stored in the field.
+func (w *StructWriter) Next(v Value) error {
+	for w.next < len(w.fields) && w.SkipUnexported && !w.fields[w.next].name.isExported() {
+		w.next++
+	}
+	if w.next >= len(w.fields) {
+		return fmt.Errorf("reflect: StructWriter.Next: all %d fields of %s have been written", len(w.fields), w.typ)
+	}
+	i := w.next
+	w.next++
+	return w.set("Next", i, v)
+}
+
+// SetField writes v to the i'th field, converting it like Next does.
+// It does not move the position used by Next.
+// It returns an error if i is out of range, if the field is unexported,
+// or if v cannot be stored in the field.
+func (w *StructWriter) SetField(i int, v Value) error {
+	if i < 0 || i >= len(w.fields) {
+		return fmt.Errorf("reflect: StructWriter.SetField: field index %d out of range for %s", i, w.typ)
+	}
+	return w.set("SetField", i, v)
+}
+
+func (w *StructWriter) set(method string, i int, v Value) error {
+	f := &w.fields[i]
+	if !f.name.isExported() {
+		return fmt.Errorf("reflect: StructWriter.%s: field %s of %s is unexported", method, f.name.name(), w.typ)
+	}
+	if !v.IsValid() {
+		return fmt.Errorf("reflect: StructWriter.%s: invalid Value for field %s of %s", method, f.name.name(), w.typ)
+	}
+	if v.flag&flagRO != 0 {
+		return fmt.Errorf("reflect: StructWriter.%s: value for field %s of %s was obtained using unexported field", method, f.name.name(), w.typ)
+	}
+	dst := unsafe.Add(w.base, f.offset)
+	if v.typ == f.typ && v.flag&flagMethod == 0 {
+		typedmemmove(f.typ, dst, v.data())
+		return nil
+	}
+	vt := v.Type()
+	if !vt.AssignableTo(f.typ) {
+		if !vt.ConvertibleTo(f.typ) {
+			return fmt.Errorf("reflect: StructWriter.%s: cannot use %s as %s in field %s of %s", method, vt, f.typ, f.name.name(), w.typ)
+		}
+		v = v.Convert(f.typ)
+	}
+	value_Set(value_NewAt(f.typ, dst).Elem(), v)
+	return nil
+}
+
+// Reset starts writing a new zero value of the struct type from the first
+// field, so that one StructWriter can fill many rows.
+// Values returned by earlier calls to Finish are not affected.
+func (w *StructWriter) Reset() {
+	w.ptr = value_New(w.typ)
+	w.base = w.ptr.pointer()
+	w.next = 0
+}
+
+// Finish returns the addressable struct Value that has been written.
+func (w *StructWriter) Finish() Value {
+	return value_Elem(w.ptr)
+}
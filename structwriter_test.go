@@ -0,0 +1,90 @@
+package reflect_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type rowT struct {
+	ID     int64
+	hidden string
+	Name   string
+	Score  float64
+}
+
+func TestStructWriter(t *testing.T) {
+	typ := reflect.TypeOf(rowT{})
+
+	w := reflect.NewStructWriter(typ)
+	w.SkipUnexported = true
+	for _, v := range []any{int32(7), "gopher", 1.5} {
+		if err := w.Next(reflect.ValueOf(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Next(reflect.ValueOf(1)); err == nil || !strings.Contains(err.Error(), "all 4 fields") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	row := w.Finish()
+	if !row.CanAddr() {
+		t.Fatal("Finish must return an addressable struct")
+	}
+	if got := row.Interface().(rowT); got != (rowT{ID: 7, Name: "gopher", Score: 1.5}) {
+		t.Fatalf("got %+v", got)
+	}
+
+	w = reflect.NewStructWriter(typ)
+	if err := w.Next(reflect.ValueOf(int64(1))); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Next(reflect.ValueOf("x")); err == nil || !strings.Contains(err.Error(), "field hidden of reflect_test.rowT is unexported") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := w.SetField(3, reflect.ValueOf("x")); err == nil || !strings.Contains(err.Error(), "cannot use string as float64") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := w.SetField(4, reflect.ValueOf(1)); err == nil {
+		t.Fatal("expected out of range error")
+	}
+	if err := w.SetField(3, reflect.ValueOf(2)); err != nil {
+		t.Fatal(err)
+	}
+	first := w.Finish()
+	if got := first.Field(3).Float(); got != 2 {
+		t.Fatalf("got %v", got)
+	}
+	w.Reset()
+	if err := w.Next(reflect.ValueOf(int64(9))); err != nil {
+		t.Fatal(err)
+	}
+	if first.Field(0).Int() != 1 || w.Finish().Field(0).Int() != 9 || w.Finish().Field(3).Float() != 0 {
+		t.Fatal("Reset must start a new struct")
+	}
+}
+
+func BenchmarkStructWriter(b *testing.B) {
+	typ := reflect.TypeOf(rowT{})
+	id, name, score := reflect.ValueOf(int64(1)), reflect.ValueOf("gopher"), reflect.ValueOf(1.5)
+	b.Run("FieldSet", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			v := reflect.New(typ).Elem()
+			v.Field(0).Set(id)
+			v.Field(2).Set(name)
+			v.Field(3).Set(score)
+		}
+	})
+	b.Run("StructWriter", func(b *testing.B) {
+		w := reflect.NewStructWriter(typ)
+		w.SkipUnexported = true
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			w.Reset()
+			w.Next(id)
+			w.Next(name)
+			w.Next(score)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package reflect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StructTag is an alias of reflect.StructTag, so the parser below is
+// made of functions rather than methods.
+
+// A TagEntry is one key:"value" pair of a struct tag.
+type TagEntry struct {
+	Key string
+	// Value is the unquoted value, as returned by StructTag.Get.
+	Value string
+	// Options holds the comma-separated elements of Value after the
+	// first one, as in `json:"name,omitempty"`.
+	Options []string
+}
+
+// A TagSyntaxError describes malformed struct tag syntax.
+type TagSyntaxError struct {
+	Tag    StructTag
+	Offset int // byte offset in Tag of the error
+	Msg    string
+}
+
+func (e *TagSyntaxError) Error() string {
+	return fmt.Sprintf("reflect: malformed struct tag at offset %d: %s", e.Offset, e.Msg)
+}
+
+// ParseTag returns all key:"value" pairs of tag in order.
+// It follows the conventional syntax StructTag.Get understands, but
+// instead of ignoring what follows a syntax error, it returns a
+// *TagSyntaxError with the offset of the malformed part.
+func ParseTag(tag StructTag) ([]TagEntry, error) {
+	var entries []TagEntry
+	s := string(tag)
+	i := 0
+	for {
+		// Skip leading space.
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i == len(s) {
+			return entries, nil
+		}
+
+		// Scan to colon. A space, a quote or a control character is a syntax error.
+		start := i
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		if i == start {
+			return nil, &TagSyntaxError{Tag: tag, Offset: i, Msg: "missing key"}
+		}
+		key := s[start:i]
+		if i == len(s) || s[i] != ':' {
+			return nil, &TagSyntaxError{Tag: tag, Offset: i, Msg: "missing colon after key " + strconv.Quote(key)}
+		}
+		i++
+		if i == len(s) || s[i] != '"' {
+			return nil, &TagSyntaxError{Tag: tag, Offset: i, Msg: "missing quoted value for key " + strconv.Quote(key)}
+		}
+
+		// Scan quoted string to find value.
+		start = i
+		i++
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			return nil, &TagSyntaxError{Tag: tag, Offset: start, Msg: "unterminated quoted value for key " + strconv.Quote(key)}
+		}
+		i++
+		value, err := strconv.Unquote(s[start:i])
+		if err != nil {
+			return nil, &TagSyntaxError{Tag: tag, Offset: start, Msg: "invalid quoted value for key " + strconv.Quote(key)}
+		}
+		entry := TagEntry{Key: key, Value: value}
+		if _, opts, ok := strings.Cut(value, ","); ok {
+			entry.Options = strings.Split(opts, ",")
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// ValidTag reports whether tag is well-formed according to ParseTag.
+func ValidTag(tag StructTag) bool {
+	_, err := ParseTag(tag)
+	return err == nil
+}
@@ -0,0 +1,71 @@
+package reflect_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+func TestParseTag(t *testing.T) {
+	entries, err := reflect.ParseTag(`json:"name,omitempty,string" xml:"n"  db:""`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if e := entries[0]; e.Key != "json" || e.Value != "name,omitempty,string" ||
+		len(e.Options) != 2 || e.Options[0] != "omitempty" || e.Options[1] != "string" {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+	if e := entries[1]; e.Key != "xml" || e.Value != "n" || e.Options != nil {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+	if e := entries[2]; e.Key != "db" || e.Value != "" {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+
+	// The escaped-quote tag of typeTests.
+	tag := reflect.TypeOf(struct {
+		a int8 `reflect:"hi \x00there\t\n\"\\"`
+	}{}).Field(0).Tag
+	entries, err = reflect.ParseTag(tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Value != tag.Get("reflect") || entries[0].Value != "hi \x00there\t\n\"\\" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if !reflect.ValidTag(tag) || !reflect.ValidTag("") {
+		t.Fatal("expected valid tag")
+	}
+}
+
+func TestParseTagErrors(t *testing.T) {
+	tests := []struct {
+		tag    reflect.StructTag
+		offset int
+	}{
+		{`json:"name`, 5},
+		{`json:"a" xml`, 12},
+		{`json "a"`, 4},
+		{`json:name`, 5},
+		{`:"a"`, 0},
+		{`json:"a" "b"`, 9},
+		{`json:"\x"`, 5},
+	}
+	for _, test := range tests {
+		_, err := reflect.ParseTag(test.tag)
+		var serr *reflect.TagSyntaxError
+		if !errors.As(err, &serr) {
+			t.Fatalf("ParseTag(%q): expected TagSyntaxError, got %v", test.tag, err)
+		}
+		if serr.Offset != test.offset {
+			t.Errorf("ParseTag(%q): offset %d, want %d (%v)", test.tag, serr.Offset, test.offset, err)
+		}
+		if reflect.ValidTag(test.tag) {
+			t.Errorf("ValidTag(%q) = true", test.tag)
+		}
+	}
+}
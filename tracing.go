@@ -0,0 +1,93 @@
+package reflect
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+// A WriteOp identifies the mutating Value method reported by a WriteEvent.
+type WriteOp int
+
+const (
+	WriteSet WriteOp = iota + 1
+	WriteSetBool
+	WriteSetBytes
+	WriteSetCap
+	WriteSetComplex
+	WriteSetFloat
+	WriteSetInt
+	WriteSetLen
+	WriteSetMapIndex
+	WriteSetPointer
+	WriteSetString
+	WriteSetUint
+	WriteSetZero
+	WriteSend
+	WriteTrySend
+	WriteClose
+	WriteStoreAtomic
+)
+
+var writeOpNames = []string{
+	WriteSet:         "Set",
+	WriteSetBool:     "SetBool",
+	WriteSetBytes:    "SetBytes",
+	WriteSetCap:      "SetCap",
+	WriteSetComplex:  "SetComplex",
+	WriteSetFloat:    "SetFloat",
+	WriteSetInt:      "SetInt",
+	WriteSetLen:      "SetLen",
+	WriteSetMapIndex: "SetMapIndex",
+	WriteSetPointer:  "SetPointer",
+	WriteSetString:   "SetString",
+	WriteSetUint:     "SetUint",
+	WriteSetZero:     "SetZero",
+	WriteSend:        "Send",
+	WriteTrySend:     "TrySend",
+	WriteClose:       "Close",
+	WriteStoreAtomic: "StoreAtomic",
+}
+
+// String returns the name of the Value method op stands for.
+func (op WriteOp) String() string {
+	if op > 0 && int(op) < len(writeOpNames) {
+		return writeOpNames[op]
+	}
+	return "WriteOp(" + strconv.Itoa(int(op)) + ")"
+}
+
+// A WriteEvent describes a mutation reported to the tracer installed with
+// SetWriteTracer.
+type WriteEvent struct {
+	Op WriteOp
+	// Type is the type of the mutated value: the channel type for Send,
+	// TrySend and Close and the map type for SetMapIndex.
+	Type Type
+}
+
+var writeTracer atomic.Pointer[func(target Value, ev WriteEvent)]
+
+// SetWriteTracer installs fn to be called synchronously after every
+// successful mutation through one of the Set methods, SetMapIndex, Send,
+// TrySend (if the value was sent), Close and StoreAtomic of a Value,
+// with the mutated Value as target. A nil fn disables tracing, which
+// leaves only a single atomic load on those methods.
+//
+// Tracing is meant for debugging. fn is called on the goroutine performing
+// the mutation, so it may capture a stack trace, but it must not itself
+// mutate values through this package, or it is called recursively.
+// Package-level functions such as Copy are not traced.
+func SetWriteTracer(fn func(target Value, ev WriteEvent)) {
+	if fn == nil {
+		writeTracer.Store(nil)
+		return
+	}
+	writeTracer.Store(&fn)
+}
+
+// traceWrite reports the mutation op of v to the write tracer, if any.
+func (v Value) traceWrite(op WriteOp) {
+	if fn := writeTracer.Load(); fn != nil {
+		(*fn)(v, WriteEvent{Op: op, Type: v.typ})
+	}
+}
@@ -0,0 +1,85 @@
+package reflect
+
+import "strconv"
+
+// A ValueOpError is returned by TrySet and TryConvert to describe why v
+// cannot be set or converted, where Set and Convert would panic.
+type ValueOpError struct {
+	Method string // "reflect.Value.TrySet" or "reflect.Value.TryConvert"
+	From   Type   // type of the value set or converted; nil for the zero Value
+	To     Type   // type of the value set, or converted to
+	Reason string // description of the problem naming the types involved
+}
+
+func (e *ValueOpError) Error() string {
+	return "reflect: " + e.Method + ": " + e.Reason
+}
+
+// unexportedReason describes v, which was obtained using an unexported
+// field.
+func unexportedReason(v Value) string {
+	return "value of type " + v.Type().String() + " obtained using unexported field"
+}
+
+// TrySet is like Set but returns an error instead of panicking: a
+// *ValueError if v is the zero Value, and a *ValueOpError
+// if v cannot be set, because it is not addressable or was obtained using
+// an unexported field, or if x is the zero Value, was obtained using an
+// unexported field, or is not assignable to v's type.
+func (v Value) TrySet(x Value) error {
+	const method = "reflect.Value.TrySet"
+	if v.flag == 0 {
+		return zeroValueError(method)
+	}
+	vt := v.Type()
+	err := &ValueOpError{Method: method, To: vt}
+	if x.flag != 0 {
+		err.From = x.Type()
+	}
+	switch {
+	case v.flag&flagRO != 0:
+		err.Reason = "cannot set " + unexportedReason(v)
+	case v.flag&flagAddr == 0:
+		err.Reason = "cannot set unaddressable value of type " + vt.String()
+	case x.flag == 0:
+		err.Reason = "cannot set value of type " + vt.String() + " to the zero Value"
+	case x.flag&flagRO != 0:
+		err.Reason = "cannot use " + unexportedReason(x)
+	case err.From != vt && !err.From.AssignableTo(vt):
+		err.Reason = explainNotAssignable(err.From, vt)
+	default:
+		value_Set(v, x)
+		v.traceWrite(WriteSet)
+		return nil
+	}
+	return err
+}
+
+// TryConvert is like Convert but returns an error instead of panicking:
+// a *ValueError if v is the zero Value, an error if t is
+// nil, and a *ValueOpError if v cannot be converted to t, including a
+// slice that is shorter than the array type t.
+func (v Value) TryConvert(t Type) (Value, error) {
+	const method = "reflect.Value.TryConvert"
+	if v.flag == 0 {
+		return Value{}, zeroValueError(method)
+	}
+	if t == nil {
+		return Value{}, errNilType("Value.TryConvert")
+	}
+	vt := v.Type()
+	if !vt.ConvertibleTo(t) {
+		return Value{}, &ValueOpError{Method: method, From: vt, To: t,
+			Reason: "value of type " + vt.String() + " cannot be converted to type " + t.String()}
+	}
+	if !v.CanConvert(t) {
+		at := t
+		if at.Kind() == Ptr {
+			at = at.Elem()
+		}
+		n := at.Len()
+		return Value{}, &ValueOpError{Method: method, From: vt, To: t,
+			Reason: "slice of length " + strconv.Itoa(value_Len(v)) + " is too short for type " + t.String() + " of length " + strconv.Itoa(n)}
+	}
+	return v.Convert(t), nil
+}
@@ -2,13 +2,37 @@ package reflect
 
 import (
 	"reflect"
-	_ "unsafe"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
 )
 
 //go:linkname ifaceIndir reflect.ifaceIndir
 //go:noescape
 func ifaceIndir(Type) bool
 
+// A NilTypeError occurs when a function that needs a Type is passed nil.
+// Functions that return errors return it, and the others panic with it.
+type NilTypeError struct {
+	Func string // function that was called, e.g. "New"
+}
+
+func (e *NilTypeError) Error() string {
+	return "reflect: " + e.Func + " of nil Type"
+}
+
+func errNilType(fn string) error {
+	return &NilTypeError{Func: fn}
+}
+
+// mustBeNonNilType panics with a *NilTypeError naming fn if t is nil.
+func mustBeNonNilType(t Type, fn string) {
+	if t == nil {
+		panic(errNilType(fn))
+	}
+}
+
 func arrayOf(i int, typ Type) Type {
 	return toT(reflect.ArrayOf(i, toRT(typ)))
 }
@@ -17,8 +41,59 @@ func chanOf(dir ChanDir, typ Type) Type {
 	return toT(reflect.ChanOf(dir, toRT(typ)))
 }
 
+// funcOfCache maps a signature, encoded by appendFuncKey, to its function
+// type. reflect.FuncOf canonicalizes the types it returns as well, but
+// allocates a prototype type on every call before consulting its cache.
+var funcOfCache struct {
+	sync.RWMutex
+	m map[string]Type
+}
+
+// appendFuncKey appends to b an encoding of a signature that distinguishes
+// it from all others: the variadic flag, the number of arguments and the
+// addresses of the argument and result types. Types are never freed, so
+// their addresses cannot be reused by other types.
+func appendFuncKey(b []byte, in, out []Type, variadic bool) []byte {
+	if variadic {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	b = appendUintptr(b, uintptr(len(in)))
+	for _, t := range in {
+		b = appendUintptr(b, uintptr(unsafe.Pointer(t)))
+	}
+	for _, t := range out {
+		b = appendUintptr(b, uintptr(unsafe.Pointer(t)))
+	}
+	return b
+}
+
+func appendUintptr(b []byte, x uintptr) []byte {
+	for i := uintptr(0); i < unsafe.Sizeof(x); i++ {
+		b = append(b, byte(x))
+		x >>= 8
+	}
+	return b
+}
+
 func funcOf(in []Type, out []Type, variadic bool) Type {
-	return toT(reflect.FuncOf(toRTs(in), toRTs(out), variadic))
+	var buf [128]byte
+	key := appendFuncKey(buf[:0], in, out, variadic)
+	funcOfCache.RLock()
+	ft, ok := funcOfCache.m[string(key)]
+	funcOfCache.RUnlock()
+	if ok {
+		return ft
+	}
+	ft = toT(reflect.FuncOf(toRTs(in), toRTs(out), variadic))
+	funcOfCache.Lock()
+	if funcOfCache.m == nil {
+		funcOfCache.m = make(map[string]Type)
+	}
+	funcOfCache.m[string(key)] = ft
+	funcOfCache.Unlock()
+	return ft
 }
 
 func mapOf(key Type, elem Type) Type {
@@ -33,10 +108,20 @@ func sliceOf(t Type) Type {
 	return toT(reflect.SliceOf(toRT(t)))
 }
 
+// isExportedName reports whether name starts with an upper-case letter.
+func isExportedName(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
+
 func structOf(fields []StructField) Type {
 	return ToType(reflect.StructOf(toRSFs(fields)))
 }
 
+//go:linkname typedmemmove runtime.typedmemmove
+//go:noescape
+func typedmemmove(t Type, dst, src unsafe.Pointer)
+
 //go:linkname type_Align reflect.(*rtype).Align
 //go:noescape
 func type_Align(Type) int
@@ -157,6 +242,39 @@ func type_Out(Type, int) reflect.Type
 //go:noescape
 func type_toType(t Type) reflect.Type
 
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+func fnvString(h uint64, s string) uint64 {
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+func fnvUint64(h uint64, x uint64) uint64 {
+	for i := 0; i < 8; i++ {
+		h ^= x & 0xff
+		h *= fnvPrime64
+		x >>= 8
+	}
+	return h
+}
+
+// typeFingerprint hashes the process-independent identity of t with FNV-1a.
+func typeFingerprint(t Type) uint64 {
+	h := uint64(fnvOffset64)
+	h = fnvString(h, type_String(t))
+	h = fnvString(h, "\x00")
+	h = fnvString(h, type_PkgPath(t))
+	h = fnvUint64(h, uint64(type_Kind(t)))
+	h = fnvUint64(h, uint64(type_Size(t)))
+	return h
+}
+
 var dummy struct {
 	b bool
 	x any
@@ -0,0 +1,52 @@
+package reflect
+
+import "errors"
+
+// TypedFunc returns the function fn as a plain Go func of type F, so that
+// hot paths can call it directly instead of through Call. fn's type must
+// be assignable to F, as for Set.
+//
+// It returns an error naming both signatures if it is not, and an error if
+// F is not a func type, if fn is a nil func or if fn was obtained using an
+// unexported struct field.
+//
+// Funcs are pointer-shaped, so no copy of fn is made: the result is the
+// function fn refers to, including one created by MakeFunc. A method value
+// is turned into a func on each call, as by Interface.
+func TypedFunc[F any](fn Value) (F, error) {
+	var zero F
+	ft := TypeFor[F]()
+	if ft.Kind() != Func {
+		return zero, errors.New("reflect: TypedFunc of non-func type " + ft.String())
+	}
+	if k := fn.Kind(); k != Func {
+		return zero, errors.New("reflect: TypedFunc of " + k.String() + " value")
+	}
+	if fn.flag&flagRO != 0 {
+		return zero, errors.New("reflect: TypedFunc of func obtained using unexported field")
+	}
+	if fn.flag&flagMethod == 0 && fn.pointer() == nil {
+		return zero, errors.New("reflect: TypedFunc of nil func of type " + fn.typ.String())
+	}
+	typ := fn.Type()
+	if !typ.AssignableTo(ft) {
+		return zero, errors.New("reflect: TypedFunc: " + explainNotAssignable(typ, ft))
+	}
+	if typ != ft {
+		fn = value_Convert(fn, ft)
+	}
+	if i, ok := value_InterfaceDirect(fn); ok {
+		return i.(F), nil
+	}
+	return value_Interface(fn).(F), nil
+}
+
+// MustTypedFunc is like TypedFunc but panics if fn cannot be returned as
+// a func of type F. It simplifies wiring functions up at initialization.
+func MustTypedFunc[F any](fn Value) F {
+	f, err := TypedFunc[F](fn)
+	if err != nil {
+		panic(err.Error())
+	}
+	return f
+}
@@ -0,0 +1,64 @@
+package reflect_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type binaryOp func(int, int) int
+
+func TestTypedFunc(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	f, err := reflect.TypedFunc[func(int, int) int](reflect.ValueOf(add))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f(1, 2); got != 3 {
+		t.Errorf("compiled func returned %d, want 3", got)
+	}
+
+	mul := reflect.MakeFunc(reflect.TypeOf(add), func(in []reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.ValueOf(int(in[0].Int() * in[1].Int()))}
+	})
+	op := reflect.MustTypedFunc[binaryOp](mul)
+	if got := op(3, 4); got != 12 {
+		t.Errorf("MakeFunc func returned %d, want 12", got)
+	}
+	if !reflect.CrossCheckEnabled() {
+		if n := testing.AllocsPerRun(10, func() { reflect.MustTypedFunc[binaryOp](mul) }); n > 0 {
+			t.Errorf("MustTypedFunc of MakeFunc result allocates %v times, want 0", n)
+		}
+	}
+
+	p := Point{1, 2}
+	dist, err := reflect.TypedFunc[func(int) int](reflect.ValueOf(p).MethodByName("Dist"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := dist(3), p.Dist(3); got != want {
+		t.Errorf("method value returned %d, want %d", got, want)
+	}
+
+	_, err = reflect.TypedFunc[func(string) int](reflect.ValueOf(add))
+	if err == nil || !strings.Contains(err.Error(), "func(int, int) int") || !strings.Contains(err.Error(), "func(string) int") {
+		t.Errorf("TypedFunc mismatch error = %v, want both signatures", err)
+	}
+
+	var nilFunc func(int, int) int
+	for _, fn := range []reflect.Value{
+		{},
+		reflect.ValueOf(1),
+		reflect.ValueOf(nilFunc),
+		reflect.ValueOf(struct{ f func(int, int) int }{add}).Field(0),
+	} {
+		if _, err := reflect.TypedFunc[func(int, int) int](fn); err == nil {
+			t.Errorf("TypedFunc(%v) succeeded", fn)
+		}
+	}
+	if _, err := reflect.TypedFunc[int](reflect.ValueOf(add)); err == nil {
+		t.Errorf("TypedFunc[int] succeeded")
+	}
+	shouldPanic(func() { reflect.MustTypedFunc[func()](reflect.ValueOf(add)) })
+}
@@ -0,0 +1,161 @@
+package reflect
+
+import (
+	"strconv"
+)
+
+// A TypeChangeKind is the kind of a TypeChange.
+type TypeChangeKind int
+
+const (
+	FieldAdded TypeChangeKind = iota + 1
+	FieldRemoved
+	FieldRenamed
+	FieldTypeChanged
+	FieldTagChanged
+	SizeChanged
+	AlignChanged
+)
+
+var typeChangeKindNames = []string{
+	FieldAdded:       "field added",
+	FieldRemoved:     "field removed",
+	FieldRenamed:     "field renamed",
+	FieldTypeChanged: "type changed",
+	FieldTagChanged:  "tag changed",
+	SizeChanged:      "size changed",
+	AlignChanged:     "alignment changed",
+}
+
+// String returns a description of the change kind.
+func (k TypeChangeKind) String() string {
+	if k > 0 && int(k) < len(typeChangeKindNames) {
+		return typeChangeKindNames[k]
+	}
+	return "TypeChangeKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// A TypeChange describes one difference between two types found by TypeDiff.
+type TypeChange struct {
+	Kind TypeChangeKind
+	// Path is the dot-separated path of the field the change applies to,
+	// named as in the new type, or empty for the types themselves.
+	// For a removed field it is named as in the old type.
+	Path string
+	// Old and New describe the changed property before and after the change:
+	// the field type for FieldAdded, FieldRemoved and FieldTypeChanged,
+	// the field name for FieldRenamed, the tag for FieldTagChanged and
+	// the decimal size or alignment for SizeChanged and AlignChanged.
+	// Old is empty for FieldAdded and New is empty for FieldRemoved.
+	Old, New string
+}
+
+// String returns a one-line description of the change, suitable for logging.
+func (c TypeChange) String() string {
+	s := c.Kind.String()
+	if c.Path != "" {
+		s = c.Path + ": " + s
+	}
+	switch c.Kind {
+	case FieldAdded:
+		return s + " (" + c.New + ")"
+	case FieldRemoved:
+		return s + " (" + c.Old + ")"
+	case FieldTagChanged:
+		return s + " " + strconv.Quote(c.Old) + " -> " + strconv.Quote(c.New)
+	}
+	return s + " " + c.Old + " -> " + c.New
+}
+
+// TypeDiff reports how the type b differs from the type a, typically two
+// versions of the same struct type.
+//
+// For struct types, fields are matched by name. An unmatched field of a and
+// an unmatched field of b at the same position with identical type and tag
+// are reported as a rename; other unmatched fields as removed and added.
+// Matched fields are compared by type and tag; if both are struct types,
+// the comparison recurses one level and reports the changes of their fields
+// instead, falling back to FieldTypeChanged if those are identical but the
+// types still differ. Changes of the size and alignment of a type are
+// reported after the changes of its fields.
+// Any other pair of distinct types is reported as a single FieldTypeChanged.
+//
+// The result depends only on a and b, so it can be compared and logged.
+// It is nil if a and b are identical.
+func TypeDiff(a, b Type) []TypeChange {
+	mustBeNonNilType(a, "TypeDiff")
+	mustBeNonNilType(b, "TypeDiff")
+	return typeDiff(nil, a, b, "", 1)
+}
+
+func typeDiff(changes []TypeChange, a, b Type, path string, depth int) []TypeChange {
+	if a == b {
+		return changes
+	}
+	if a.Kind() != Struct || b.Kind() != Struct {
+		return append(changes, TypeChange{Kind: FieldTypeChanged, Path: path, Old: a.String(), New: b.String()})
+	}
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	na, nb := a.NumField(), b.NumField()
+	matched := make([]bool, nb)
+	for i := 0; i < na; i++ {
+		if j, ok := fieldIndexByOwnName(b, a.Field(i).Name); ok {
+			matched[j] = true
+		}
+	}
+	for i := 0; i < na; i++ {
+		fa := a.Field(i)
+		j, ok := fieldIndexByOwnName(b, fa.Name)
+		if !ok {
+			if i < nb && !matched[i] {
+				if fb := b.Field(i); fb.Type == fa.Type && fb.Tag == fa.Tag {
+					matched[i] = true
+					changes = append(changes, TypeChange{Kind: FieldRenamed, Path: prefix + fb.Name, Old: fa.Name, New: fb.Name})
+					continue
+				}
+			}
+			changes = append(changes, TypeChange{Kind: FieldRemoved, Path: prefix + fa.Name, Old: fa.Type.String()})
+			continue
+		}
+		fb := b.Field(j)
+		if fa.Type != fb.Type {
+			n := len(changes)
+			if depth > 0 && fa.Type.Kind() == Struct && fb.Type.Kind() == Struct {
+				changes = typeDiff(changes, fa.Type, fb.Type, prefix+fb.Name, depth-1)
+			}
+			if len(changes) == n {
+				changes = append(changes, TypeChange{Kind: FieldTypeChanged, Path: prefix + fb.Name, Old: fa.Type.String(), New: fb.Type.String()})
+			}
+		}
+		if fa.Tag != fb.Tag {
+			changes = append(changes, TypeChange{Kind: FieldTagChanged, Path: prefix + fb.Name, Old: string(fa.Tag), New: string(fb.Tag)})
+		}
+	}
+	for j := 0; j < nb; j++ {
+		if !matched[j] {
+			fb := b.Field(j)
+			changes = append(changes, TypeChange{Kind: FieldAdded, Path: prefix + fb.Name, New: fb.Type.String()})
+		}
+	}
+	if sa, sb := a.Size(), b.Size(); sa != sb {
+		changes = append(changes, TypeChange{Kind: SizeChanged, Path: path, Old: strconv.FormatUint(uint64(sa), 10), New: strconv.FormatUint(uint64(sb), 10)})
+	}
+	if aa, ab := a.Align(), b.Align(); aa != ab {
+		changes = append(changes, TypeChange{Kind: AlignChanged, Path: path, Old: strconv.Itoa(aa), New: strconv.Itoa(ab)})
+	}
+	return changes
+}
+
+// fieldIndexByOwnName returns the index of the field of the struct type t
+// named name, ignoring promoted fields.
+func fieldIndexByOwnName(t Type, name string) (int, bool) {
+	for i, n := 0, t.NumField(); i < n; i++ {
+		if t.Field(i).Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,93 @@
+package reflect_test
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+	"unsafe"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type addrV1 struct {
+	Street string
+	Zip    int
+}
+
+type addrV2 struct {
+	Street string
+	Zip    string
+}
+
+type recordV1 struct {
+	ID   int `json:"id"`
+	Name string
+	Addr addrV1
+	Note string `json:"note"`
+	Old  bool
+}
+
+type recordV2 struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Addr   addrV2
+	Remark string `json:"note"`
+	New    float64
+}
+
+func TestTypeDiff(t *testing.T) {
+	size := func(n uintptr) string { return strconv.FormatUint(uint64(n), 10) }
+	got := reflect.TypeDiff(reflect.TypeOf(recordV1{}), reflect.TypeOf(recordV2{}))
+	want := []reflect.TypeChange{
+		{Kind: reflect.FieldTypeChanged, Path: "ID", Old: "int", New: "int64"},
+		{Kind: reflect.FieldTagChanged, Path: "Name", Old: "", New: `json:"name"`},
+		{Kind: reflect.FieldTypeChanged, Path: "Addr.Zip", Old: "int", New: "string"},
+		{Kind: reflect.SizeChanged, Path: "Addr", Old: size(unsafe.Sizeof(addrV1{})), New: size(unsafe.Sizeof(addrV2{}))},
+		{Kind: reflect.FieldRenamed, Path: "Remark", Old: "Note", New: "Remark"},
+		{Kind: reflect.FieldRemoved, Path: "Old", Old: "bool"},
+		{Kind: reflect.FieldAdded, Path: "New", New: "float64"},
+		{Kind: reflect.SizeChanged, Path: "", Old: size(unsafe.Sizeof(recordV1{})), New: size(unsafe.Sizeof(recordV2{}))},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("TypeDiff:\ngot  %v\nwant %v", got, want)
+	}
+
+	type small1 struct{ A int8 }
+	type small2 struct {
+		A int8
+		B int16
+	}
+	got = reflect.TypeDiff(reflect.TypeOf(small1{}), reflect.TypeOf(small2{}))
+	want = []reflect.TypeChange{
+		{Kind: reflect.FieldAdded, Path: "B", New: "int16"},
+		{Kind: reflect.SizeChanged, Old: "1", New: "4"},
+		{Kind: reflect.AlignChanged, Old: "1", New: "2"},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("TypeDiff:\ngot  %v\nwant %v", got, want)
+	}
+
+	// Nested structs that differ only by name are reported as a type change.
+	type inner1 struct{ X int }
+	type inner2 struct{ X int }
+	type outer1 struct{ In inner1 }
+	type outer2 struct{ In inner2 }
+	got = reflect.TypeDiff(reflect.TypeOf(outer1{}), reflect.TypeOf(outer2{}))
+	want = []reflect.TypeChange{
+		{Kind: reflect.FieldTypeChanged, Path: "In", Old: "reflect_test.inner1", New: "reflect_test.inner2"},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("TypeDiff:\ngot  %v\nwant %v", got, want)
+	}
+
+	if got := reflect.TypeDiff(reflect.TypeOf(recordV1{}), reflect.TypeOf(recordV1{})); got != nil {
+		t.Fatalf("TypeDiff of identical types = %v", got)
+	}
+	got = reflect.TypeDiff(reflect.TypeOf(1), reflect.TypeOf(""))
+	if len(got) != 1 || got[0].String() != "type changed int -> string" {
+		t.Fatalf("TypeDiff(int, string) = %v", got)
+	}
+	if s := want[0].String(); s != "In: type changed reflect_test.inner1 -> reflect_test.inner2" {
+		t.Fatalf("String = %q", s)
+	}
+}
@@ -0,0 +1,119 @@
+package reflect
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// TypeFlags is a set of facts about a type that encoders commonly branch
+// on, as returned by TypeFlagsOf. The values of the flags are stable, so
+// TypeFlags may be stored or compared across releases.
+type TypeFlags uint16
+
+const (
+	// PointerFree means that values of the type hold no pointers, so
+	// they can be copied as plain memory.
+	PointerFree TypeFlags = 1 << iota
+	// ZeroSize means that values of the type occupy no memory, such as
+	// struct{} and [0]int.
+	ZeroSize
+	// DirectIface means that an interface holding a value of the type
+	// stores the value itself in its data word, as reported by IfaceIndir
+	// being false.
+	DirectIface
+	// ComparableFlag means that values of the type are comparable, as
+	// reported by Type.Comparable.
+	ComparableFlag
+	// StringerByValue and StringerByPointer mean that the type implements
+	// fmt.Stringer, or only a pointer to it does, as reported by
+	// ImplementsStringer.
+	StringerByValue
+	StringerByPointer
+	// TextMarshalerByValue and TextMarshalerByPointer mean that the type
+	// implements encoding.TextMarshaler, or only a pointer to it does, as
+	// reported by ImplementsTextMarshaler.
+	TextMarshalerByValue
+	TextMarshalerByPointer
+	// Anonymous means that the type has no name, such as []int or
+	// struct{ A int }.
+	Anonymous
+)
+
+var typeFlagNames = []string{
+	"PointerFree",
+	"ZeroSize",
+	"DirectIface",
+	"Comparable",
+	"StringerByValue",
+	"StringerByPointer",
+	"TextMarshalerByValue",
+	"TextMarshalerByPointer",
+	"Anonymous",
+}
+
+// Has reports whether f contains all the flags in flags.
+func (f TypeFlags) Has(flags TypeFlags) bool {
+	return f&flags == flags
+}
+
+// String returns the names of the flags in f separated by |, such as
+// "PointerFree|DirectIface", or "0" if f is empty.
+func (f TypeFlags) String() string {
+	if f == 0 {
+		return "0"
+	}
+	var b strings.Builder
+	for i, name := range typeFlagNames {
+		if f&(1<<i) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('|')
+		}
+		b.WriteString(name)
+	}
+	return b.String()
+}
+
+var typeFlagsCache typeCache[TypeFlags]
+
+// TypeFlagsOf returns the TypeFlags of t. They are computed once per type
+// and cached, so that later calls are a single map read; the interface
+// bits come from the ImplCheck cache.
+// It panics if t is nil.
+func TypeFlagsOf(t Type) TypeFlags {
+	mustBeNonNilType(t, "TypeFlagsOf")
+	return typeFlagsCache.get(t, buildTypeFlags)
+}
+
+func buildTypeFlags(t Type) TypeFlags {
+	var f TypeFlags
+	if (*abiType)(unsafe.Pointer(t)).ptrBytes == 0 {
+		f |= PointerFree
+	}
+	if t.Size() == 0 {
+		f |= ZeroSize
+	}
+	if !ifaceIndir(t) {
+		f |= DirectIface
+	}
+	if t.Comparable() {
+		f |= ComparableFlag
+	}
+	switch ImplementsStringer(t) {
+	case ImplByValue:
+		f |= StringerByValue
+	case ImplByPointer:
+		f |= StringerByPointer
+	}
+	switch ImplementsTextMarshaler(t) {
+	case ImplByValue:
+		f |= TextMarshalerByValue
+	case ImplByPointer:
+		f |= TextMarshalerByPointer
+	}
+	if t.Name() == "" {
+		f |= Anonymous
+	}
+	return f
+}
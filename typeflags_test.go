@@ -0,0 +1,98 @@
+package reflect_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type flagsPOD struct {
+	A int
+	B [4]float64
+}
+
+type flagsWithPtr struct {
+	A int
+	P *int
+}
+
+type flagsFunc func()
+
+func TestTypeFlagsOf(t *testing.T) {
+	const (
+		pod  = reflect.PointerFree | reflect.ComparableFlag
+		anon = reflect.Anonymous
+	)
+	for _, tc := range []struct {
+		typ  reflect.Type
+		want reflect.TypeFlags
+	}{
+		{reflect.TypeOf(0), pod},
+		{reflect.TypeOf(flagsPOD{}), pod},
+		{reflect.TypeOf(flagsWithPtr{}), reflect.ComparableFlag},
+		{reflect.TypeOf(&flagsPOD{}), reflect.DirectIface | reflect.ComparableFlag | anon},
+		{reflect.TypeOf([0]*byte{}), pod | reflect.ZeroSize | anon},
+		{reflect.TypeOf(struct{}{}), pod | reflect.ZeroSize | anon},
+		{reflect.TypeOf(flagsFunc(nil)), reflect.DirectIface},
+		{reflect.TypeOf([]int(nil)), anon},
+		{reflect.TypeOf(struct{ M map[string]int }{}), reflect.DirectIface | anon},
+		{reflect.TypeOf(implValueStringer{}), pod | reflect.ZeroSize | reflect.StringerByValue},
+		{reflect.TypeOf(implPtrMarshaler{}), pod | reflect.ZeroSize | reflect.TextMarshalerByPointer},
+		{reflect.TypeOf(&implPtrMarshaler{}), reflect.DirectIface | reflect.ComparableFlag | reflect.TextMarshalerByValue | anon},
+		{reflect.TypeOf(time.Time{}), reflect.ComparableFlag | reflect.StringerByValue | reflect.TextMarshalerByValue},
+		{reflect.StringerType, reflect.ComparableFlag | reflect.StringerByValue},
+	} {
+		if got := reflect.TypeFlagsOf(tc.typ); got != tc.want {
+			t.Errorf("TypeFlagsOf(%v) = %v, want %v", tc.typ, got, tc.want)
+		}
+	}
+
+	f := reflect.TypeFlagsOf(reflect.TypeOf(flagsPOD{}))
+	if !f.Has(reflect.PointerFree) || !f.Has(pod) || f.Has(pod|reflect.ZeroSize) {
+		t.Errorf("%v.Has reports wrong results", f)
+	}
+	if s := (reflect.PointerFree | reflect.DirectIface | reflect.Anonymous).String(); s != "PointerFree|DirectIface|Anonymous" {
+		t.Errorf("String = %q", s)
+	}
+	if s := reflect.TypeFlags(0).String(); s != "0" {
+		t.Errorf("String of no flags = %q", s)
+	}
+
+	typ := reflect.TypeOf(flagsWithPtr{})
+	reflect.TypeFlagsOf(typ)
+	if n := testing.AllocsPerRun(100, func() { reflect.TypeFlagsOf(typ) }); n != 0 {
+		t.Errorf("cached TypeFlagsOf: %v allocs, want 0", n)
+	}
+}
+
+func BenchmarkTypeFlagsOf(b *testing.B) {
+	types := []reflect.Type{
+		reflect.TypeOf(flagsPOD{}),
+		reflect.TypeOf(flagsWithPtr{}),
+		reflect.TypeOf(time.Time{}),
+		reflect.TypeOf(implPtrMarshaler{}),
+	}
+	// Dispatch as an encoder would: use a marshaler if there is one,
+	// otherwise copy pointer-free values as memory.
+	b.Run("TypeFlagsOf", func(b *testing.B) {
+		n := 0
+		for i := 0; i < b.N; i++ {
+			f := reflect.TypeFlagsOf(types[i%len(types)])
+			if f&(reflect.TextMarshalerByValue|reflect.StringerByValue) != 0 || f.Has(reflect.PointerFree|reflect.ComparableFlag) {
+				n++
+			}
+		}
+	})
+	b.Run("Predicates", func(b *testing.B) {
+		n := 0
+		for i := 0; i < b.N; i++ {
+			t := types[i%len(types)]
+			if reflect.ImplementsTextMarshaler(t) == reflect.ImplByValue || reflect.ImplementsStringer(t) == reflect.ImplByValue ||
+				!slices.Contains(reflect.Explain(t).PointerWords, true) && t.Comparable() {
+				n++
+			}
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package reflect
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// A TypePool is a set of sync.Pools, one per Type, for reusing values of
+// types that are only known at run time.
+// The zero value is ready to use, and a TypePool is safe for concurrent use.
+type TypePool struct {
+	pools typeCache[*typePool]
+}
+
+// A typePool is the pool of one type of a TypePool. It keeps track of the
+// values it has allocated, so that Put only takes back values handed out
+// by Get. They are keyed by address, which does not keep them alive; an
+// entry is dropped when its value is garbage collected, before the
+// address can be reused.
+type typePool struct {
+	pool   sync.Pool // unsafe.Pointer
+	mu     sync.Mutex
+	out    map[uintptr]bool // whether the value is handed out, by address
+	forget func(*byte)      // finalizer dropping the entry of a value
+}
+
+func newTypePool(t Type) *typePool {
+	tp := &typePool{out: make(map[uintptr]bool)}
+	tp.forget = func(p *byte) {
+		tp.mu.Lock()
+		delete(tp.out, uintptr(unsafe.Pointer(p)))
+		tp.mu.Unlock()
+	}
+	tp.pool.New = func() any {
+		ptr := value_New(t).ptr
+		if t.Size() == 0 {
+			// All zero-size values share one address; there is
+			// nothing to reuse or to alias.
+			return ptr
+		}
+		tp.mu.Lock()
+		tp.out[uintptr(ptr)] = false
+		tp.mu.Unlock()
+		runtime.SetFinalizer((*byte)(ptr), tp.forget)
+		return ptr
+	}
+	return tp
+}
+
+// Get returns an addressable zero Value of type t, reusing a value
+// previously passed to Put if one is available.
+// It panics if t is nil.
+func (p *TypePool) Get(t Type) Value {
+	mustBeNonNilType(t, "TypePool.Get")
+	tp := p.pools.get(t, newTypePool)
+	ptr := tp.pool.Get().(unsafe.Pointer)
+	if t.Size() != 0 {
+		tp.mu.Lock()
+		tp.out[uintptr(ptr)] = true
+		tp.mu.Unlock()
+	}
+	return Value{typ: t, ptr: ptr, flag: flag(t.Kind()) | flagIndir | flagAddr}
+}
+
+// Put zeroes v and adds it to the pool of its type.
+// v must not be used afterwards.
+// It returns an error, and leaves v alone, unless v is a Value returned by
+// Get of p, or one referring to the same value, such as v.Addr().Elem(), that
+// has not been passed to Put since. In particular, Put does not take other
+// addressable values, such as fields of structs or elements of slices.
+func (p *TypePool) Put(v Value) error {
+	if !v.IsValid() {
+		return errors.New("reflect: TypePool.Put of invalid Value")
+	}
+	if v.flag&flagAddr == 0 {
+		return errors.New("reflect: TypePool.Put of unaddressable value of type " + v.Type().String())
+	}
+	if v.flag&flagRO != 0 {
+		return errors.New("reflect: TypePool.Put of value of type " + v.Type().String() + " obtained using unexported field")
+	}
+	tp, ok := p.pools.load(v.typ)
+	if !ok {
+		return errors.New("reflect: TypePool.Put of value of unpooled type " + v.Type().String())
+	}
+	if v.typ.Size() != 0 {
+		tp.mu.Lock()
+		out, ok := tp.out[uintptr(v.ptr)]
+		if out {
+			tp.out[uintptr(v.ptr)] = false
+		}
+		tp.mu.Unlock()
+		if !ok {
+			return errors.New("reflect: TypePool.Put of value of type " + v.Type().String() + " not obtained from TypePool.Get")
+		}
+		if !out {
+			return errors.New("reflect: TypePool.Put of value of type " + v.Type().String() + " already put")
+		}
+	}
+	value_SetZero(v)
+	tp.pool.Put(v.ptr)
+	return nil
+}
+
+// A pooledItem is a value handed out by NewPooled, together with its
+// release function, which is bound once when the item is allocated so that
+// handing the item out again costs no allocation.
+type pooledItem struct {
+	v       Value // the addressable value
+	pool    *sync.Pool
+	out     atomic.Bool // whether the item is handed out
+	release func()      // the release method value
+}
+
+func (item *pooledItem) free() {
+	if !item.out.CompareAndSwap(true, false) {
+		panic("reflect: NewPooled release function called twice")
+	}
+	value_SetZero(item.v)
+	item.pool.Put(item)
+}
+
+var pooledItems typeCache[*sync.Pool] // *pooledItem by Type
+
+func newPooledItems(t Type) *sync.Pool {
+	pool := new(sync.Pool)
+	pool.New = func() any {
+		item := &pooledItem{v: value_Elem(value_New(t)), pool: pool}
+		item.release = item.free
+		return item
+	}
+	return pool
+}
+
+// NewPooled is like New, but takes the value from a pool of values of type
+// t shared by the package, and returns with it a function that releases
+// the value: it zeroes the value and returns it to the pool, so that
+// short-lived values of types only known at run time do not each cost an
+// allocation of their size. The release function belongs to the pooled
+// value, so neither does NewPooled allocate once the pool is warm.
+//
+// Neither the Value nor any pointer obtained from it may be used once the
+// release function has been called, and the release function must not be
+// called again: a second call panics if the value has not been handed out
+// since, but otherwise releases the value of its next user. A value that is
+// never released is garbage collected as usual.
+// NewPooled panics if t is nil.
+func NewPooled(t Type) (Value, func()) {
+	mustBeNonNilType(t, "NewPooled")
+	item := pooledItems.get(t, newPooledItems).Get().(*pooledItem)
+	item.out.Store(true)
+	return Value{typ: PtrTo(t), ptr: item.v.ptr, flag: flag(Ptr)}, item.release
+}
@@ -0,0 +1,180 @@
+package reflect_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/3JoB/go-reflect"
+)
+
+type pooledT struct {
+	Name  string
+	Tags  []string
+	Next  *pooledT
+	Count int
+}
+
+func TestTypePool(t *testing.T) {
+	var pool reflect.TypePool
+	typ := reflect.TypeOf(pooledT{})
+	v := pool.Get(typ)
+	if !v.CanSet() || v.Type() != typ {
+		t.Fatalf("unexpected pooled value %v", v)
+	}
+	p := v.Addr().Interface().(*pooledT)
+	*p = pooledT{Name: "a", Tags: []string{"x"}, Next: p, Count: 1}
+	if err := pool.Put(v); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*p, pooledT{}) {
+		t.Fatalf("Put did not zero the value: %+v", *p)
+	}
+	for i := 0; i < 10; i++ {
+		v := pool.Get(typ)
+		if !v.IsZero() {
+			t.Fatalf("recycled value is not zero: %+v", v.Interface())
+		}
+		v.Field(0).SetString("b")
+		if err := pool.Put(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTypePoolPutErrors(t *testing.T) {
+	var pool reflect.TypePool
+	typ := reflect.TypeOf(pooledT{})
+	pool.Get(typ)
+	if err := pool.Put(reflect.ValueOf(pooledT{})); err == nil {
+		t.Fatal("expected error for unaddressable value")
+	}
+	if err := pool.Put(reflect.New(reflect.TypeOf(0)).Elem()); err == nil {
+		t.Fatal("expected error for unpooled type")
+	}
+	if err := pool.Put(reflect.Value{}); err == nil {
+		t.Fatal("expected error for invalid value")
+	}
+	if err := pool.Put(reflect.ValueOf(&struct{ p pooledT }{}).Elem().Field(0)); err == nil {
+		t.Fatal("expected error for value obtained using unexported field")
+	}
+
+	// Only values handed out by Get are taken back, once.
+	live := struct{ P pooledT }{pooledT{Name: "live"}}
+	if err := pool.Put(reflect.ValueOf(&live).Elem().Field(0)); err == nil {
+		t.Fatal("expected error for a field of a live struct")
+	}
+	if live.P.Name != "live" {
+		t.Fatalf("rejected value was zeroed: %+v", live.P)
+	}
+	if err := pool.Put(reflect.New(typ).Elem()); err == nil {
+		t.Fatal("expected error for a value not obtained from Get")
+	}
+	v := pool.Get(typ)
+	if err := pool.Put(v.Addr().Elem()); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Put(v); err == nil {
+		t.Fatal("expected error for a value put twice")
+	}
+	var other reflect.TypePool
+	w := other.Get(typ)
+	if err := pool.Put(w); err == nil {
+		t.Fatal("expected error for a value of another TypePool")
+	}
+
+	empty := reflect.TypeOf(struct{}{})
+	if err := pool.Put(pool.Get(empty)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewPooled(t *testing.T) {
+	typ := reflect.TypeOf(pooledT{})
+	for i := 0; i < 10; i++ {
+		v, release := reflect.NewPooled(typ)
+		if v.Type() != reflect.PtrTo(typ) || !v.Elem().CanSet() {
+			t.Fatalf("NewPooled returned %v of type %v", v, v.Type())
+		}
+		if !v.Elem().IsZero() {
+			t.Fatalf("recycled value is not zero: %+v", v.Elem().Interface())
+		}
+		p := v.Interface().(*pooledT)
+		*p = pooledT{Name: "a", Tags: []string{"x"}, Next: p, Count: i}
+		release()
+		if !reflect.DeepEqual(*p, pooledT{}) {
+			t.Fatalf("release did not zero the value: %+v", *p)
+		}
+		shouldPanic(release)
+	}
+	shouldPanic(func() { reflect.NewPooled(nil) })
+
+	if !reflect.CrossCheckEnabled() {
+		_, release := reflect.NewPooled(typ)
+		release()
+		n := testing.AllocsPerRun(100, func() {
+			_, release := reflect.NewPooled(typ)
+			release()
+		})
+		if n != 0 {
+			t.Errorf("NewPooled and release: %v allocs, want 0", n)
+		}
+	}
+}
+
+// pooled256 is a 256-byte struct.
+type pooled256 struct {
+	Name string
+	Data [29]int64
+}
+
+func BenchmarkNewPooled(b *testing.B) {
+	typ := reflect.TypeOf(pooled256{})
+	// gcs reports the number of collections per million operations.
+	gcs := func(b *testing.B, f func(int)) {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			f(i)
+		}
+		b.StopTimer()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.NumGC-before.NumGC)*1e6/float64(b.N), "gcs/Mop")
+	}
+	b.Run("New", func(b *testing.B) {
+		gcs(b, func(i int) {
+			v := reflect.New(typ)
+			v.Elem().Field(1).Index(0).SetInt(int64(i))
+		})
+	})
+	b.Run("NewPooled", func(b *testing.B) {
+		gcs(b, func(i int) {
+			v, release := reflect.NewPooled(typ)
+			v.Elem().Field(1).Index(0).SetInt(int64(i))
+			release()
+		})
+	})
+}
+
+func BenchmarkTypePool(b *testing.B) {
+	typ := reflect.TypeOf(pooledT{})
+	b.Run("New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			v := reflect.New(typ).Elem()
+			v.Field(3).SetInt(int64(i))
+		}
+	})
+	b.Run("TypePool", func(b *testing.B) {
+		var pool reflect.TypePool
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			v := pool.Get(typ)
+			v.Field(3).SetInt(int64(i))
+			if err := pool.Put(v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -52,7 +52,11 @@ func value_NewAt(typ Type, p unsafe.Pointer) Value {
 }
 
 func value_Select(cases []SelectCase) (int, Value, bool) {
-	chosen, recv, recvOK := reflect.Select(toRSCs(cases))
+	return value_SelectRSCs(toRSCs(cases))
+}
+
+func value_SelectRSCs(cases []reflect.SelectCase) (int, Value, bool) {
+	chosen, recv, recvOK := reflect.Select(cases)
 	return chosen, toV(recv), recvOK
 }
 
@@ -144,6 +148,51 @@ func value_Interface(v Value) any {
 	return toRV(v).Interface()
 }
 
+// value_InterfaceDirect packs v into an interface without allocating.
+// It reports false if v is not pointer-shaped, is a method value or
+// cannot be exposed as an interface, in which case a copy is required.
+func value_InterfaceDirect(v Value) (any, bool) {
+	if v.flag == 0 || v.flag&(flagMethod|flagRO) != 0 || ifaceIndir(v.typ) {
+		return nil, false
+	}
+	var i any
+	e := (*Value)(unsafe.Pointer(&i))
+	e.typ = v.typ
+	e.ptr = v.pointer()
+	return i, true
+}
+
+func (f flag) kind() Kind {
+	return Kind(f & flagKindMask)
+}
+
+// typeOf returns the type of v, which must be valid. Unlike v.typ, which
+// holds the receiver type for a method value, it is the func type of the
+// method value, as Type reports.
+func (v Value) typeOf() Type {
+	if v.flag&flagMethod != 0 {
+		return value_Type(v)
+	}
+	return v.typ
+}
+
+// data returns a pointer to the memory holding v's value.
+// v must not be a method value.
+func (v Value) data() unsafe.Pointer {
+	if v.flag&flagIndir != 0 {
+		return v.ptr
+	}
+	return unsafe.Pointer(&v.ptr)
+}
+
+// pointer returns the pointer held by v, whose type must be pointer-shaped.
+func (v Value) pointer() unsafe.Pointer {
+	if v.flag&flagIndir != 0 {
+		return *(*unsafe.Pointer)(v.ptr)
+	}
+	return v.ptr
+}
+
 func value_InterfaceData(v Value) [2]uintptr {
 	return toRV(v).InterfaceData()
 }
@@ -168,6 +217,31 @@ func value_Len(v Value) int {
 	return toRV(v).Len()
 }
 
+// mapaccess returns a pointer to the element for the key pointed to by
+// key in the map m of type t, or nil if the key is not present.
+//
+//go:linkname mapaccess reflect.mapaccess
+//go:noescape
+func mapaccess(t Type, m unsafe.Pointer, key unsafe.Pointer) unsafe.Pointer
+
+// hiter is the map iterator of mapiterinit and mapiternext. Only key and
+// elem are read; the rest is large enough for the runtime's iterator of
+// any supported Go version, with its pointer fields first.
+type hiter struct {
+	key  unsafe.Pointer // nil at the end of the iteration
+	elem unsafe.Pointer
+	_    [6]unsafe.Pointer
+	_    [6]uintptr
+}
+
+//go:linkname mapiterinit reflect.mapiterinit
+//go:noescape
+func mapiterinit(t Type, m unsafe.Pointer, it *hiter)
+
+//go:linkname mapiternext reflect.mapiternext
+//go:noescape
+func mapiternext(it *hiter)
+
 func value_MapIndex(v Value, key Value) Value {
 	return toV(toRV(v).MapIndex(toRV(key)))
 }
@@ -180,6 +254,21 @@ func value_MapRange(v Value) *MapIter {
 	return (*MapIter)(toRV(v).MapRange())
 }
 
+func value_RangeMap(v Value, fn func(key, val Value) bool) {
+	rv := toRV(v)
+	it := rv.MapRange()
+	rk := reflect.New(rv.Type().Key()).Elem()
+	re := reflect.New(rv.Type().Elem()).Elem()
+	key, val := toV(rk), toV(re)
+	for it.Next() {
+		rk.SetIterKey(it)
+		re.SetIterValue(it)
+		if !fn(key, val) {
+			return
+		}
+	}
+}
+
 func value_Method(v Value, i int) Value {
 	return toV(toRV(v).Method(i))
 }